@@ -89,6 +89,9 @@ func runMigrations() error {
 	// để tránh lỗi khi chuyển cột sang JSON (MySQL không cho phép index trực tiếp trên JSON).
 	dropLegacyArticleTagIndexes()
 
+	// type_key không còn unique đơn lẻ mà unique theo (type_key, locale)
+	dropIndexIfExists("homepage_sections", "idx_homepage_sections_type_key")
+
 	// QUAN TRỌNG: Chuyển đổi tag_id sang JSON TRƯỚC KHI chạy AutoMigrate
 	// Nếu không, AutoMigrate sẽ cố gắng MODIFY cột với dữ liệu không hợp lệ
 	if err := convertTagIDToJSON(); err != nil {
@@ -97,11 +100,34 @@ func runMigrations() error {
 
 	// Migration cho các bảng cần thiết
 	migrationOrder := []interface{}{
-		&model.User{},            // Tạo trước vì Article cần reference
-		&model.Category{},        // Danh mục phân cấp 3 cấp
-		&model.Tag{},             // Tag cho bài viết
-		&model.Article{},         // Bài viết thuộc danh mục và tag
-		&model.HomepageSection{}, // Các mục hiển thị ở trang chủ
+		&model.User{},                 // Tạo trước vì Article cần reference
+		&model.Category{},             // Danh mục phân cấp 3 cấp
+		&model.AuthorProfile{},        // Hồ sơ công khai của luật sư/thành viên (Article tham chiếu người thẩm định)
+		&model.Tag{},                  // Tag cho bài viết
+		&model.Article{},              // Bài viết thuộc danh mục và tag
+		&model.HomepageSection{},      // Các mục hiển thị ở trang chủ
+		&model.ArticleRelation{},      // Bài viết liên quan được ghim thủ công
+		&model.Series{},               // Chuỗi bài viết nhiều phần
+		&model.SeriesArticle{},        // Thứ tự bài viết trong chuỗi
+		&model.ConsultationRequest{},  // Yêu cầu tư vấn từ website
+		&model.ConsultationNote{},     // Ghi chú nội bộ trên yêu cầu tư vấn
+		&model.ArticleCoAuthor{},      // Đồng tác giả của bài viết
+		&model.ArticleComment{},       // Bình luận của độc giả
+		&model.ArticleFeedback{},      // Đánh giá bài viết có hữu ích không
+		&model.ArticleViewDaily{},     // Lượt xem bài viết theo ngày
+		&model.LegalDocument{},        // Thư viện văn bản pháp luật
+		&model.ArticleLegalDocument{}, // Văn bản được bài viết trích dẫn
+		&model.FAQ{},                  // Câu hỏi thường gặp
+		&model.ArticleFAQ{},           // Câu hỏi nhúng trong bài viết
+		&model.Subscriber{},           // Người đăng ký bản tin
+		&model.NewsletterDigest{},     // Lịch sử gửi bản tin
+		&model.MediaAsset{},           // Thư viện media (file đã upload và xác nhận)
+		&model.MediaUsage{},           // Chỉ mục nội dung đang dùng file
+		&model.Upload{},               // Sổ upload để tính quota
+		&model.UploadQuotaLock{},      // Dòng khóa khi xét quota upload
+		&model.UploadTicket{},         // Vé upload công khai dùng một lần
+		&model.MediaDownload{},        // Nhật ký tải file riêng tư/tệp đính kèm
+		&model.MultipartUpload{},      // Phiên upload nhiều phần đang mở
 	}
 
 	// Migrate từng model một cách tuần tự
@@ -116,6 +142,15 @@ func runMigrations() error {
 	DB.Exec("UPDATE articles SET is_active = 1 WHERE is_active IS NULL")
 	DB.Exec("UPDATE articles SET is_hot = 0 WHERE is_hot IS NULL")
 
+	// Dữ liệu cũ: gán ngôn ngữ mặc định và nhóm bản dịch là chính bản ghi
+	for _, table := range []string{"articles", "categories", "tags"} {
+		DB.Exec(fmt.Sprintf("UPDATE %s SET locale = 'vi' WHERE locale IS NULL OR locale = ''", table))
+		DB.Exec(fmt.Sprintf("UPDATE %s SET translation_group_id = id WHERE translation_group_id IS NULL", table))
+	}
+	DB.Exec("UPDATE homepage_sections SET locale = 'vi' WHERE locale IS NULL OR locale = ''")
+	// Section cũ: type_key vừa là định danh vừa là loại section
+	DB.Exec("UPDATE homepage_sections SET section_type = type_key WHERE section_type IS NULL OR section_type = ''")
+
 	// Ensure all UUID columns have the same charset and collation
 	log.Println("🔄 Fixing column charset and collation...")
 
@@ -333,6 +368,11 @@ func createDefaultData() error {
 		log.Println("✅ Default categories created successfully")
 	}
 
+	// Tạo bản tiếng Anh cho các danh mục chính
+	if err := createDefaultEnglishCategories(); err != nil {
+		log.Printf("⚠️  Warning: Failed to create English categories: %v", err)
+	}
+
 	// Tạo Homepage Sections mặc định
 	if err := createDefaultHomepageSections(); err != nil {
 		log.Printf("⚠️  Warning: Failed to create homepage sections: %v", err)
@@ -341,6 +381,55 @@ func createDefaultData() error {
 	return nil
 }
 
+// createDefaultEnglishCategories tạo bản tiếng Anh cho 6 danh mục chính (phục vụ khách hàng nước ngoài)
+func createDefaultEnglishCategories() error {
+	var enCount int64
+	DB.Model(&model.Category{}).Where("locale = ?", "en").Count(&enCount)
+	if enCount > 0 {
+		return nil
+	}
+
+	translations := []struct {
+		SourceSlug  string
+		Name        string
+		Slug        string
+		Description string
+	}{
+		{"xay-dung", "Construction", "construction", "Legal issues in construction"},
+		{"doanh-nghiep-va-dau-tu", "Business and Investment", "business-and-investment", "Enterprise and investment law for domestic and foreign investors"},
+		{"dat-quoc-phong-ket-hop-kinh-te", "Defense Land Combined with Economic Activities", "defense-land-economic-activities", "Law on defense land used for economic activities"},
+		{"lao-dong", "Labor", "labor", "Labor law and employment relations"},
+		{"hinh-su", "Criminal", "criminal", "Criminal law and criminal procedure"},
+		{"giai-quyet-tranh-chap", "Dispute Resolution", "dispute-resolution", "Dispute resolution matters"},
+	}
+
+	log.Println("🔄 Creating English categories...")
+	for _, t := range translations {
+		var source model.Category
+		if err := DB.Where("slug = ? AND locale = ?", t.SourceSlug, "vi").First(&source).Error; err != nil {
+			continue
+		}
+		groupID := source.ID
+		if source.TranslationGroupID != nil {
+			groupID = *source.TranslationGroupID
+		}
+		category := model.Category{
+			Name:               t.Name,
+			Slug:               t.Slug,
+			Description:        t.Description,
+			IsActive:           source.IsActive,
+			DisplayOrder:       source.DisplayOrder,
+			Locale:             "en",
+			TranslationGroupID: &groupID,
+		}
+		if err := DB.Create(&category).Error; err != nil {
+			log.Printf("⚠️  Warning: Failed to create category %s: %v", category.Name, err)
+		}
+	}
+	log.Println("✅ English categories created successfully")
+	return nil
+}
+
 func GetDB() *gorm.DB {
 	return DB
 }
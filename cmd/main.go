@@ -2,15 +2,38 @@ package main
 
 import (
 	"backend/app"
+	"backend/internal/analytics"
+	"backend/internal/handle"
+	"backend/internal/helpers"
+	"backend/internal/legalreview"
+	"backend/internal/newsletter"
 	"backend/router"
 	"backend/utils"
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
 
+// trustedProxies đọc TRUSTED_PROXIES; rỗng là không tin proxy nào
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -27,9 +50,38 @@ func main() {
 		gin.SetMode(gin.ReleaseMode) // Mặc định tắt debug logs
 	}
 
+	// Định dạng variant ảnh phải có encoder (thư viện chuẩn chỉ có jpeg và png)
+	if err := handle.ValidateMediaImageFormat(); err != nil {
+		log.Fatalf("Invalid MEDIA_IMAGE_FORMAT: %v", err)
+	}
+
+	// Job nhắc rà soát nội dung pháp lý (bài quá hạn review_by hoặc trích dẫn văn bản hết hiệu lực)
+	legalreview.Start()
+
+	// Gửi bản tin định kỳ (tắt mặc định, bật bằng NEWSLETTER_DIGEST_INTERVAL_HOURS)
+	newsletter.Start()
+
+	// Dọn file upload không còn được dùng (tắt mặc định, bật bằng MEDIA_GC_INTERVAL_HOURS)
+	handle.StartMediaGC()
+
+	// Quét lại file upload đang chờ kiểm tra/quét mã độc (MEDIA_SCAN_INTERVAL_MINUTES, mặc định 5 phút)
+	handle.StartMediaScan()
+
+	// Hủy phiên upload nhiều phần quá hạn (MULTIPART_CLEANUP_INTERVAL_MINUTES, mặc định 60 phút)
+	handle.StartMultipartCleanup()
+
+	// Dùng tên trường theo tag json trong lỗi validate
+	helpers.RegisterValidatorTagNames()
+
 	// Initialize Gin router
 	r := gin.New()
 
+	// Chỉ tin X-Forwarded-For/X-Real-IP từ các proxy trong TRUSTED_PROXIES (danh sách IP/CIDR, phân cách bằng dấu phẩy).
+	// Mặc định không tin proxy nào: IP client là địa chỉ kết nối, không giả mạo được để vượt rate limit
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Add basic middleware
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
@@ -51,12 +103,34 @@ func main() {
 	if port == "" {
 		port = "8080" // Default port changed from 3000 to 8080
 	}
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
+
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		// log.Printf("Server will be available at: http://localhost:%s", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to start server on port %s: %v", port, err)
+			log.Println("Tip: Port might be in use. Try changing PORT in .env file")
+			log.Fatal(err)
+		}
+	}()
 
-	log.Printf("Server starting on port %s", port)
-	// log.Printf("Server will be available at: http://localhost:%s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Printf("Failed to start server on port %s: %v", port, err)
-		log.Println("Tip: Port might be in use. Try changing PORT in .env file")
-		log.Fatal(err)
+	// Tắt server êm: ngừng nhận kết nối mới, chờ các request đang xử lý xong (tối đa 30 giây)
+	// rồi ghi nốt lượt xem đang gom trong bộ nhớ
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("⚠️  Warning: Server shutdown did not finish cleanly: %v", err)
+	}
+	if err := analytics.Views().Flush(); err != nil {
+		log.Printf("⚠️  Warning: Failed to flush article views on shutdown: %v", err)
 	}
 }
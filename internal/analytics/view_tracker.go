@@ -0,0 +1,191 @@
+package analytics
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/repo"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// botPattern nhận diện User-Agent của bot, crawler, công cụ xem trước liên kết và client dòng lệnh
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|scrape|preview|facebookexternalhit|embedly|headless|lighthouse|pingdom|uptime|monitor|curl|wget|python-requests|go-http-client|java/|okhttp|axios|node-fetch|postman`)
+
+type viewKey struct {
+	ArticleID uuid.UUID
+	Date      string // YYYY-MM-DD theo giờ server
+}
+
+// ViewTracker gom lượt xem trong bộ nhớ và ghi xuống DB theo lô.
+// Lượt xem của bot và của nhân viên đã đăng nhập bị bỏ qua; mỗi khách chỉ được tính
+// một lượt cho mỗi bài viết trong một cửa sổ thời gian (dedupWindow).
+type ViewTracker struct {
+	repo          *repo.ArticleViewRepo
+	flushInterval time.Duration
+	dedupWindow   time.Duration
+	maxPending    int
+
+	mu      sync.Mutex
+	pending map[viewKey]int64
+	seen    map[string]time.Time // visitorHash|articleID -> hết hạn
+	flushMu sync.Mutex
+	flushCh chan struct{}
+}
+
+// NewViewTracker đọc cấu hình từ env:
+// VIEW_FLUSH_INTERVAL_SECONDS (mặc định 30), VIEW_DEDUP_WINDOW_MINUTES (mặc định 30),
+// VIEW_BUFFER_MAX_KEYS (mặc định 1000, vượt ngưỡng sẽ ghi sớm)
+func NewViewTracker(viewRepo *repo.ArticleViewRepo) *ViewTracker {
+	return &ViewTracker{
+		repo:          viewRepo,
+		flushInterval: envDuration("VIEW_FLUSH_INTERVAL_SECONDS", 30, time.Second),
+		dedupWindow:   envDuration("VIEW_DEDUP_WINDOW_MINUTES", 30, time.Minute),
+		maxPending:    envInt("VIEW_BUFFER_MAX_KEYS", 1000),
+		pending:       map[viewKey]int64{},
+		seen:          map[string]time.Time{},
+		flushCh:       make(chan struct{}, 1),
+	}
+}
+
+// Start chạy vòng lặp ghi định kỳ ở goroutine riêng
+func (t *ViewTracker) Start() {
+	go func() {
+		ticker := time.NewTicker(t.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-t.flushCh:
+			}
+			if err := t.Flush(); err != nil {
+				log.Printf("⚠️  Warning: Failed to flush article views: %v", err)
+			}
+		}
+	}()
+}
+
+// Record ghi nhận một lượt xem bài viết từ request công khai
+func (t *ViewTracker) Record(c *gin.Context, articleID uuid.UUID) {
+	if IsBot(c.Request.UserAgent()) || isStaffRequest(c) {
+		return
+	}
+
+	now := time.Now()
+	seenKey := helpers.VisitorHash(c) + "|" + articleID.String()
+
+	t.mu.Lock()
+	if expiresAt, ok := t.seen[seenKey]; ok && now.Before(expiresAt) {
+		t.mu.Unlock()
+		return
+	}
+	t.seen[seenKey] = now.Add(t.dedupWindow)
+	t.pending[viewKey{ArticleID: articleID, Date: now.Format("2006-01-02")}]++
+	full := len(t.pending) >= t.maxPending
+	t.mu.Unlock()
+
+	if full {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush ghi toàn bộ lượt xem đang chờ xuống DB. Nếu ghi lỗi, lượt xem được trả lại hàng đợi.
+func (t *ViewTracker) Flush() error {
+	t.flushMu.Lock()
+	defer t.flushMu.Unlock()
+
+	now := time.Now()
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = map[viewKey]int64{}
+	for k, expiresAt := range t.seen {
+		if now.After(expiresAt) {
+			delete(t.seen, k)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	deltas := make([]repo.ArticleViewDelta, 0, len(batch))
+	for key, views := range batch {
+		date, _ := time.ParseInLocation("2006-01-02", key.Date, time.Local)
+		deltas = append(deltas, repo.ArticleViewDelta{
+			ArticleID: key.ArticleID,
+			ViewDate:  date,
+			Views:     views,
+		})
+	}
+
+	if err := t.repo.ApplyDeltas(deltas); err != nil {
+		t.mu.Lock()
+		for key, views := range batch {
+			t.pending[key] += views
+		}
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// IsBot kiểm tra User-Agent có phải bot/crawler (User-Agent rỗng cũng coi là bot)
+func IsBot(userAgent string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || botPattern.MatchString(userAgent)
+}
+
+// isStaffRequest - request mang JWT hợp lệ với vai trò quản trị là của nhân viên CMS (xem trước bài viết), không tính lượt xem.
+// Tài khoản tự đăng ký (role user) vẫn được tính như độc giả
+func isStaffRequest(c *gin.Context) bool {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" || token == c.GetHeader("Authorization") {
+		return false
+	}
+	parsed, err := helpers.ValidateJWT(token)
+	if err != nil || !parsed.Valid {
+		return false
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	role, _ := claims["role"].(string)
+	return helpers.IsStaffRole(role)
+}
+
+var (
+	defaultTracker *ViewTracker
+	defaultOnce    sync.Once
+)
+
+// Views trả về bộ đếm lượt xem dùng chung, khởi động vòng lặp ghi ở lần gọi đầu tiên
+func Views() *ViewTracker {
+	defaultOnce.Do(func() {
+		defaultTracker = NewViewTracker(repo.NewArticleViewRepo())
+		defaultTracker.Start()
+	})
+	return defaultTracker
+}
+
+func envInt(name string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+func envDuration(name string, def int, unit time.Duration) time.Duration {
+	return time.Duration(envInt(name, def)) * unit
+}
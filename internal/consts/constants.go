@@ -9,16 +9,8 @@ const (
 	ROLE_ADMIN = "admin"
 	ROLE_USER  = "user"
 
-	// Thông báo phản hồi
-	MSG_SUCCESS             = "Success"
-	MSG_INVALID_CREDENTIALS = "Invalid credentials"
-	MSG_USER_NOT_FOUND      = "User not found"
-	MSG_EMAIL_EXISTS        = "Email already exists"
-	MSG_USERNAME_EXISTS     = "Username already exists"
-	MSG_UNAUTHORIZED        = "Unauthorized"
-	MSG_FORBIDDEN           = "Forbidden"
-	MSG_INTERNAL_ERROR      = "Internal server error"
-	MSG_VALIDATION_ERROR    = "Validation error"
+	// Thông báo phản hồi thành công (thông báo lỗi nằm trong catalog helpers.Err*)
+	MSG_SUCCESS = "Success"
 )
 
 // Vai trò người dùng
@@ -77,3 +69,118 @@ func CanManageRole(userRole, targetRole string) bool {
 
 	return false
 }
+
+// Ngôn ngữ nội dung
+const (
+	LocaleVI      = "vi"
+	LocaleEN      = "en"
+	DefaultLocale = LocaleVI // Ngôn ngữ mặc định khi client không chỉ định hoặc chưa có bản dịch
+)
+
+// Danh sách ngôn ngữ được hỗ trợ
+var SupportedLocales = []string{LocaleVI, LocaleEN}
+
+// IsSupportedLocale kiểm tra locale có nằm trong danh sách hỗ trợ không
+func IsSupportedLocale(locale string) bool {
+	for _, l := range SupportedLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Trạng thái yêu cầu tư vấn
+const (
+	ConsultationStatusNew       = "new"
+	ConsultationStatusAssigned  = "assigned"
+	ConsultationStatusContacted = "contacted"
+	ConsultationStatusClosed    = "closed"
+)
+
+// Danh sách trạng thái yêu cầu tư vấn hợp lệ
+var ConsultationStatuses = []string{
+	ConsultationStatusNew,
+	ConsultationStatusAssigned,
+	ConsultationStatusContacted,
+	ConsultationStatusClosed,
+}
+
+// Trạng thái bình luận
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+	CommentStatusSpam     = "spam"
+)
+
+// Danh sách trạng thái bình luận hợp lệ
+var CommentStatuses = []string{
+	CommentStatusPending,
+	CommentStatusApproved,
+	CommentStatusRejected,
+	CommentStatusSpam,
+}
+
+// Trạng thái bài viết
+const (
+	ArticleStatusDraft = "draft"
+	ArticleStatusPost  = "post"
+)
+
+// Trạng thái bài viết được coi là đã xuất bản (kèm is_active mới hiển thị công khai)
+var ArticlePublishedStatuses = []string{ArticleStatusPost}
+
+// IsPublishedArticleStatus kiểm tra trạng thái bài viết có thuộc nhóm đã xuất bản không
+func IsPublishedArticleStatus(status string) bool {
+	for _, s := range ArticlePublishedStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Hiệu lực văn bản pháp luật
+const (
+	LegalDocStatusInForce = "in_force" // Còn hiệu lực
+	LegalDocStatusExpired = "expired"  // Hết hiệu lực
+	LegalDocStatusAmended = "amended"  // Đã được sửa đổi, bổ sung
+)
+
+// Danh sách trạng thái hiệu lực văn bản hợp lệ
+var LegalDocStatuses = []string{
+	LegalDocStatusInForce,
+	LegalDocStatusExpired,
+	LegalDocStatusAmended,
+}
+
+// Loại văn bản pháp luật
+const (
+	LegalDocTypeLaw              = "law"               // Luật
+	LegalDocTypeDecree           = "decree"            // Nghị định
+	LegalDocTypeCircular         = "circular"          // Thông tư
+	LegalDocTypeContractTemplate = "contract_template" // Mẫu hợp đồng
+)
+
+// Tên hiển thị của loại văn bản (dùng cho bộ lọc)
+var LegalDocTypeLabels = map[string]string{
+	LegalDocTypeLaw:              "Luật",
+	LegalDocTypeDecree:           "Nghị định",
+	LegalDocTypeCircular:         "Thông tư",
+	LegalDocTypeContractTemplate: "Mẫu hợp đồng",
+}
+
+// Trạng thái người đăng ký bản tin
+const (
+	SubscriberStatusPending      = "pending"      // Chờ xác nhận email (double opt-in)
+	SubscriberStatusConfirmed    = "confirmed"    // Đã xác nhận, nhận bản tin
+	SubscriberStatusUnsubscribed = "unsubscribed" // Đã hủy đăng ký
+)
+
+// Danh sách trạng thái người đăng ký hợp lệ
+var SubscriberStatuses = []string{
+	SubscriberStatusPending,
+	SubscriberStatusConfirmed,
+	SubscriberStatusUnsubscribed,
+}
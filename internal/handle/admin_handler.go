@@ -55,7 +55,7 @@ func (h *AdminHandler) GetAllUsers(c *gin.Context) {
 	}
 	users, total, err := h.userRepo.GetUsersByRolesWithPagination(roles, name, phone, email, page, limit)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -83,17 +83,17 @@ func (h *AdminHandler) GetUserByID(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.BadRequestResponse(c, "ID người dùng không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, nil)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -104,7 +104,7 @@ func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.BadRequestResponse(c, "ID người dùng không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, nil)
 		return
 	}
 
@@ -113,23 +113,23 @@ func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	user.Role = input.Role
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -140,23 +140,23 @@ func (h *AdminHandler) ToggleUserStatus(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.BadRequestResponse(c, "ID người dùng không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, nil)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	user.IsActive = !user.IsActive
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -167,22 +167,22 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.BadRequestResponse(c, "ID người dùng không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, nil)
 		return
 	}
 
 	_, err = h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	if err := h.userRepo.DeleteUser(userID); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -193,19 +193,19 @@ func (h *AdminHandler) DeleteUser(c *gin.Context) {
 func (h *AdminHandler) CreateUser(c *gin.Context) {
 	currentUserRole, exists := c.Get("user_role")
 	if !exists {
-		helpers.UnauthorizedResponse(c, "Chưa xác thực")
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	// Chỉ super_admin mới có thể tạo người dùng
 	if currentUserRole != "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không đủ quyền", nil)
+		helpers.ErrorResponse(c, helpers.ErrForbidden, nil)
 		return
 	}
 
 	var input model.CreateUserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -213,31 +213,31 @@ func (h *AdminHandler) CreateUser(c *gin.Context) {
 	if input.Role == "super_admin" {
 		exists, err := h.userRepo.CheckSuperAdminExists()
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 			return
 		}
 		if exists {
-			helpers.ErrorResponse(c, http.StatusConflict, "Chỉ được phép có một tài khoản Super Admin", nil)
+			helpers.ErrorResponse(c, helpers.ErrSuperAdminExists, nil)
 			return
 		}
 	}
 
 	// Kiểm tra username đã tồn tại
 	if h.userRepo.IsUsernameExists(input.Username) {
-		helpers.ErrorResponse(c, http.StatusConflict, "Tên người dùng đã tồn tại", nil)
+		helpers.ErrorResponse(c, helpers.ErrUsernameExists, nil)
 		return
 	}
 
 	// Kiểm tra email đã tồn tại
 	if h.userRepo.IsEmailExists(input.Email) {
-		helpers.ErrorResponse(c, http.StatusConflict, "Email đã tồn tại", nil)
+		helpers.ErrorResponse(c, helpers.ErrEmailExists, nil)
 		return
 	}
 
 	// Mã hóa mật khẩu
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể mã hóa mật khẩu", err)
+		helpers.ErrorResponse(c, helpers.ErrPasswordHashFailed, err)
 		return
 	}
 
@@ -270,7 +270,7 @@ func (h *AdminHandler) CreateUser(c *gin.Context) {
 	}
 
 	if err := h.userRepo.CreateUser(&user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tạo người dùng", err)
+		helpers.ErrorResponse(c, helpers.ErrUserCreateFailed, err)
 		return
 	}
 
@@ -285,13 +285,13 @@ func (h *AdminHandler) CreateUser(c *gin.Context) {
 func (h *AdminHandler) GetUsersByRole(c *gin.Context) {
 	currentUserRole, exists := c.Get("user_role")
 	if !exists {
-		helpers.UnauthorizedResponse(c, "Chưa xác thực")
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	// Chỉ super_admin mới có quyền
 	if currentUserRole != "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không đủ quyền", nil)
+		helpers.ErrorResponse(c, helpers.ErrForbidden, nil)
 		return
 	}
 
@@ -311,7 +311,7 @@ func (h *AdminHandler) GetUsersByRole(c *gin.Context) {
 
 	users, total, err := h.userRepo.GetUsersByRole(role, page, limit)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách người dùng", err)
+		helpers.ErrorResponse(c, helpers.ErrUserListFailed, err)
 		return
 	}
 
@@ -341,63 +341,63 @@ func (h *AdminHandler) GetUsersByRole(c *gin.Context) {
 func (h *AdminHandler) AssignUserRole(c *gin.Context) {
 	currentUserID, exists := c.Get("userID")
 	if !exists {
-		helpers.UnauthorizedResponse(c, "Chưa xác thực")
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	currentUserRole, exists := c.Get("user_role")
 	if !exists {
-		helpers.UnauthorizedResponse(c, "Chưa xác thực")
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	// Chỉ super_admin mới có quyền
 	if currentUserRole != "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không đủ quyền", nil)
+		helpers.ErrorResponse(c, helpers.ErrForbidden, nil)
 		return
 	}
 
 	targetUserIDStr := c.Param("id")
 	targetUserID, err := uuid.Parse(targetUserIDStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID người dùng không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, err)
 		return
 	}
 
 	var input model.UpdateUserRoleInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	// Kiểm tra có thể quản lý user đích không
 	canManage, err := h.userRepo.CheckUserCanManage(currentUserID.(uuid.UUID), targetUserID)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
 	if !canManage {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không thể quản lý người dùng này", nil)
+		helpers.ErrorResponse(c, helpers.ErrCannotManageUser, nil)
 		return
 	}
 
 	// Không cho phép tạo thêm super_admin
 	if input.Role == "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không thể gán vai trò Super Admin", nil)
+		helpers.ErrorResponse(c, helpers.ErrCannotAssignSuperAdmin, nil)
 		return
 	}
 
 	// Cập nhật vai trò
 	if err := h.userRepo.UpdateUserRole(targetUserID, input.Role); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể cập nhật vai trò người dùng", err)
+		helpers.ErrorResponse(c, helpers.ErrUserRoleUpdateFailed, err)
 		return
 	}
 
 	// Lấy thông tin đã cập nhật
 	updatedUser, err := h.userRepo.GetUserByID(targetUserID)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy thông tin người dùng đã cập nhật", err)
+		helpers.ErrorResponse(c, helpers.ErrUserReloadFailed, err)
 		return
 	}
 
@@ -412,19 +412,19 @@ func (h *AdminHandler) AssignUserRole(c *gin.Context) {
 func (h *AdminHandler) GetUserStats(c *gin.Context) {
 	currentUserRole, exists := c.Get("user_role")
 	if !exists {
-		helpers.UnauthorizedResponse(c, "Chưa xác thực")
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	// Chỉ super_admin mới có quyền
 	if currentUserRole != "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Không đủ quyền", nil)
+		helpers.ErrorResponse(c, helpers.ErrForbidden, nil)
 		return
 	}
 
 	stats, err := h.userRepo.GetUserStats()
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy thống kê người dùng", err)
+		helpers.ErrorResponse(c, helpers.ErrUserStatsFailed, err)
 		return
 	}
 
@@ -440,30 +440,30 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID người dùng không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, err)
 		return
 	}
 
 	var input model.AdminUserUpdateInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	// Chỉ super_admin mới được sửa thông tin
 	currentUserRole, exists := c.Get("user_role")
 	if !exists || currentUserRole != "super_admin" {
-		helpers.ErrorResponse(c, http.StatusForbidden, "Chỉ Super Admin mới được sửa thông tin người dùng", nil)
+		helpers.ErrorResponse(c, helpers.ErrSuperAdminOnlyEdit, nil)
 		return
 	}
 
@@ -473,7 +473,7 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 	}
 	if input.Email != "" && input.Email != user.Email {
 		if h.userRepo.IsEmailExists(input.Email) {
-			helpers.ErrorResponse(c, http.StatusBadRequest, consts.MSG_EMAIL_EXISTS, nil)
+			helpers.ErrorResponse(c, helpers.ErrEmailExists, nil)
 			return
 		}
 		user.Email = input.Email
@@ -500,7 +500,7 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 	}
 
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -512,37 +512,37 @@ func (h *AdminHandler) ResetUserPassword(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.BadRequestResponse(c, "ID người dùng không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidUserID, nil)
 		return
 	}
 
 	var input model.ResetPasswordInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	// Frontend sends { "password": "..." }
 	newPwd := input.Password
 	if newPwd == "" {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ErrorResponse(c, helpers.ErrValidation, nil)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	// Hash new password
 	hashed, err := helpers.HashPassword(newPwd)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 	user.Password = hashed
@@ -550,7 +550,7 @@ func (h *AdminHandler) ResetUserPassword(c *gin.Context) {
 	user.PasswordChangedAt = &now
 
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -0,0 +1,176 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ArticleAuthorHandler quản lý tác giả chính, đồng tác giả và người thẩm định của bài viết
+type ArticleAuthorHandler struct {
+	articleRepo *repo.ArticleRepo
+	profileRepo *repo.AuthorProfileRepo
+	userRepo    *repo.UserRepository
+}
+
+func NewArticleAuthorHandler(userRepo *repo.UserRepository) *ArticleAuthorHandler {
+	return &ArticleAuthorHandler{
+		articleRepo: repo.NewArticleRepo(),
+		profileRepo: repo.NewAuthorProfileRepo(),
+		userRepo:    userRepo,
+	}
+}
+
+// SetArticleAttribution cập nhật đồng tác giả (theo thứ tự) và người thẩm định pháp lý
+func (h *ArticleAuthorHandler) SetArticleAttribution(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.ArticleAttributionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	// Đồng tác giả: không trùng lặp, tồn tại và không phải hồ sơ của tác giả chính
+	seen := make(map[uuid.UUID]struct{}, len(input.CoAuthorIDs))
+	for _, profileID := range input.CoAuthorIDs {
+		if _, ok := seen[profileID]; ok {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCoAuthor, fmt.Errorf("đồng tác giả %s xuất hiện nhiều lần", profileID))
+			return
+		}
+		seen[profileID] = struct{}{}
+	}
+	profiles, err := h.profileRepo.GetByIDs(input.CoAuthorIDs)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if len(profiles) != len(input.CoAuthorIDs) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidCoAuthor, errors.New("một hoặc nhiều hồ sơ đồng tác giả không tồn tại"))
+		return
+	}
+	for _, profile := range profiles {
+		if profile.UserID != nil && *profile.UserID == article.AuthorID {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCoAuthor, errors.New("tác giả chính không thể là đồng tác giả"))
+			return
+		}
+	}
+
+	fields := map[string]interface{}{
+		"reviewed_by_id": nil,
+		"reviewed_at":    nil,
+	}
+	if input.ReviewedByID != nil {
+		if _, err := h.profileRepo.GetByID(*input.ReviewedByID); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidReviewer, err)
+			return
+		}
+		reviewedAt := time.Now()
+		if input.ReviewedAt != nil {
+			reviewedAt = *input.ReviewedAt
+		}
+		fields["reviewed_by_id"] = *input.ReviewedByID
+		fields["reviewed_at"] = reviewedAt
+	}
+
+	if err := h.articleRepo.SetAttribution(id, input.CoAuthorIDs, fields); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleAttributionFailed, err)
+		return
+	}
+
+	invalidateRelatedCache()
+	invalidateHomepageCache()
+
+	updated, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleFetchFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật tác giả bài viết thành công", updated.ToResponse())
+}
+
+// ReassignArticleAuthor chuyển tác giả chính của bài viết sang User khác (chỉ Super Admin)
+func (h *ArticleAuthorHandler) ReassignArticleAuthor(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.ArticleAuthorInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	user, err := h.userRepo.GetUserByID(input.AuthorID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleAuthor, err)
+		return
+	}
+	if !user.IsActive {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleAuthor, errors.New("tài khoản tác giả đã bị vô hiệu hóa"))
+		return
+	}
+
+	if err := h.articleRepo.UpdateAttribution(id, map[string]interface{}{"author_id": user.ID}); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleAttributionFailed, err)
+		return
+	}
+
+	updated, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleFetchFailed, err)
+		return
+	}
+
+	// Tác giả chính mới không đồng thời là đồng tác giả
+	if updated.Author != nil && updated.Author.Profile != nil {
+		var coAuthorIDs []uuid.UUID
+		removed := false
+		for _, coAuthor := range updated.CoAuthors {
+			if coAuthor.AuthorProfileID == updated.Author.Profile.ID {
+				removed = true
+				continue
+			}
+			coAuthorIDs = append(coAuthorIDs, coAuthor.AuthorProfileID)
+		}
+		if removed {
+			if err := h.articleRepo.SetCoAuthors(id, coAuthorIDs); err != nil {
+				helpers.ErrorResponse(c, helpers.ErrArticleAttributionFailed, err)
+				return
+			}
+			if updated, err = h.articleRepo.GetByID(id); err != nil {
+				helpers.ErrorResponse(c, helpers.ErrArticleFetchFailed, err)
+				return
+			}
+		}
+	}
+
+	invalidateRelatedCache()
+	invalidateHomepageCache()
+
+	helpers.SuccessResponse(c, "Chuyển tác giả chính thành công", updated.ToResponse())
+}
@@ -1,11 +1,14 @@
 package handle
 
 import (
+	"backend/internal/analytics"
+	"backend/internal/consts"
 	"backend/internal/helpers"
 	"backend/internal/model"
 	"backend/internal/repo"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -19,22 +22,35 @@ type ArticleHandler struct {
 	articleRepo  *repo.ArticleRepo
 	categoryRepo *repo.CategoryRepo
 	tagRepo      *repo.TagRepo
+	seriesRepo   *repo.SeriesRepo
+	documentRepo *repo.LegalDocumentRepo
+	faqRepo      *repo.FAQRepo
+	mediaRepo    *repo.MediaRepo
 }
 
 func normalizeArticleStatus(status *string) (string, error) {
 	if status == nil || strings.TrimSpace(*status) == "" {
-		return "draft", nil
+		return consts.ArticleStatusDraft, nil
 	}
 
 	val := strings.ToLower(strings.TrimSpace(*status))
-	switch val {
-	case "draft":
-		return "draft", nil
-	case "post":
-		return "post", nil
-	default:
-		return "", errors.New("status phải là 'draft' hoặc 'post'")
+	if val == consts.ArticleStatusDraft || consts.IsPublishedArticleStatus(val) {
+		return val, nil
 	}
+	return "", fmt.Errorf("status phải là '%s' hoặc một trong '%s'",
+		consts.ArticleStatusDraft, strings.Join(consts.ArticlePublishedStatuses, "', '"))
+}
+
+// normalizeContentLocale chuẩn hóa locale của nội dung, mặc định là ngôn ngữ mặc định
+func normalizeContentLocale(locale string) (string, error) {
+	if strings.TrimSpace(locale) == "" {
+		return consts.DefaultLocale, nil
+	}
+	normalized := helpers.NormalizeLocale(locale)
+	if normalized == "" {
+		return "", fmt.Errorf("locale phải là một trong: %s", strings.Join(consts.SupportedLocales, ", "))
+	}
+	return normalized, nil
 }
 
 func NewArticleHandler() *ArticleHandler {
@@ -42,9 +58,45 @@ func NewArticleHandler() *ArticleHandler {
 		articleRepo:  repo.NewArticleRepo(),
 		categoryRepo: repo.NewCategoryRepo(),
 		tagRepo:      repo.NewTagRepo(),
+		seriesRepo:   repo.NewSeriesRepo(),
+		documentRepo: repo.NewLegalDocumentRepo(),
+		faqRepo:      repo.NewFAQRepo(),
+		mediaRepo:    repo.NewMediaRepo(),
 	}
 }
 
+// resolveAttachments kiểm tra tệp đính kèm chọn từ thư viện media (file phải đã quét sạch, tệp chỉ dành cho
+// người đăng ký phải là file riêng tư), bỏ tệp trùng và điền thông tin file từ thư viện
+func (h *ArticleHandler) resolveAttachments(input []model.ArticleAttachment) ([]model.ArticleAttachment, error) {
+	attachments := make([]model.ArticleAttachment, 0, len(input))
+	seen := map[uuid.UUID]bool{}
+	for _, attachment := range input {
+		if seen[attachment.MediaID] {
+			continue
+		}
+		seen[attachment.MediaID] = true
+
+		asset, err := h.mediaRepo.GetByID(attachment.MediaID)
+		if err != nil {
+			return nil, fmt.Errorf("không tìm thấy file %s trong thư viện media", attachment.MediaID)
+		}
+		if asset.Status != model.MediaStatusClean {
+			return nil, fmt.Errorf("file %s chưa được kiểm tra xong hoặc đã bị từ chối", asset.FileName)
+		}
+		if attachment.SubscribersOnly && !asset.IsPrivate() {
+			return nil, fmt.Errorf("file %s đang công khai, chuyển sang riêng tư trước khi chỉ cho người đăng ký tải", asset.FileName)
+		}
+
+		attachment.Title = strings.TrimSpace(attachment.Title)
+		attachment.Key = asset.Key
+		attachment.FileName = asset.FileName
+		attachment.ContentType = asset.ContentType
+		attachment.Size = asset.Size
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
 // attachTagNamesToResponses thêm trường TagNames (tên của tags) vào các ArticleResponse
 func (h *ArticleHandler) attachTagNamesToResponses(responses []model.ArticleResponse) {
 	// collect unique tag ids
@@ -99,18 +151,56 @@ func (h *ArticleHandler) attachTagNamesToResponse(resp *model.ArticleResponse) {
 	resp.TagNames = names
 }
 
+// attachSeriesToResponses đính kèm vị trí trong chuỗi bài viết cho các ArticleResponse
+// publishedOnly = true: chỉ tính các phần đã xuất bản (dùng cho API công khai)
+func (h *ArticleHandler) attachSeriesToResponses(responses []model.ArticleResponse, publishedOnly bool) {
+	if len(responses) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(responses))
+	for _, r := range responses {
+		ids = append(ids, r.ID)
+	}
+
+	seriesList, err := h.seriesRepo.GetByArticleIDs(ids, publishedOnly)
+	if err != nil {
+		return
+	}
+
+	for i := range seriesList {
+		parts := seriesList[i].Parts(publishedOnly)
+		for j := range responses {
+			if responses[j].Series != nil {
+				continue
+			}
+			responses[j].Series = model.PositionOf(&seriesList[i], parts, responses[j].ID)
+		}
+	}
+}
+
+// attachSeriesToResponse đính kèm vị trí trong chuỗi cho một ArticleResponse đơn lẻ
+func (h *ArticleHandler) attachSeriesToResponse(resp *model.ArticleResponse, publishedOnly bool) {
+	if resp == nil {
+		return
+	}
+	responses := []model.ArticleResponse{*resp}
+	h.attachSeriesToResponses(responses, publishedOnly)
+	resp.Series = responses[0].Series
+}
+
 // CreateArticle tạo bài viết mới
 func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	var input model.ArticleInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	// Lấy author ID từ context (user đang đăng nhập)
 	authorIDInterface, exists := c.Get("userID")
 	if !exists {
-		helpers.ErrorResponse(c, http.StatusUnauthorized, "Không xác định được người dùng", errors.New("userID not found in context"))
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, errors.New("userID not found in context"))
 		return
 	}
 	authorID := authorIDInterface.(uuid.UUID)
@@ -121,11 +211,11 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	// Kiểm tra slug
 	exists, err := h.articleRepo.CheckSlugExists(input.Slug, uuid.Nil)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("bài viết với slug này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("bài viết với slug này đã tồn tại"))
 		return
 	}
 
@@ -133,7 +223,7 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	if input.CategoryID != nil {
 		_, err := h.categoryRepo.GetByID(*input.CategoryID)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "Danh mục không hợp lệ", errors.New("không tìm thấy danh mục"))
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategory, errors.New("không tìm thấy danh mục"))
 			return
 		}
 	}
@@ -141,10 +231,39 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	// Validate và set status
 	status, err := normalizeArticleStatus(input.Status)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Status không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleStatus, err)
+		return
+	}
+
+	locale, err := normalizeContentLocale(input.Locale)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
 		return
 	}
 
+	// Liên kết vào nhóm bản dịch của bài viết gốc nếu có
+	var translationGroupID *uuid.UUID
+	if input.TranslationOf != nil {
+		source, err := h.articleRepo.GetByID(*input.TranslationOf)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidTranslationOf, errors.New("không tìm thấy bài viết gốc của bản dịch"))
+			return
+		}
+		translationGroupID = source.TranslationGroupID
+		if translationGroupID == nil {
+			translationGroupID = &source.ID
+		}
+		exists, err := h.articleRepo.CheckTranslationLocaleExists(*translationGroupID, locale, uuid.Nil)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+			return
+		}
+		if exists {
+			helpers.ErrorResponse(c, helpers.ErrTranslationExists, fmt.Errorf("bài viết đã có bản dịch ngôn ngữ '%s'", locale))
+			return
+		}
+	}
+
 	isActive := true
 	if input.IsActive != nil {
 		isActive = *input.IsActive
@@ -156,20 +275,31 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	}
 
 	article := model.Article{
-		Title:       input.Title,
-		Description: input.Description,
-		Slug:        input.Slug,
-		CategoryID:  input.CategoryID,
-		IsActive:    isActive,
-		IsHot:       isHot,
-		Status:      status,
-		PublishedAt: input.PublishedAt,
-		AuthorID:    authorID,
+		Title:              input.Title,
+		Description:        input.Description,
+		Slug:               input.Slug,
+		CategoryID:         input.CategoryID,
+		IsActive:           isActive,
+		IsHot:              isHot,
+		Status:             status,
+		PublishedAt:        input.PublishedAt,
+		AuthorID:           authorID,
+		Locale:             locale,
+		TranslationGroupID: translationGroupID,
+		ReviewBy:           input.ReviewBy,
+	}
+	article.SetLegalBasis(input.LegalBasis)
+
+	attachments, err := h.resolveAttachments(input.Attachments)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidAttachment, err)
+		return
 	}
+	article.SetAttachments(attachments)
 
 	// Sử dụng method SetTagIDs của Article model
 	if err := article.SetTagIDs(input.TagIDs); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Danh sách tag không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidTagList, err)
 		return
 	}
 
@@ -186,14 +316,16 @@ func (h *ArticleHandler) CreateArticle(c *gin.Context) {
 	}
 
 	if err := h.articleRepo.Create(&article); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tạo bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleCreateFailed, err)
 		return
 	}
+	invalidateRelatedCache()
+	invalidateHomepageCache()
 
 	// Load lại với quan hệ
 	createdArticle, err := h.articleRepo.GetByID(article.ID)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tải bài viết đã tạo", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleReloadFailed, err)
 		return
 	}
 
@@ -212,6 +344,7 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	tagIDStr := c.Query("tag_id")
 	publishedStr := c.Query("published")
 	search := c.Query("search")
+	locale := helpers.NormalizeLocale(c.Query("locale"))
 
 	page, _ := strconv.Atoi(pageStr)
 	limit, _ := strconv.Atoi(limitStr)
@@ -232,7 +365,7 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	if categoryIDStr != "" {
 		categoryID, err := uuid.Parse(categoryIDStr)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "ID danh mục không hợp lệ", err)
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, err)
 			return
 		}
 		filter.CategoryID = &categoryID
@@ -242,12 +375,14 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	if tagIDStr != "" {
 		tagID, err := uuid.Parse(tagIDStr)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "ID tag không hợp lệ", err)
+			helpers.ErrorResponse(c, helpers.ErrInvalidTagID, err)
 			return
 		}
 		filter.TagID = &tagID
 	}
 
+	filter.Locale = locale
+
 	// Parse published status
 	if publishedStr == "true" {
 		t := true
@@ -260,7 +395,7 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	// Search with filters
 	articles, total, err := h.articleRepo.SearchWithFilters(filter, page, limit)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleListFailed, err)
 		return
 	}
 
@@ -271,6 +406,7 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 
 	// Attach tag names for user-facing responses
 	h.attachTagNamesToResponses(response)
+	h.attachSeriesToResponses(response, false)
 
 	totalPages := (total + int64(limit) - 1) / int64(limit)
 
@@ -302,9 +438,9 @@ func (h *ArticleHandler) GetPublicArticles(c *gin.Context) {
 		limit = 10
 	}
 
-	articles, total, err := h.articleRepo.GetPublished(page, limit)
+	articles, total, err := h.articleRepo.GetPublished(page, limit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleListFailed, err)
 		return
 	}
 
@@ -314,6 +450,7 @@ func (h *ArticleHandler) GetPublicArticles(c *gin.Context) {
 	}
 	// Attach tag names for user-facing responses
 	h.attachTagNamesToResponses(response)
+	h.attachSeriesToResponses(response, true)
 	totalPages := (total + int64(limit) - 1) / int64(limit)
 
 	c.JSON(http.StatusOK, helpers.Response{
@@ -336,9 +473,9 @@ func (h *ArticleHandler) GetAllPublicArticles(c *gin.Context) {
 	maxLimit := int(^uint(0) >> 1)
 
 	// Lấy chỉ các bài đã xuất bản và active, sắp xếp theo view_count desc
-	articles, err := h.articleRepo.GetAllPublishedOrdered(maxLimit)
+	articles, err := h.articleRepo.GetAllPublishedOrdered(maxLimit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleListFailed, err)
 		return
 	}
 
@@ -349,6 +486,7 @@ func (h *ArticleHandler) GetAllPublicArticles(c *gin.Context) {
 
 	// Đính kèm tên tags
 	h.attachTagNamesToResponses(response)
+	h.attachSeriesToResponses(response, true)
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
@@ -365,23 +503,26 @@ func (h *ArticleHandler) GetArticleByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID bài viết không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
 		return
 	}
 
 	article, err := h.articleRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
 		return
 	}
 
-	// Tăng view count
-	_ = h.articleRepo.IncrementViewCount(id)
+	resp := article.ToResponse()
+	h.attachSeriesToResponse(&resp, false)
+	resp.Translations, _ = h.articleRepo.GetTranslations(article, false)
+	legalReview := article.ToLegalReviewResponse()
+	resp.LegalReview = &legalReview
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy thông tin bài viết thành công",
-		Data:    article.ToResponse(),
+		Data:    resp,
 	})
 }
 
@@ -391,17 +532,20 @@ func (h *ArticleHandler) GetArticleBySlug(c *gin.Context) {
 
 	article, err := h.articleRepo.GetBySlug(slug)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
 		return
 	}
 
-	// Tăng view count
-	_ = h.articleRepo.IncrementViewCount(article.ID)
+	resp := article.ToResponse()
+	h.attachSeriesToResponse(&resp, false)
+	resp.Translations, _ = h.articleRepo.GetTranslations(article, false)
+	legalReview := article.ToLegalReviewResponse()
+	resp.LegalReview = &legalReview
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy thông tin bài viết thành công",
-		Data:    article.ToResponse(),
+		Data:    resp,
 	})
 }
 
@@ -412,17 +556,31 @@ func (h *ArticleHandler) GetArticleBySlugPublic(c *gin.Context) {
 	article, err := h.articleRepo.GetPublishedBySlug(slug)
 	if err != nil {
 		if err.Error() == "article not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy bài viết", err)
+			helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleFetchFailed, err)
 		return
 	}
 
-	_ = h.articleRepo.IncrementViewCount(article.ID)
+	// Lượt xem được gom trong bộ nhớ và ghi theo lô (bỏ qua bot, nhân viên và lượt xem lặp lại)
+	analytics.Views().Record(c, article.ID)
 
 	resp := article.ToResponse()
 	h.attachTagNamesToResponse(&resp)
+	h.attachSeriesToResponse(&resp, true)
+	resp.Translations, _ = h.articleRepo.GetTranslations(article, true)
+	if docs, err := h.documentRepo.GetByArticle(article.ID, true); err == nil {
+		for i := range docs {
+			resp.LegalDocuments = append(resp.LegalDocuments, docs[i].ToSimpleResponse())
+		}
+	}
+	if faqs, err := h.faqRepo.GetByArticle(article.ID, true); err == nil {
+		for i := range faqs {
+			resp.FAQs = append(resp.FAQs, faqs[i].ToResponse())
+		}
+	}
+	resp.JSONLD = buildArticleJSONLD(&resp)
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
@@ -435,7 +593,7 @@ func (h *ArticleHandler) GetArticleBySlugPublic(c *gin.Context) {
 func (h *ArticleHandler) GetArticlesByCategorySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if strings.TrimSpace(slug) == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Slug không hợp lệ", errors.New("slug không được để trống"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidSlug, errors.New("slug không được để trống"))
 		return
 	}
 
@@ -453,16 +611,19 @@ func (h *ArticleHandler) GetArticlesByCategorySlug(c *gin.Context) {
 	category, err := h.categoryRepo.GetActiveBySlugWithArticles(slug)
 	if err != nil {
 		if err.Error() == "category not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy danh mục", err)
+			helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryFetchFailed, err)
 		return
 	}
 
-	articles, total, err := h.articleRepo.GetPublishedByCategorySlug(slug, page, limit)
+	categoryResp := category.ToResponse()
+	categoryResp.Translations, _ = h.categoryRepo.GetTranslations(category, true)
+
+	articles, total, err := h.articleRepo.GetPublishedByCategorySlug(slug, page, limit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy bài viết theo danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryArticlesFailed, err)
 		return
 	}
 
@@ -472,13 +633,14 @@ func (h *ArticleHandler) GetArticlesByCategorySlug(c *gin.Context) {
 	}
 	// Attach tag names for user-facing responses
 	h.attachTagNamesToResponses(responses)
+	h.attachSeriesToResponses(responses, true)
 	totalPages := (total + int64(limit) - 1) / int64(limit)
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy bài viết theo danh mục thành công",
 		Data: map[string]interface{}{
-			"category": category.ToResponse(),
+			"category": categoryResp,
 			"articles": responses,
 			"pagination": map[string]interface{}{
 				"page":        page,
@@ -499,9 +661,9 @@ func (h *ArticleHandler) GetFeaturedArticles(c *gin.Context) {
 		limit = 5
 	}
 
-	articles, err := h.articleRepo.GetFeatured(limit)
+	articles, err := h.articleRepo.GetFeatured(limit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy bài viết nổi bật", err)
+		helpers.ErrorResponse(c, helpers.ErrFeaturedArticlesFailed, err)
 		return
 	}
 
@@ -511,6 +673,7 @@ func (h *ArticleHandler) GetFeaturedArticles(c *gin.Context) {
 	}
 	// Attach tag names for user-facing responses
 	h.attachTagNamesToResponses(response)
+	h.attachSeriesToResponses(response, true)
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy bài viết nổi bật thành công",
@@ -522,7 +685,7 @@ func (h *ArticleHandler) GetFeaturedArticles(c *gin.Context) {
 func (h *ArticleHandler) SearchArticles(c *gin.Context) {
 	keyword := c.Query("q")
 	if keyword == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Từ khóa tìm kiếm không được để trống", nil)
+		helpers.ErrorResponse(c, helpers.ErrSearchKeywordRequired, nil)
 		return
 	}
 
@@ -541,7 +704,7 @@ func (h *ArticleHandler) SearchArticles(c *gin.Context) {
 
 	articles, total, err := h.articleRepo.Search(keyword, page, limit)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tìm kiếm bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleSearchFailed, err)
 		return
 	}
 
@@ -571,19 +734,19 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID bài viết không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
 		return
 	}
 
 	var input model.ArticleInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	article, err := h.articleRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
 		return
 	}
 
@@ -593,11 +756,11 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	// Kiểm tra slug
 	exists, err := h.articleRepo.CheckSlugExists(input.Slug, id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("bài viết khác với slug này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("bài viết khác với slug này đã tồn tại"))
 		return
 	}
 
@@ -605,7 +768,7 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	if input.CategoryID != nil {
 		_, err := h.categoryRepo.GetByID(*input.CategoryID)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "Danh mục không hợp lệ", errors.New("không tìm thấy danh mục"))
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategory, errors.New("không tìm thấy danh mục"))
 			return
 		}
 	}
@@ -613,9 +776,30 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	// Validate status nếu có
 	if input.Status != nil && *input.Status != "" {
 		if _, err := normalizeArticleStatus(input.Status); err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "Status không hợp lệ", err)
+			helpers.ErrorResponse(c, helpers.ErrInvalidArticleStatus, err)
+			return
+		}
+	}
+
+	// Đổi ngôn ngữ nếu có, không được trùng với bản dịch khác trong nhóm
+	if strings.TrimSpace(input.Locale) != "" {
+		locale, err := normalizeContentLocale(input.Locale)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
 			return
 		}
+		if locale != article.Locale && article.TranslationGroupID != nil {
+			exists, err := h.articleRepo.CheckTranslationLocaleExists(*article.TranslationGroupID, locale, article.ID)
+			if err != nil {
+				helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+				return
+			}
+			if exists {
+				helpers.ErrorResponse(c, helpers.ErrTranslationExists, fmt.Errorf("bài viết đã có bản dịch ngôn ngữ '%s'", locale))
+				return
+			}
+		}
+		article.Locale = locale
 	}
 
 	article.Title = input.Title
@@ -635,7 +819,7 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	// Sử dụng method SetTagIDs của Article model
 	if input.TagIDs != nil {
 		if err := article.SetTagIDs(input.TagIDs); err != nil {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "Danh sách tag không hợp lệ", err)
+			helpers.ErrorResponse(c, helpers.ErrInvalidTagList, err)
 			return
 		}
 	}
@@ -646,6 +830,20 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	if input.PublishedAt != nil {
 		article.PublishedAt = input.PublishedAt
 	}
+	if input.ReviewBy != nil {
+		article.ReviewBy = input.ReviewBy
+	}
+	if input.LegalBasis != nil {
+		article.SetLegalBasis(input.LegalBasis)
+	}
+	if input.Attachments != nil {
+		attachments, err := h.resolveAttachments(input.Attachments)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidAttachment, err)
+			return
+		}
+		article.SetAttachments(attachments)
+	}
 
 	if input.Metadata != nil {
 		metadataJSON, _ := json.Marshal(input.Metadata)
@@ -658,13 +856,15 @@ func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
 	}
 
 	if err := h.articleRepo.Update(article); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể cập nhật bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleUpdateFailed, err)
 		return
 	}
+	invalidateRelatedCache()
+	invalidateHomepageCache()
 
 	updatedArticle, err := h.articleRepo.GetByID(article.ID)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tải bài viết đã cập nhật", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleReloadFailed, err)
 		return
 	}
 
@@ -680,20 +880,22 @@ func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID bài viết không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
 		return
 	}
 
 	_, err = h.articleRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
 		return
 	}
 
 	if err := h.articleRepo.Delete(id); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể xóa bài viết", err)
+		helpers.ErrorResponse(c, helpers.ErrArticleDeleteFailed, err)
 		return
 	}
+	invalidateRelatedCache()
+	invalidateHomepageCache()
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
@@ -0,0 +1,93 @@
+package handle
+
+import (
+	"backend/internal/model"
+	"time"
+)
+
+// jsonLDPerson tạo node schema.org Person từ thông tin tác giả
+func jsonLDPerson(author *model.AuthorResponse, base string) map[string]interface{} {
+	person := map[string]interface{}{
+		"@type": "Person",
+		"name":  author.FullName,
+	}
+	if author.Slug != "" {
+		person["url"] = base + "/tac-gia/" + author.Slug
+	}
+	if author.Title != "" {
+		person["jobTitle"] = author.Title
+	}
+	if author.Photo != nil {
+		person["image"] = author.Photo.URL
+	}
+	return person
+}
+
+// buildArticleJSONLD tạo structured data cho trang chi tiết bài viết:
+// WebPage (người thẩm định, ngày thẩm định) chứa Article (tác giả chính và đồng tác giả)
+// và FAQPage nếu bài viết có nhúng câu hỏi thường gặp
+func buildArticleJSONLD(resp *model.ArticleResponse) map[string]interface{} {
+	base := getPublicBase()
+	pageURL := base + localizedPath(resp.Locale, "/bai-viet/"+resp.Slug)
+
+	var authors []map[string]interface{}
+	if resp.Author != nil {
+		authors = append(authors, jsonLDPerson(resp.Author, base))
+	}
+	for i := range resp.CoAuthors {
+		authors = append(authors, jsonLDPerson(&resp.CoAuthors[i], base))
+	}
+
+	article := map[string]interface{}{
+		"@type":            "Article",
+		"headline":         resp.Title,
+		"description":      resp.Description,
+		"inLanguage":       resp.Locale,
+		"mainEntityOfPage": pageURL,
+		"dateModified":     resp.UpdatedAt.Format(time.RFC3339),
+	}
+	if resp.PublishedAt != nil {
+		article["datePublished"] = resp.PublishedAt.Format(time.RFC3339)
+	}
+	if len(authors) > 0 {
+		article["author"] = authors
+	}
+	if resp.Category != nil {
+		article["articleSection"] = resp.Category.Name
+	}
+	if len(resp.TagNames) > 0 {
+		article["keywords"] = resp.TagNames
+	}
+	if len(resp.LegalDocuments) > 0 {
+		citations := make([]map[string]interface{}, 0, len(resp.LegalDocuments))
+		for _, doc := range resp.LegalDocuments {
+			citation := map[string]interface{}{
+				"@type": "Legislation",
+				"name":  doc.Title,
+				"url":   base + "/van-ban/" + doc.Slug,
+			}
+			if doc.DocumentNumber != "" {
+				citation["legislationIdentifier"] = doc.DocumentNumber
+			}
+			citations = append(citations, citation)
+		}
+		article["citation"] = citations
+	}
+
+	page := map[string]interface{}{
+		"@context":   "https://schema.org",
+		"@type":      "WebPage",
+		"url":        pageURL,
+		"mainEntity": article,
+	}
+	if resp.ReviewedBy != nil {
+		page["reviewedBy"] = jsonLDPerson(resp.ReviewedBy, base)
+	}
+	if resp.LastReviewedAt != nil {
+		page["lastReviewed"] = resp.LastReviewedAt.Format("2006-01-02")
+	}
+	if len(resp.FAQs) > 0 {
+		page["hasPart"] = buildFAQPageJSONLD(resp.FAQs)
+	}
+	return page
+}
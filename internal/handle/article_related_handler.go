@@ -0,0 +1,311 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"errors"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Trọng số chấm điểm bài viết liên quan
+const (
+	relatedScoreSharedTag       = 3.0 // Mỗi tag chung
+	relatedScoreSameCategory    = 4.0 // Cùng danh mục
+	relatedScoreSiblingCategory = 2.0 // Danh mục cha/con/anh em
+	relatedScoreRecency         = 2.0 // Tối đa cho bài mới xuất bản
+	relatedScoreViews           = 1.0 // Nhân với log10(view_count + 1)
+
+	relatedCandidateLimit = 200
+	relatedMaxLimit       = 20
+)
+
+// Cache kết quả bài viết liên quan theo slug + limit
+var (
+	relatedCache   = map[string]relatedCacheEntry{}
+	relatedCacheMu sync.RWMutex
+)
+
+type relatedCacheEntry struct {
+	data    []model.RelatedArticleResponse
+	expires time.Time
+}
+
+func relatedCacheTTL() time.Duration {
+	if v := os.Getenv("RELATED_CACHE_TTL_SECONDS"); v != "" {
+		if s, err := time.ParseDuration(v + "s"); err == nil {
+			return s
+		}
+	}
+	return 10 * time.Minute
+}
+
+// invalidateRelatedCache xóa toàn bộ cache bài viết liên quan (gọi khi bài viết thay đổi)
+func invalidateRelatedCache() {
+	relatedCacheMu.Lock()
+	relatedCache = map[string]relatedCacheEntry{}
+	relatedCacheMu.Unlock()
+}
+
+// scoreRelatedArticle chấm điểm một bài viết ứng viên so với bài viết gốc
+func scoreRelatedArticle(source, candidate *model.Article, sourceTags map[uuid.UUID]struct{}, relativeCategories map[uuid.UUID]struct{}, now time.Time) float64 {
+	score := 0.0
+
+	for _, id := range candidate.GetTagIDs() {
+		if _, ok := sourceTags[id]; ok {
+			score += relatedScoreSharedTag
+		}
+	}
+
+	if source.CategoryID != nil && candidate.CategoryID != nil {
+		if *source.CategoryID == *candidate.CategoryID {
+			score += relatedScoreSameCategory
+		} else if _, ok := relativeCategories[*candidate.CategoryID]; ok {
+			score += relatedScoreSiblingCategory
+		}
+	}
+
+	// Độ mới: giảm dần theo số ngày kể từ khi xuất bản (nửa giá trị sau 30 ngày)
+	publishedAt := candidate.CreatedAt
+	if candidate.PublishedAt != nil {
+		publishedAt = *candidate.PublishedAt
+	}
+	days := now.Sub(publishedAt).Hours() / 24
+	if days < 0 {
+		days = 0
+	}
+	score += relatedScoreRecency / (1 + days/30)
+
+	score += relatedScoreViews * math.Log10(float64(candidate.ViewCount)+1)
+
+	return score
+}
+
+// GetRelatedArticles trả về bài viết liên quan của một bài viết public
+// Bài được ghim thủ công đứng đầu, phần còn lại được chấm điểm theo tag chung,
+// danh mục, độ mới và lượt xem
+func (h *ArticleHandler) GetRelatedArticles(c *gin.Context) {
+	slug := c.Param("slug")
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "6"))
+	if limit < 1 || limit > relatedMaxLimit {
+		limit = 6
+	}
+
+	cacheKey := slug + ":" + strconv.Itoa(limit)
+	relatedCacheMu.RLock()
+	if entry, ok := relatedCache[cacheKey]; ok && time.Now().Before(entry.expires) {
+		relatedCacheMu.RUnlock()
+		helpers.SuccessResponse(c, "Lấy bài viết liên quan thành công", entry.data)
+		return
+	}
+	relatedCacheMu.RUnlock()
+
+	article, err := h.articleRepo.GetPublishedBySlug(slug)
+	if err != nil {
+		if err.Error() == "article not found" {
+			helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrArticleFetchFailed, err)
+		return
+	}
+
+	result := make([]model.RelatedArticleResponse, 0, limit)
+	seen := map[uuid.UUID]struct{}{article.ID: {}}
+
+	// 1. Bài viết được ghim thủ công
+	pinned, err := h.articleRepo.GetPinnedRelated(article.ID, true)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrRelatedFetchFailed, err)
+		return
+	}
+	for i := range pinned {
+		if len(result) >= limit {
+			break
+		}
+		seen[pinned[i].ID] = struct{}{}
+		result = append(result, model.RelatedArticleResponse{
+			ArticleResponse: pinned[i].ToResponse(),
+			Pinned:          true,
+		})
+	}
+
+	// 2. Bài viết được chấm điểm tự động
+	if len(result) < limit {
+		relativeCategories := map[uuid.UUID]struct{}{}
+		var categoryIDs []uuid.UUID
+		if article.CategoryID != nil {
+			categoryIDs = append(categoryIDs, *article.CategoryID)
+			if article.Category != nil {
+				relativeIDs, err := h.categoryRepo.GetRelativeIDs(article.Category)
+				if err == nil {
+					for _, id := range relativeIDs {
+						relativeCategories[id] = struct{}{}
+					}
+					categoryIDs = append(categoryIDs, relativeIDs...)
+				}
+			}
+		}
+
+		candidates, err := h.articleRepo.GetRelatedCandidates(article, categoryIDs, relatedCandidateLimit)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrRelatedFetchFailed, err)
+			return
+		}
+
+		sourceTags := map[uuid.UUID]struct{}{}
+		for _, id := range article.GetTagIDs() {
+			sourceTags[id] = struct{}{}
+		}
+
+		now := time.Now()
+		scored := make([]model.RelatedArticleResponse, 0, len(candidates))
+		for i := range candidates {
+			if _, ok := seen[candidates[i].ID]; ok {
+				continue
+			}
+			scored = append(scored, model.RelatedArticleResponse{
+				ArticleResponse: candidates[i].ToResponse(),
+				Score:           math.Round(scoreRelatedArticle(article, &candidates[i], sourceTags, relativeCategories, now)*100) / 100,
+			})
+		}
+		sort.SliceStable(scored, func(i, j int) bool {
+			return scored[i].Score > scored[j].Score
+		})
+
+		for _, item := range scored {
+			if len(result) >= limit {
+				break
+			}
+			seen[item.ID] = struct{}{}
+			result = append(result, item)
+		}
+	}
+
+	// 3. Bổ sung bằng bài viết nổi bật nếu vẫn chưa đủ
+	if len(result) < limit {
+		featured, err := h.articleRepo.GetFeatured(limit+len(seen), article.Locale)
+		if err == nil {
+			for i := range featured {
+				if len(result) >= limit {
+					break
+				}
+				if _, ok := seen[featured[i].ID]; ok {
+					continue
+				}
+				seen[featured[i].ID] = struct{}{}
+				result = append(result, model.RelatedArticleResponse{
+					ArticleResponse: featured[i].ToResponse(),
+				})
+			}
+		}
+	}
+
+	responses := make([]model.ArticleResponse, len(result))
+	for i := range result {
+		responses[i] = result[i].ArticleResponse
+	}
+	h.attachTagNamesToResponses(responses)
+	for i := range result {
+		result[i].TagNames = responses[i].TagNames
+	}
+
+	relatedCacheMu.Lock()
+	relatedCache[cacheKey] = relatedCacheEntry{data: result, expires: time.Now().Add(relatedCacheTTL())}
+	relatedCacheMu.Unlock()
+
+	helpers.SuccessResponse(c, "Lấy bài viết liên quan thành công", result)
+}
+
+// GetPinnedRelatedArticles lấy danh sách bài viết liên quan được ghim (admin)
+func (h *ArticleHandler) GetPinnedRelatedArticles(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	articles, err := h.articleRepo.GetPinnedRelated(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrRelatedFetchFailed, err)
+		return
+	}
+
+	response := make([]model.ArticleResponse, 0, len(articles))
+	for _, article := range articles {
+		response = append(response, article.ToResponse())
+	}
+
+	helpers.SuccessResponse(c, "Lấy bài viết liên quan thành công", response)
+}
+
+// SetPinnedRelatedArticles ghim thủ công danh sách bài viết liên quan (admin)
+func (h *ArticleHandler) SetPinnedRelatedArticles(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.PinnedRelatedInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	// Loại bỏ trùng lặp và chính bài viết hiện tại
+	seen := map[uuid.UUID]struct{}{}
+	relatedIDs := make([]uuid.UUID, 0, len(input.RelatedIDs))
+	for _, relatedID := range input.RelatedIDs {
+		if relatedID == id {
+			helpers.ErrorResponse(c, helpers.ErrRelatedSelfReference, errors.New("related_ids chứa ID của bài viết hiện tại"))
+			return
+		}
+		if _, ok := seen[relatedID]; ok {
+			continue
+		}
+		if _, err := h.articleRepo.GetByID(relatedID); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidRelatedArticle, err)
+			return
+		}
+		seen[relatedID] = struct{}{}
+		relatedIDs = append(relatedIDs, relatedID)
+	}
+
+	if err := h.articleRepo.SetPinnedRelated(id, relatedIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrRelatedUpdateFailed, err)
+		return
+	}
+	invalidateRelatedCache()
+
+	articles, err := h.articleRepo.GetPinnedRelated(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrRelatedFetchFailed, err)
+		return
+	}
+
+	response := make([]model.ArticleResponse, 0, len(articles))
+	for _, article := range articles {
+		response = append(response, article.ToResponse())
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật bài viết liên quan thành công", response)
+}
@@ -0,0 +1,68 @@
+package handle
+
+import (
+	"backend/internal/model"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestScoreRelatedArticle(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	category := uuid.New()
+	sibling := uuid.New()
+	other := uuid.New()
+	tagA, tagB, tagC := uuid.New(), uuid.New(), uuid.New()
+
+	source := &model.Article{CategoryID: &category}
+	sourceTags := map[uuid.UUID]struct{}{tagA: {}, tagB: {}}
+	relativeCategories := map[uuid.UUID]struct{}{sibling: {}}
+
+	daysAgo := func(days int) *time.Time {
+		at := now.AddDate(0, 0, -days)
+		return &at
+	}
+	candidate := func(categoryID *uuid.UUID, tags []uuid.UUID, publishedAt *time.Time, views int) *model.Article {
+		article := &model.Article{CategoryID: categoryID, PublishedAt: publishedAt, CreatedAt: now.AddDate(0, 0, -60), ViewCount: views}
+		if err := article.SetTagIDs(tags); err != nil {
+			t.Fatalf("SetTagIDs() = %v", err)
+		}
+		return article
+	}
+
+	tests := []struct {
+		name      string
+		candidate *model.Article
+		want      float64
+	}{
+		{"chỉ có độ mới (vừa xuất bản)", candidate(&other, nil, daysAgo(0), 0), relatedScoreRecency},
+		{"giảm một nửa sau 30 ngày", candidate(&other, nil, daysAgo(30), 0), relatedScoreRecency / 2},
+		{"thiếu published_at thì dùng created_at", candidate(&other, nil, nil, 0), relatedScoreRecency / 3},
+		{"xuất bản trong tương lai không vượt điểm tối đa", candidate(&other, nil, daysAgo(-10), 0), relatedScoreRecency},
+		{"cùng danh mục", candidate(&category, nil, daysAgo(0), 0), relatedScoreSameCategory + relatedScoreRecency},
+		{"danh mục cha/con/anh em", candidate(&sibling, nil, daysAgo(0), 0), relatedScoreSiblingCategory + relatedScoreRecency},
+		{"không có danh mục", candidate(nil, nil, daysAgo(0), 0), relatedScoreRecency},
+		{"mỗi tag chung", candidate(&other, []uuid.UUID{tagA, tagB, tagC}, daysAgo(0), 0), 2*relatedScoreSharedTag + relatedScoreRecency},
+		{"lượt xem theo log10", candidate(&other, nil, daysAgo(0), 99), relatedScoreViews*2 + relatedScoreRecency},
+		{"cộng dồn mọi tiêu chí", candidate(&category, []uuid.UUID{tagA}, daysAgo(30), 9),
+			relatedScoreSharedTag + relatedScoreSameCategory + relatedScoreRecency/2 + relatedScoreViews},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreRelatedArticle(source, tt.candidate, sourceTags, relativeCategories, now)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("scoreRelatedArticle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("bài gốc không có danh mục", func(t *testing.T) {
+		got := scoreRelatedArticle(&model.Article{}, candidate(&category, nil, daysAgo(0), 0), sourceTags, relativeCategories, now)
+		if math.Abs(got-relatedScoreRecency) > 1e-9 {
+			t.Errorf("scoreRelatedArticle() = %v, want %v", got, relatedScoreRecency)
+		}
+	})
+}
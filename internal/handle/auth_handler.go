@@ -6,7 +6,6 @@ import (
 	"backend/internal/model"
 	"backend/internal/repo"
 	"encoding/json"
-	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,26 +25,26 @@ func NewAuthHandler(userRepo *repo.UserRepository) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var input model.UserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	// Kiểm tra xem username đã tồn tại chưa
 	if h.userRepo.IsUsernameExists(input.Username) {
-		helpers.ErrorResponse(c, http.StatusBadRequest, consts.MSG_USERNAME_EXISTS, nil)
+		helpers.ErrorResponse(c, helpers.ErrUsernameExists, nil)
 		return
 	}
 
 	// Kiểm tra xem email đã tồn tại chưa
 	if h.userRepo.IsEmailExists(input.Email) {
-		helpers.ErrorResponse(c, http.StatusBadRequest, consts.MSG_EMAIL_EXISTS, nil)
+		helpers.ErrorResponse(c, helpers.ErrEmailExists, nil)
 		return
 	}
 
 	// Mã hóa mật khẩu
 	hashedPassword, err := helpers.HashPassword(input.Password)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -74,14 +73,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.userRepo.CreateUser(&user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	// Tạo JWT token
 	token, err := helpers.GenerateJWT(user.ID, user.Username, user.Role)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -96,7 +95,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input model.LoginInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -104,37 +103,29 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	user, err := h.userRepo.GetUserByIdentifier(input.Identifier)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"message": consts.MSG_INVALID_CREDENTIALS,
-				"code":    "INVALID_CREDENTIALS",
-			})
+			helpers.ErrorResponse(c, helpers.ErrInvalidCredentials, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	// Kiểm tra mật khẩu
 	if !helpers.CheckPasswordHash(input.Password, user.Password) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": consts.MSG_INVALID_CREDENTIALS,
-			"code":    "INVALID_CREDENTIALS",
-		})
+		helpers.ErrorResponse(c, helpers.ErrInvalidCredentials, nil)
 		return
 	}
 
 	// Kiểm tra xem người dùng có đang hoạt động không
 	if !user.IsActive {
-		helpers.ErrorResponse(c, http.StatusUnauthorized, consts.MSG_UNAUTHORIZED, nil)
+		helpers.ErrorResponse(c, helpers.ErrAccountDisabled, nil)
 		return
 	}
 
 	// Tạo JWT token
 	token, err := helpers.GenerateJWT(user.ID, user.Username, user.Role)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -149,17 +140,17 @@ func (h *AuthHandler) Login(c *gin.Context) {
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID.(uuid.UUID))
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -169,19 +160,19 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	var input model.UpdateUserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userID.(uuid.UUID))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -192,7 +183,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	if input.Email != "" && input.Email != user.Email {
 		// Kiểm tra xem email đã tồn tại chưa
 		if h.userRepo.IsEmailExists(input.Email) {
-			helpers.ErrorResponse(c, http.StatusBadRequest, consts.MSG_EMAIL_EXISTS, nil)
+			helpers.ErrorResponse(c, helpers.ErrEmailExists, nil)
 			return
 		}
 		user.Email = input.Email
@@ -208,7 +199,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
@@ -220,40 +211,40 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	userIDVal, exists := c.Get("userID")
 	if !exists {
-		helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, nil)
 		return
 	}
 
 	var input model.ChangePasswordInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, consts.MSG_VALIDATION_ERROR)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
 	// basic confirm check
 	if input.NewPassword != input.ConfirmPassword {
-		helpers.BadRequestResponse(c, "Mật khẩu mới và xác nhận không khớp")
+		helpers.ErrorResponse(c, helpers.ErrPasswordMismatch, nil)
 		return
 	}
 
 	user, err := h.userRepo.GetUserByID(userIDVal.(uuid.UUID))
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			helpers.ErrorResponse(c, http.StatusNotFound, consts.MSG_USER_NOT_FOUND, nil)
+			helpers.ErrorResponse(c, helpers.ErrUserNotFound, nil)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
 	// If not super_admin, require current password verification
 	if user.Role != "super_admin" {
 		if input.CurrentPassword == "" {
-			helpers.BadRequestResponse(c, "Cần cung cấp mật khẩu hiện tại")
+			helpers.ErrorResponse(c, helpers.ErrCurrentPasswordEmpty, nil)
 			return
 		}
 		if !helpers.CheckPasswordHash(input.CurrentPassword, user.Password) {
-			helpers.BadRequestResponse(c, "Mật khẩu hiện tại không đúng")
+			helpers.ErrorResponse(c, helpers.ErrCurrentPasswordWrong, nil)
 			return
 		}
 	}
@@ -261,7 +252,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	// Hash new password and update
 	hashed, err := helpers.HashPassword(input.NewPassword)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 	user.Password = hashed
@@ -269,7 +260,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	user.PasswordChangedAt = &now
 
 	if err := h.userRepo.UpdateUser(user); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, consts.MSG_INTERNAL_ERROR, err)
+		helpers.ErrorResponse(c, helpers.ErrInternal, err)
 		return
 	}
 
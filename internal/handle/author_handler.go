@@ -0,0 +1,352 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuthorHandler struct {
+	profileRepo  *repo.AuthorProfileRepo
+	articleRepo  *repo.ArticleRepo
+	categoryRepo *repo.CategoryRepo
+	userRepo     *repo.UserRepository
+}
+
+func NewAuthorHandler(userRepo *repo.UserRepository) *AuthorHandler {
+	return &AuthorHandler{
+		profileRepo:  repo.NewAuthorProfileRepo(),
+		articleRepo:  repo.NewArticleRepo(),
+		categoryRepo: repo.NewCategoryRepo(),
+		userRepo:     userRepo,
+	}
+}
+
+// validateProfileInput chuẩn hóa slug và kiểm tra slug, User liên kết và lĩnh vực hành nghề
+func (h *AuthorHandler) validateProfileInput(input *model.AuthorProfileInput, profileID uuid.UUID) (*helpers.APIError, error) {
+	input.FullName = strings.TrimSpace(input.FullName)
+	input.Slug = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input.Slug), " ", "-"))
+
+	exists, err := h.profileRepo.CheckSlugExists(input.Slug, profileID)
+	if err != nil {
+		return helpers.ErrDatabase, err
+	}
+	if exists {
+		return helpers.ErrSlugExists, errors.New("hồ sơ tác giả với slug này đã tồn tại")
+	}
+
+	if input.UserID != nil {
+		if _, err := h.userRepo.GetUserByID(*input.UserID); err != nil {
+			return helpers.ErrUserNotFound, err
+		}
+		linked, err := h.profileRepo.CheckUserLinked(*input.UserID, profileID)
+		if err != nil {
+			return helpers.ErrDatabase, err
+		}
+		if linked {
+			return helpers.ErrAuthorUserLinked, fmt.Errorf("user %s đã có hồ sơ tác giả", *input.UserID)
+		}
+	}
+
+	// Bỏ các lĩnh vực trùng lặp, giữ nguyên thứ tự
+	seen := make(map[uuid.UUID]struct{}, len(input.PracticeAreaIDs))
+	areaIDs := make([]uuid.UUID, 0, len(input.PracticeAreaIDs))
+	for _, id := range input.PracticeAreaIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			areaIDs = append(areaIDs, id)
+		}
+	}
+	input.PracticeAreaIDs = areaIDs
+
+	if len(input.PracticeAreaIDs) > 0 {
+		categories, err := h.categoryRepo.GetByIDs(input.PracticeAreaIDs)
+		if err != nil {
+			return helpers.ErrDatabase, err
+		}
+		if len(categories) != len(input.PracticeAreaIDs) {
+			return helpers.ErrInvalidPracticeArea, errors.New("một hoặc nhiều lĩnh vực hành nghề không tồn tại")
+		}
+	}
+	return nil, nil
+}
+
+// buildProfileResponses tạo response kèm lĩnh vực hành nghề và số bài viết đã xuất bản
+func (h *AuthorHandler) buildProfileResponses(profiles []model.AuthorProfile) ([]model.AuthorProfileResponse, error) {
+	var profileIDs, categoryIDs []uuid.UUID
+	for i := range profiles {
+		profileIDs = append(profileIDs, profiles[i].ID)
+		categoryIDs = append(categoryIDs, profiles[i].GetPracticeAreaIDs()...)
+	}
+
+	counts, err := h.articleRepo.CountPublishedByAuthorProfiles(profileIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := h.categoryRepo.GetByIDs(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	categoryByID := make(map[uuid.UUID]model.Category, len(categories))
+	for _, category := range categories {
+		categoryByID[category.ID] = category
+	}
+
+	responses := make([]model.AuthorProfileResponse, 0, len(profiles))
+	for i := range profiles {
+		response := profiles[i].ToResponse()
+		for _, id := range response.PracticeAreaIDs {
+			if category, ok := categoryByID[id]; ok {
+				response.PracticeAreas = append(response.PracticeAreas, model.CategorySimpleResponse{
+					ID:   category.ID,
+					Name: category.Name,
+					Slug: category.Slug,
+				})
+			}
+		}
+		response.ArticleCount = counts[profiles[i].ID]
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// GetPublicAuthors lấy danh sách tác giả đang hoạt động
+func (h *AuthorHandler) GetPublicAuthors(c *gin.Context) {
+	profiles, err := h.profileRepo.GetActive()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorListFailed, err)
+		return
+	}
+
+	responses, err := h.buildProfileResponses(profiles)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorListFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy danh sách tác giả thành công", responses)
+}
+
+// GetPublicAuthorBySlug lấy hồ sơ tác giả kèm bài viết đã xuất bản (có phân trang)
+func (h *AuthorHandler) GetPublicAuthorBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	if strings.TrimSpace(slug) == "" {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSlug, errors.New("slug không được để trống"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	profile, err := h.profileRepo.GetActiveBySlug(slug)
+	if err != nil {
+		if err.Error() == "author profile not found" {
+			helpers.ErrorResponse(c, helpers.ErrAuthorNotFound, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrAuthorFetchFailed, err)
+		return
+	}
+
+	profileResponses, err := h.buildProfileResponses([]model.AuthorProfile{*profile})
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorFetchFailed, err)
+		return
+	}
+
+	// Bài viết là tác giả chính (hồ sơ gắn User) hoặc đồng tác giả; hồ sơ đứng riêng chỉ có bài đồng tác giả
+	articles, total, err := h.articleRepo.GetPublishedByAuthorProfile(profile, page, limit, helpers.ResolveLocale(c))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorArticlesFailed, err)
+		return
+	}
+	responses := make([]model.ArticleResponse, 0, len(articles))
+	for _, article := range articles {
+		responses = append(responses, article.ToResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy thông tin tác giả thành công", map[string]interface{}{
+		"author":   profileResponses[0],
+		"articles": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetAuthorProfiles lấy danh sách hồ sơ tác giả (admin)
+func (h *AuthorHandler) GetAuthorProfiles(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	search := strings.TrimSpace(c.Query("search"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	profiles, total, err := h.profileRepo.Search(search, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorListFailed, err)
+		return
+	}
+
+	responses, err := h.buildProfileResponses(profiles)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorListFailed, err)
+		return
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách hồ sơ tác giả thành công", map[string]interface{}{
+		"authors": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetAuthorProfileByID lấy hồ sơ tác giả theo ID (admin)
+func (h *AuthorHandler) GetAuthorProfileByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidAuthorID, err)
+		return
+	}
+
+	profile, err := h.profileRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorNotFound, err)
+		return
+	}
+
+	responses, err := h.buildProfileResponses([]model.AuthorProfile{*profile})
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorFetchFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin hồ sơ tác giả thành công", responses[0])
+}
+
+// CreateAuthorProfile tạo hồ sơ tác giả mới (gắn với User hoặc đứng riêng)
+func (h *AuthorHandler) CreateAuthorProfile(c *gin.Context) {
+	var input model.AuthorProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if apiErr, err := h.validateProfileInput(&input, uuid.Nil); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	profile := model.AuthorProfile{IsActive: true}
+	profile.ApplyInput(input)
+
+	if err := h.profileRepo.Create(&profile); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorCreateFailed, err)
+		return
+	}
+
+	responses, err := h.buildProfileResponses([]model.AuthorProfile{profile})
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorFetchFailed, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Tạo hồ sơ tác giả thành công",
+		Data:    responses[0],
+	})
+}
+
+// UpdateAuthorProfile cập nhật hồ sơ tác giả
+func (h *AuthorHandler) UpdateAuthorProfile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidAuthorID, err)
+		return
+	}
+
+	var input model.AuthorProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	profile, err := h.profileRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorNotFound, err)
+		return
+	}
+
+	if apiErr, err := h.validateProfileInput(&input, id); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	profile.ApplyInput(input)
+
+	if err := h.profileRepo.Update(profile); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorUpdateFailed, err)
+		return
+	}
+
+	responses, err := h.buildProfileResponses([]model.AuthorProfile{*profile})
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorFetchFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật hồ sơ tác giả thành công", responses[0])
+}
+
+// DeleteAuthorProfile xóa hồ sơ tác giả (bài viết của User vẫn giữ nguyên)
+func (h *AuthorHandler) DeleteAuthorProfile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidAuthorID, err)
+		return
+	}
+
+	if _, err := h.profileRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorNotFound, err)
+		return
+	}
+
+	if err := h.profileRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAuthorDeleteFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xóa hồ sơ tác giả thành công", nil)
+}
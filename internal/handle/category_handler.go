@@ -6,6 +6,7 @@ import (
 	"backend/internal/repo"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -29,7 +30,7 @@ func NewCategoryHandler() *CategoryHandler {
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	var input model.CategoryInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -39,14 +40,43 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	// Kiểm tra xem slug đã tồn tại chưa
 	exists, err := h.categoryRepo.CheckSlugExists(input.Slug, uuid.Nil)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("danh mục với slug này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("danh mục với slug này đã tồn tại"))
 		return
 	}
 
+	locale, err := normalizeContentLocale(input.Locale)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+		return
+	}
+
+	// Liên kết vào nhóm bản dịch của danh mục gốc nếu có
+	var translationGroupID *uuid.UUID
+	if input.TranslationOf != nil {
+		source, err := h.categoryRepo.GetByID(*input.TranslationOf)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidTranslationOf, errors.New("không tìm thấy danh mục gốc của bản dịch"))
+			return
+		}
+		translationGroupID = source.TranslationGroupID
+		if translationGroupID == nil {
+			translationGroupID = &source.ID
+		}
+		exists, err := h.categoryRepo.CheckTranslationLocaleExists(*translationGroupID, locale, uuid.Nil)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+			return
+		}
+		if exists {
+			helpers.ErrorResponse(c, helpers.ErrTranslationExists, fmt.Errorf("danh mục đã có bản dịch ngôn ngữ '%s'", locale))
+			return
+		}
+	}
+
 	// Đảm bảo metadata luôn có giá trị và meta_image luôn là mảng (không null)
 	if input.Metadata == nil {
 		input.Metadata = &model.CategoryMetadata{
@@ -63,15 +93,17 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	metadataJSON, _ := json.Marshal(input.Metadata)
 
 	category := model.Category{
-		Name:         input.Name,
-		Description:  input.Description,
-		Slug:         input.Slug,
-		DisplayOrder: 0,
-		IsActive:     false,
-		ShowOnMenu:   false,
-		ShowOnHome:   false,
-		ShowOnFooter: false,
-		Metadata:     datatypes.JSON(metadataJSON),
+		Name:               input.Name,
+		Description:        input.Description,
+		Slug:               input.Slug,
+		DisplayOrder:       0,
+		IsActive:           false,
+		ShowOnMenu:         false,
+		ShowOnHome:         false,
+		ShowOnFooter:       false,
+		Metadata:           datatypes.JSON(metadataJSON),
+		Locale:             locale,
+		TranslationGroupID: translationGroupID,
 	}
 
 	// Handle parent category if provided
@@ -79,7 +111,7 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 		if parentID, err := uuid.Parse(strings.TrimSpace(*input.ParentCategory)); err == nil {
 			category.ParentID = &parentID
 		} else {
-			helpers.ErrorResponse(c, http.StatusBadRequest, "Parent category ID không hợp lệ", err)
+			helpers.ErrorResponse(c, helpers.ErrInvalidParentCategory, err)
 			return
 		}
 	}
@@ -117,7 +149,7 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 			if parentID, err := uuid.Parse(strings.TrimSpace(*input.ParentCategory)); err == nil {
 				category.ParentID = &parentID
 			} else {
-				helpers.ErrorResponse(c, http.StatusBadRequest, "Parent category ID không hợp lệ", err)
+				helpers.ErrorResponse(c, helpers.ErrInvalidParentCategory, err)
 				return
 			}
 		}
@@ -126,9 +158,11 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	category.Metadata = datatypes.JSON(metadataJSON)
 
 	if err := h.categoryRepo.Create(&category); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tạo danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryCreateFailed, err)
 		return
 	}
+	// Section bài viết theo danh mục tra cứu danh mục theo slug
+	invalidateHomepageCache()
 
 	c.JSON(http.StatusCreated, helpers.Response{
 		Success: true,
@@ -173,7 +207,7 @@ func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	}
 
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryListFailed, err)
 		return
 	}
 
@@ -213,9 +247,9 @@ func (h *CategoryHandler) GetCategories(c *gin.Context) {
 func (h *CategoryHandler) GetPublicCategories(c *gin.Context) {
 	treeView := c.DefaultQuery("tree", "true") == "true"
 
-	categories, err := h.categoryRepo.GetActive()
+	categories, err := h.categoryRepo.GetActive(helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryFetchFailed, err)
 		return
 	}
 
@@ -243,9 +277,9 @@ func (h *CategoryHandler) GetPublicHomeCategories(c *gin.Context) {
 		perArticles = 6
 	}
 
-	categories, err := h.categoryRepo.GetHomeCategoriesWithArticles(perArticles)
+	categories, err := h.categoryRepo.GetHomeCategoriesWithArticles(perArticles, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách danh mục cho trang chủ", err)
+		helpers.ErrorResponse(c, helpers.ErrHomeCategoriesFailed, err)
 		return
 	}
 
@@ -273,7 +307,7 @@ func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
 	}
 
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy cây danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryTreeFailed, err)
 		return
 	}
 
@@ -292,7 +326,7 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID danh mục không hợp lệ", errors.New("ID danh mục phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, errors.New("ID danh mục phải là UUID hợp lệ"))
 		return
 	}
 
@@ -306,17 +340,20 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 	}
 	if err != nil {
 		if err.Error() == "category not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy danh mục", err)
+			helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
+	resp := category.ToResponse()
+	resp.Translations, _ = h.categoryRepo.GetTranslations(category, false)
+
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy thông tin danh mục thành công",
-		Data:    category.ToResponse(),
+		Data:    resp,
 	})
 }
 
@@ -324,7 +361,7 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 func (h *CategoryHandler) GetCategoryBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Slug không hợp lệ", errors.New("slug không được để trống"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidSlug, errors.New("slug không được để trống"))
 		return
 	}
 
@@ -339,17 +376,20 @@ func (h *CategoryHandler) GetCategoryBySlug(c *gin.Context) {
 	}
 	if err != nil {
 		if err.Error() == "category not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy danh mục", err)
+			helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
+	resp := category.ToResponse()
+	resp.Translations, _ = h.categoryRepo.GetTranslations(category, false)
+
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
 		Message: "Lấy thông tin danh mục thành công",
-		Data:    category.ToResponse(),
+		Data:    resp,
 	})
 }
 
@@ -358,13 +398,13 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID danh mục không hợp lệ", errors.New("ID danh mục phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, errors.New("ID danh mục phải là UUID hợp lệ"))
 		return
 	}
 
 	var input model.CategoryInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -372,10 +412,10 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	category, err := h.categoryRepo.GetByID(id)
 	if err != nil {
 		if err.Error() == "category not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy danh mục", err)
+			helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
@@ -385,14 +425,35 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	// Kiểm tra xem slug đã tồn tại chưa (loại trừ danh mục hiện tại)
 	exists, err := h.categoryRepo.CheckSlugExists(input.Slug, id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("danh mục khác với slug này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("danh mục khác với slug này đã tồn tại"))
 		return
 	}
 
+	// Đổi ngôn ngữ nếu có, không được trùng với bản dịch khác trong nhóm
+	if strings.TrimSpace(input.Locale) != "" {
+		locale, err := normalizeContentLocale(input.Locale)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+			return
+		}
+		if locale != category.Locale && category.TranslationGroupID != nil {
+			exists, err := h.categoryRepo.CheckTranslationLocaleExists(*category.TranslationGroupID, locale, category.ID)
+			if err != nil {
+				helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+				return
+			}
+			if exists {
+				helpers.ErrorResponse(c, helpers.ErrTranslationExists, fmt.Errorf("danh mục đã có bản dịch ngôn ngữ '%s'", locale))
+				return
+			}
+		}
+		category.Locale = locale
+	}
+
 	// Cập nhật danh mục
 	category.Name = input.Name
 	category.Description = input.Description
@@ -438,16 +499,17 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 			if parentID, err := uuid.Parse(strings.TrimSpace(*input.ParentCategory)); err == nil {
 				category.ParentID = &parentID
 			} else {
-				helpers.ErrorResponse(c, http.StatusBadRequest, "Parent category ID không hợp lệ", err)
+				helpers.ErrorResponse(c, helpers.ErrInvalidParentCategory, err)
 				return
 			}
 		}
 	}
 
 	if err := h.categoryRepo.Update(category); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể cập nhật danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryUpdateFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
@@ -461,7 +523,7 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID danh mục không hợp lệ", errors.New("ID danh mục phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, errors.New("ID danh mục phải là UUID hợp lệ"))
 		return
 	}
 
@@ -469,17 +531,18 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	_, err = h.categoryRepo.GetByID(id)
 	if err != nil {
 		if err.Error() == "category not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy danh mục", err)
+			helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
 	if err := h.categoryRepo.Delete(id); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể xóa danh mục", err)
+		helpers.ErrorResponse(c, helpers.ErrCategoryDeleteFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	c.JSON(http.StatusOK, helpers.Response{
 		Success: true,
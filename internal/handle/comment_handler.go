@@ -0,0 +1,341 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/notify"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// linkPattern nhận diện liên kết trong nội dung bình luận
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+type CommentHandler struct {
+	commentRepo *repo.CommentRepo
+	articleRepo *repo.ArticleRepo
+}
+
+func NewCommentHandler() *CommentHandler {
+	return &CommentHandler{
+		commentRepo: repo.NewCommentRepo(),
+		articleRepo: repo.NewArticleRepo(),
+	}
+}
+
+// CommentRateLimit đọc giới hạn gửi bình luận theo IP từ env
+// COMMENT_RATE_LIMIT (mặc định 5 lần) và COMMENT_RATE_WINDOW_MINUTES (mặc định 10 phút)
+func CommentRateLimit() (int, time.Duration) {
+	limit := 5
+	if v, err := strconv.Atoi(os.Getenv("COMMENT_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 10 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("COMMENT_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+// commentMaxLinks số liên kết tối đa trước khi bình luận bị đánh dấu spam (env COMMENT_MAX_LINKS, mặc định 2)
+func commentMaxLinks() int {
+	if v, err := strconv.Atoi(os.Getenv("COMMENT_MAX_LINKS")); err == nil && v >= 0 {
+		return v
+	}
+	return 2
+}
+
+// initialCommentStatus chọn trạng thái ban đầu:
+// quá nhiều liên kết -> spam; có liên kết -> chờ duyệt;
+// email đã từng được duyệt và bật COMMENT_AUTO_APPROVE -> duyệt luôn
+func (h *CommentHandler) initialCommentStatus(email string, linkCount int) string {
+	if linkCount > commentMaxLinks() {
+		return consts.CommentStatusSpam
+	}
+	if linkCount > 0 || os.Getenv("COMMENT_AUTO_APPROVE") != "true" {
+		return consts.CommentStatusPending
+	}
+	approved, err := h.commentRepo.HasApprovedByEmail(email)
+	if err != nil || !approved {
+		return consts.CommentStatusPending
+	}
+	return consts.CommentStatusApproved
+}
+
+// GetPublicComments lấy bình luận đã duyệt của bài viết theo slug (kèm trả lời)
+func (h *CommentHandler) GetPublicComments(c *gin.Context) {
+	article, err := h.articleRepo.GetPublishedBySlug(c.Param("slug"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	comments, total, err := h.commentRepo.GetApprovedByArticle(article.ID, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentListFailed, err)
+		return
+	}
+
+	responses := make([]model.PublicCommentResponse, 0, len(comments))
+	for i := range comments {
+		responses = append(responses, comments[i].ToPublicResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy bình luận thành công", map[string]interface{}{
+		"comments":      responses,
+		"comment_count": article.CommentCount,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// CreatePublicComment gửi bình luận cho bài viết; bình luận vào hàng đợi kiểm duyệt
+func (h *CommentHandler) CreatePublicComment(c *gin.Context) {
+	var input model.CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	article, err := h.articleRepo.GetPublishedBySlug(c.Param("slug"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	// Honeypot: trả về thành công giả để không lộ cơ chế lọc
+	if strings.TrimSpace(input.Website) != "" {
+		c.JSON(http.StatusCreated, helpers.Response{
+			Success: true,
+			Message: "Bình luận đã được gửi và đang chờ duyệt",
+			Data:    map[string]interface{}{"status": consts.CommentStatusPending},
+		})
+		return
+	}
+
+	// Chỉ cho phép trả lời một cấp: bình luận cha phải là bình luận gốc đã duyệt của cùng bài viết
+	if input.ParentID != nil {
+		parent, err := h.commentRepo.GetByID(*input.ParentID)
+		if err != nil || parent.ArticleID != article.ID || parent.ParentID != nil || parent.Status != consts.CommentStatusApproved {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCommentParent, errors.New("chỉ có thể trả lời bình luận gốc đã được duyệt"))
+			return
+		}
+	}
+
+	content := strings.TrimSpace(input.Content)
+	email := strings.ToLower(strings.TrimSpace(input.AuthorEmail))
+	linkCount := len(linkPattern.FindAllStringIndex(content, -1))
+
+	comment := model.ArticleComment{
+		ArticleID:   article.ID,
+		ParentID:    input.ParentID,
+		AuthorName:  strings.TrimSpace(input.AuthorName),
+		AuthorEmail: email,
+		Content:     content,
+		Status:      h.initialCommentStatus(email, linkCount),
+		LinkCount:   linkCount,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   truncateUTF8(c.Request.UserAgent(), 500),
+	}
+
+	if err := h.commentRepo.Create(&comment); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentCreateFailed, err)
+		return
+	}
+
+	message := "Bình luận đã được gửi và đang chờ duyệt"
+	switch comment.Status {
+	case consts.CommentStatusApproved:
+		_ = h.commentRepo.RefreshArticleCount(article.ID)
+		message = "Gửi bình luận thành công"
+	case consts.CommentStatusPending:
+		notify.Send(notify.Notification{
+			Event:   notify.EventCommentPending,
+			Title:   "Bình luận mới chờ duyệt",
+			Message: fmt.Sprintf("%s bình luận trên bài \"%s\"", comment.AuthorName, article.Title),
+			URL:     "/admin/comments?status=pending",
+			Data: map[string]interface{}{
+				"id":         comment.ID,
+				"article_id": article.ID,
+			},
+		})
+	}
+
+	// Bình luận spam cũng trả về "chờ duyệt" để không lộ kết quả lọc
+	status := comment.Status
+	if status == consts.CommentStatusSpam {
+		status = consts.CommentStatusPending
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: message,
+		Data: map[string]interface{}{
+			"status":  status,
+			"comment": comment.ToPublicResponse(),
+		},
+	})
+}
+
+// GetComments lấy hàng đợi kiểm duyệt bình luận trên mọi bài viết (admin)
+// Query: status, article_id, search, page, limit
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repo.CommentFilter{
+		Status: strings.TrimSpace(c.Query("status")),
+		Search: strings.TrimSpace(c.Query("search")),
+	}
+	if filter.Status != "" && !isCommentStatus(filter.Status) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidCommentStatus, fmt.Errorf("status không hợp lệ: %s", filter.Status))
+		return
+	}
+	if articleID := strings.TrimSpace(c.Query("article_id")); articleID != "" {
+		id, err := uuid.Parse(articleID)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+			return
+		}
+		filter.ArticleID = &id
+	}
+
+	comments, total, err := h.commentRepo.Search(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentListFailed, err)
+		return
+	}
+
+	counts, err := h.commentRepo.CountByStatus()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentListFailed, err)
+		return
+	}
+	statusCounts := make(map[string]int64, len(consts.CommentStatuses))
+	for _, status := range consts.CommentStatuses {
+		statusCounts[status] = counts[status]
+	}
+
+	responses := make([]model.CommentResponse, 0, len(comments))
+	for i := range comments {
+		responses = append(responses, comments[i].ToResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách bình luận thành công", map[string]interface{}{
+		"comments":      responses,
+		"status_counts": statusCounts,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// UpdateCommentStatus duyệt, từ chối hoặc đánh dấu spam một bình luận
+func (h *CommentHandler) UpdateCommentStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidCommentID, err)
+		return
+	}
+
+	var input model.CommentStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	comment, err := h.commentRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentNotFound, err)
+		return
+	}
+
+	now := time.Now()
+	comment.Status = input.Status
+	comment.ModeratedAt = &now
+	if userID, exists := c.Get("userID"); exists {
+		moderatorID := userID.(uuid.UUID)
+		comment.ModeratedByID = &moderatorID
+	}
+
+	if err := h.commentRepo.Update(comment); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentUpdateFailed, err)
+		return
+	}
+	if err := h.commentRepo.RefreshArticleCount(comment.ArticleID); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật trạng thái bình luận thành công", comment.ToResponse())
+}
+
+// DeleteComment xóa bình luận (và các trả lời của nó)
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidCommentID, err)
+		return
+	}
+
+	comment, err := h.commentRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentNotFound, err)
+		return
+	}
+
+	if err := h.commentRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrCommentDeleteFailed, err)
+		return
+	}
+	_ = h.commentRepo.RefreshArticleCount(comment.ArticleID)
+
+	helpers.SuccessResponse(c, "Xóa bình luận thành công", nil)
+}
+
+func isCommentStatus(status string) bool {
+	for _, s := range consts.CommentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,444 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/notify"
+	"backend/internal/repo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// File đính kèm phải được upload trong khoảng này trước khi gửi form
+const consultationAttachmentMaxAge = 24 * time.Hour
+
+type ConsultationHandler struct {
+	consultationRepo *repo.ConsultationRepo
+	categoryRepo     *repo.CategoryRepo
+	userRepo         *repo.UserRepository
+	uploadRepo       *repo.UploadRepo
+	usageRepo        *repo.MediaUsageRepo
+}
+
+func NewConsultationHandler(userRepo *repo.UserRepository) *ConsultationHandler {
+	return &ConsultationHandler{
+		consultationRepo: repo.NewConsultationRepo(),
+		categoryRepo:     repo.NewCategoryRepo(),
+		userRepo:         userRepo,
+		uploadRepo:       repo.NewUploadRepo(),
+		usageRepo:        repo.NewMediaUsageRepo(),
+	}
+}
+
+// ConsultationRateLimit đọc giới hạn gửi form tư vấn theo IP từ env
+// CONSULTATION_RATE_LIMIT (mặc định 5 lần) và CONSULTATION_RATE_WINDOW_MINUTES (mặc định 60 phút)
+func ConsultationRateLimit() (int, time.Duration) {
+	limit := 5
+	if v, err := strconv.Atoi(os.Getenv("CONSULTATION_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 60 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("CONSULTATION_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+// CreateConsultation nhận yêu cầu tư vấn từ form công khai
+func (h *ConsultationHandler) CreateConsultation(c *gin.Context) {
+	var input model.ConsultationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	// Honeypot: bot thường điền mọi trường, trả về thành công giả để không lộ cơ chế lọc
+	if strings.TrimSpace(input.Website) != "" {
+		c.JSON(http.StatusCreated, helpers.Response{
+			Success: true,
+			Message: "Gửi yêu cầu tư vấn thành công",
+		})
+		return
+	}
+
+	var categoryName string
+	if input.CategoryID != nil {
+		category, err := h.categoryRepo.GetByID(*input.CategoryID)
+		if err != nil || !category.IsActive {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategory, errors.New("lĩnh vực tư vấn không tồn tại"))
+			return
+		}
+		categoryName = category.Name
+	}
+
+	if apiErr, err := h.validateAttachments(c, input.Attachments); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	request := model.ConsultationRequest{
+		FullName:   strings.TrimSpace(input.FullName),
+		Phone:      strings.TrimSpace(input.Phone),
+		Email:      strings.ToLower(strings.TrimSpace(input.Email)),
+		CategoryID: input.CategoryID,
+		Message:    strings.TrimSpace(input.Message),
+		Status:     consts.ConsultationStatusNew,
+		Locale:     helpers.ResolveLocale(c),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  truncateUTF8(c.Request.UserAgent(), 500),
+	}
+	if len(input.Attachments) > 0 {
+		attachmentsJSON, _ := json.Marshal(input.Attachments)
+		request.Attachments = datatypes.JSON(attachmentsJSON)
+	}
+
+	if err := h.consultationRepo.Create(&request); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationCreateFailed, err)
+		return
+	}
+
+	notify.Send(notify.Notification{
+		Event:   notify.EventConsultationCreated,
+		Title:   "Yêu cầu tư vấn mới",
+		Message: fmt.Sprintf("%s (%s) vừa gửi yêu cầu tư vấn", request.FullName, request.Phone),
+		URL:     "/admin/consultations/" + request.ID.String(),
+		Data: map[string]interface{}{
+			"id":            request.ID,
+			"full_name":     request.FullName,
+			"phone":         request.Phone,
+			"email":         request.Email,
+			"category_name": categoryName,
+			"attachments":   len(input.Attachments),
+		},
+	})
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Gửi yêu cầu tư vấn thành công",
+		Data: map[string]interface{}{
+			"id": request.ID,
+		},
+	})
+}
+
+// validateAttachments chỉ nhận file khách vừa upload bằng vé từ cùng IP (theo sổ upload), chưa bị từ chối
+// và chưa được đính kèm ở nơi khác. Loại file và dung lượng lấy theo sổ upload thay vì tin dữ liệu gửi lên
+func (h *ConsultationHandler) validateAttachments(c *gin.Context, attachments []model.ConsultationAttachment) (*helpers.APIError, error) {
+	seen := make(map[string]bool, len(attachments))
+	since := time.Now().Add(-consultationAttachmentMaxAge)
+	for i := range attachments {
+		key := attachments[i].Key
+		if seen[key] || model.MediaKeyPattern.FindString(key) != key {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("key file đính kèm không hợp lệ: %q", key)
+		}
+		seen[key] = true
+
+		upload, err := h.uploadRepo.GetByKey(key)
+		if err != nil {
+			return helpers.ErrDatabase, err
+		}
+		if upload == nil || upload.TicketID == nil || upload.IPAddress != c.ClientIP() || upload.CreatedAt.Before(since) {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("file đính kèm %q không phải file vừa upload bằng vé", key)
+		}
+		if upload.Status == model.MediaStatusRejected {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("file đính kèm %q đã bị từ chối", key)
+		}
+		usages, err := h.usageRepo.GetByKey(key)
+		if err != nil {
+			return helpers.ErrDatabase, err
+		}
+		if len(usages) > 0 {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("file đính kèm %q đã được dùng", key)
+		}
+
+		attachments[i].ContentType = upload.ContentType
+		attachments[i].Size = upload.DeclaredSize
+		if upload.Size > 0 {
+			attachments[i].Size = upload.Size
+		}
+	}
+	return nil, nil
+}
+
+// GetConsultations lấy hộp thư yêu cầu tư vấn (admin)
+// Query: status, assigned_to (UUID hoặc "none"), category_id, search, page, limit
+func (h *ConsultationHandler) GetConsultations(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repo.ConsultationFilter{
+		Status: strings.TrimSpace(c.Query("status")),
+		Search: strings.TrimSpace(c.Query("search")),
+	}
+	if filter.Status != "" && !isConsultationStatus(filter.Status) {
+		helpers.ErrorResponse(c, helpers.ErrValidation, fmt.Errorf("status không hợp lệ: %s", filter.Status))
+		return
+	}
+	if assignedTo := strings.TrimSpace(c.Query("assigned_to")); assignedTo != "" {
+		if assignedTo == "none" {
+			filter.Unassigned = true
+		} else {
+			id, err := uuid.Parse(assignedTo)
+			if err != nil {
+				helpers.ErrorResponse(c, helpers.ErrInvalidUserID, err)
+				return
+			}
+			filter.AssignedTo = &id
+		}
+	}
+	if categoryID := strings.TrimSpace(c.Query("category_id")); categoryID != "" {
+		id, err := uuid.Parse(categoryID)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, err)
+			return
+		}
+		filter.CategoryID = &id
+	}
+
+	requests, total, err := h.consultationRepo.Search(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationListFailed, err)
+		return
+	}
+
+	counts, err := h.consultationRepo.CountByStatus()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationListFailed, err)
+		return
+	}
+	statusCounts := make(map[string]int64, len(consts.ConsultationStatuses))
+	for _, status := range consts.ConsultationStatuses {
+		statusCounts[status] = counts[status]
+	}
+
+	responses := make([]model.ConsultationResponse, 0, len(requests))
+	for i := range requests {
+		responses = append(responses, requests[i].ToResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách yêu cầu tư vấn thành công", map[string]interface{}{
+		"consultations": responses,
+		"status_counts": statusCounts,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetConsultationByID lấy chi tiết yêu cầu tư vấn kèm ghi chú nội bộ
+func (h *ConsultationHandler) GetConsultationByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidConsultationID, err)
+		return
+	}
+
+	request, err := h.consultationRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin yêu cầu tư vấn thành công", request.ToResponse())
+}
+
+// UpdateConsultationStatus cập nhật trạng thái xử lý (new, assigned, contacted, closed)
+func (h *ConsultationHandler) UpdateConsultationStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidConsultationID, err)
+		return
+	}
+
+	var input model.ConsultationStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	request, err := h.consultationRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	now := time.Now()
+	request.Status = input.Status
+	switch input.Status {
+	case consts.ConsultationStatusContacted:
+		if request.ContactedAt == nil {
+			request.ContactedAt = &now
+		}
+		request.ClosedAt = nil
+	case consts.ConsultationStatusClosed:
+		request.ClosedAt = &now
+	default:
+		request.ClosedAt = nil
+	}
+
+	if err := h.consultationRepo.Update(request); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật trạng thái yêu cầu tư vấn thành công", request.ToResponse())
+}
+
+// AssignConsultation giao yêu cầu tư vấn cho một nhân viên (assignee_id = null để bỏ giao)
+func (h *ConsultationHandler) AssignConsultation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidConsultationID, err)
+		return
+	}
+
+	var input model.ConsultationAssignInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	request, err := h.consultationRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	if input.AssigneeID == nil {
+		request.AssignedToID = nil
+		request.AssignedTo = nil
+		if request.Status == consts.ConsultationStatusAssigned {
+			request.Status = consts.ConsultationStatusNew
+		}
+	} else {
+		assignee, err := h.userRepo.GetUserByID(*input.AssigneeID)
+		if err != nil || !assignee.IsActive {
+			helpers.ErrorResponse(c, helpers.ErrInvalidAssignee, errors.New("người được giao không tồn tại hoặc đã bị vô hiệu hóa"))
+			return
+		}
+		request.AssignedToID = &assignee.ID
+		request.AssignedTo = assignee
+		if request.Status == consts.ConsultationStatusNew {
+			request.Status = consts.ConsultationStatusAssigned
+		}
+	}
+
+	if err := h.consultationRepo.Update(request); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Giao yêu cầu tư vấn thành công", request.ToResponse())
+}
+
+// AddConsultationNote thêm ghi chú nội bộ vào yêu cầu tư vấn
+func (h *ConsultationHandler) AddConsultationNote(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidConsultationID, err)
+		return
+	}
+
+	userIDVal, exists := c.Get("userID")
+	if !exists {
+		helpers.ErrorResponse(c, helpers.ErrUnauthorized, errors.New("userID not found in context"))
+		return
+	}
+
+	var input model.ConsultationNoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.consultationRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	note := model.ConsultationNote{
+		ConsultationID: id,
+		AuthorID:       userIDVal.(uuid.UUID),
+		Content:        strings.TrimSpace(input.Content),
+	}
+	if err := h.consultationRepo.AddNote(&note); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNoteFailed, err)
+		return
+	}
+
+	request, err := h.consultationRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Thêm ghi chú thành công",
+		Data:    request.ToResponse(),
+	})
+}
+
+// DeleteConsultation xóa yêu cầu tư vấn (spam, trùng lặp...)
+func (h *ConsultationHandler) DeleteConsultation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidConsultationID, err)
+		return
+	}
+
+	if _, err := h.consultationRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationNotFound, err)
+		return
+	}
+
+	if err := h.consultationRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrConsultationDeleteFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xóa yêu cầu tư vấn thành công", nil)
+}
+
+func isConsultationStatus(status string) bool {
+	for _, s := range consts.ConsultationStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateUTF8 cắt chuỗi theo số byte tối đa mà không làm vỡ ký tự UTF-8
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && (s[max]&0xC0) == 0x80 {
+		max--
+	}
+	return s[:max]
+}
@@ -1,18 +1,18 @@
-//go:build ignore
-// +build ignore
-
-// Dashboard handler disabled; remove build tag to re-enable when dashboard domain models exist.
 package handle
 
 import (
 	"backend/internal/helpers"
 	"backend/internal/repo"
-	"net/http"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Số tháng không cập nhật để coi bài viết là cũ (mặc định), có thể đổi qua query stale_months
+const defaultStaleMonths = 12
+
 type DashboardHandler struct {
 	repo *repo.DashboardRepo
 }
@@ -23,72 +23,273 @@ func NewDashboardHandler() *DashboardHandler {
 	}
 }
 
-// GetFullOverview - API 1: Tổng quan dashboard (gộp 6 API)
-// Trả về: Summary stats + Revenue chart + Order status + Top products + Top categories + Recent activities
-func (h *DashboardHandler) GetFullOverview(c *gin.Context) {
+// dashboardParams - Tham số chung của mọi báo cáo dashboard
+type dashboardParams struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	Period      string
+	StaleMonths int
+	Limit       int
+}
+
+// parseDashboardParams đọc start_date, end_date (YYYY-MM-DD, mặc định 30 ngày gần nhất),
+// period (day|week|month|year), stale_months và limit. end_date được tính trọn ngày.
+func parseDashboardParams(c *gin.Context) (dashboardParams, error) {
 	endDate := time.Now()
 	startDate := endDate.AddDate(0, 0, -30) // Default 30 days
 
 	if startStr := c.Query("start_date"); startStr != "" {
-		if parsed, err := time.Parse("2006-01-02", startStr); err == nil {
-			startDate = parsed
+		parsed, err := time.ParseInLocation("2006-01-02", startStr, time.Local)
+		if err != nil {
+			return dashboardParams{}, err
 		}
+		startDate = parsed
 	}
 
 	if endStr := c.Query("end_date"); endStr != "" {
-		if parsed, err := time.Parse("2006-01-02", endStr); err == nil {
-			endDate = parsed
+		parsed, err := time.ParseInLocation("2006-01-02", endStr, time.Local)
+		if err != nil {
+			return dashboardParams{}, err
 		}
+		endDate = parsed.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	if startDate.After(endDate) {
+		return dashboardParams{}, errors.New("start_date phải trước end_date")
 	}
 
 	period := c.DefaultQuery("period", "day")
+	switch period {
+	case "day", "week", "month", "year":
+	default:
+		return dashboardParams{}, errors.New("period phải là day, week, month hoặc year")
+	}
 
-	result, err := h.repo.GetFullOverview(startDate, endDate, period)
+	staleMonths, err := strconv.Atoi(c.DefaultQuery("stale_months", strconv.Itoa(defaultStaleMonths)))
+	if err != nil || staleMonths < 1 {
+		staleMonths = defaultStaleMonths
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return dashboardParams{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Period:      period,
+		StaleMonths: staleMonths,
+		Limit:       limit,
+	}, nil
+}
+
+// GetFullOverview - API 1: Tổng quan dashboard (gộp 6 báo cáo)
+// Trả về: Summary + Published chart + Views chart + Top articles + Top categories + Recent articles
+func (h *DashboardHandler) GetFullOverview(c *gin.Context) {
+	params, err := parseDashboardParams(c)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy dữ liệu dashboard", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetFullOverview(params.StartDate, params.EndDate, params.Period, params.StaleMonths)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDashboardFailed, err)
 		return
 	}
 
 	helpers.SuccessResponse(c, "Lấy dữ liệu dashboard thành công", result)
 }
 
-// GetAnalytics - API 2: Phân tích chi tiết (gộp 6 API)
-// Trả về: Category stats + Product stats + Payment stats + Order type stats + Top customers + Revenue chart
+// GetAnalytics - API 2: Phân tích chi tiết (gộp 6 báo cáo)
+// Trả về: Category stats + Tag stats + Top articles + Author productivity + Published chart + Views chart
 func (h *DashboardHandler) GetAnalytics(c *gin.Context) {
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -30)
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
 
-	if startStr := c.Query("start_date"); startStr != "" {
-		if parsed, err := time.Parse("2006-01-02", startStr); err == nil {
-			startDate = parsed
-		}
+	result, err := h.repo.GetAnalytics(params.StartDate, params.EndDate, params.Period)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
 	}
 
-	if endStr := c.Query("end_date"); endStr != "" {
-		if parsed, err := time.Parse("2006-01-02", endStr); err == nil {
-			endDate = parsed
-		}
+	helpers.SuccessResponse(c, "Lấy dữ liệu phân tích thành công", result)
+}
+
+// GetAlerts - API 3: Việc cần xử lý
+// Trả về: Draft backlog + Scheduled queue + Stale articles + Alert counts
+func (h *DashboardHandler) GetAlerts(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
 	}
 
-	period := c.DefaultQuery("period", "day")
+	result, err := h.repo.GetAlerts(params.EndDate, params.StaleMonths, params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAlertsFailed, err)
+		return
+	}
 
-	result, err := h.repo.GetAnalytics(startDate, endDate, period)
+	helpers.SuccessResponse(c, "Lấy cảnh báo thành công", result)
+}
+
+// GetPublishedChart - Số bài xuất bản theo kỳ
+func (h *DashboardHandler) GetPublishedChart(c *gin.Context) {
+	params, err := parseDashboardParams(c)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy dữ liệu phân tích", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "Lấy dữ liệu phân tích thành công", result)
+	result, err := h.repo.GetPublishedByTime(params.StartDate, params.EndDate, params.Period)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê xuất bản thành công", result)
 }
 
-// GetAlerts - API 3: Cảnh báo & hoạt động (gộp 3 API)
-// Trả về: Low stock products + Pending orders + New customers + Recent activities + Alert counts
-func (h *DashboardHandler) GetAlerts(c *gin.Context) {
-	result, err := h.repo.GetAlerts()
+// GetViewsChart - Lượt xem theo kỳ
+func (h *DashboardHandler) GetViewsChart(c *gin.Context) {
+	params, err := parseDashboardParams(c)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy cảnh báo", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "Lấy cảnh báo thành công", result)
+	result, err := h.repo.GetViewsByTime(params.StartDate, params.EndDate, params.Period)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê lượt xem thành công", result)
+}
+
+// GetTopArticles - Bài viết được xem nhiều nhất trong kỳ
+func (h *DashboardHandler) GetTopArticles(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetTopArticles(params.StartDate, params.EndDate, params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy bài viết nổi bật thành công", result)
+}
+
+// GetTopCategories - Danh mục theo lượt xem và số bài xuất bản trong kỳ
+func (h *DashboardHandler) GetTopCategories(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetCategoryStatistics(params.StartDate, params.EndDate, params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê danh mục thành công", result)
+}
+
+// GetTopTags - Tag theo lượt xem và số bài xuất bản trong kỳ
+func (h *DashboardHandler) GetTopTags(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetTagStatistics(params.StartDate, params.EndDate, params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê tag thành công", result)
+}
+
+// GetAuthorProductivity - Năng suất tác giả trong kỳ
+func (h *DashboardHandler) GetAuthorProductivity(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetAuthorProductivity(params.StartDate, params.EndDate)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy năng suất tác giả thành công", result)
+}
+
+// GetDraftBacklog - Bản nháp tồn đọng
+func (h *DashboardHandler) GetDraftBacklog(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetDraftBacklog(params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAlertsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy bản nháp tồn đọng thành công", result)
+}
+
+// GetScheduledQueue - Bài đã đặt lịch xuất bản
+func (h *DashboardHandler) GetScheduledQueue(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetScheduledQueue(params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAlertsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy hàng đợi xuất bản thành công", result)
+}
+
+// GetStaleArticles - Bài đã xuất bản không cập nhật trong stale_months tháng (tính tới end_date)
+func (h *DashboardHandler) GetStaleArticles(c *gin.Context) {
+	params, err := parseDashboardParams(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	result, err := h.repo.GetStaleArticles(params.EndDate, params.StaleMonths, params.Limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAlertsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy bài viết cũ thành công", map[string]interface{}{
+		"stale_months": params.StaleMonths,
+		"articles":     result,
+	})
 }
@@ -0,0 +1,448 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Số câu hỏi tối đa trả về ở trang FAQ công khai
+const faqPublicLimit = 200
+
+type FAQHandler struct {
+	faqRepo      *repo.FAQRepo
+	articleRepo  *repo.ArticleRepo
+	categoryRepo *repo.CategoryRepo
+}
+
+func NewFAQHandler() *FAQHandler {
+	return &FAQHandler{
+		faqRepo:      repo.NewFAQRepo(),
+		articleRepo:  repo.NewArticleRepo(),
+		categoryRepo: repo.NewCategoryRepo(),
+	}
+}
+
+// buildFAQPageJSONLD tạo node schema.org FAQPage từ danh sách câu hỏi
+func buildFAQPageJSONLD(faqs []model.FAQResponse) map[string]interface{} {
+	questions := make([]map[string]interface{}, 0, len(faqs))
+	for _, faq := range faqs {
+		questions = append(questions, map[string]interface{}{
+			"@type": "Question",
+			"name":  faq.Question,
+			"acceptedAnswer": map[string]interface{}{
+				"@type": "Answer",
+				"text":  faq.AnswerText,
+			},
+		})
+	}
+	return map[string]interface{}{
+		"@type":      "FAQPage",
+		"mainEntity": questions,
+	}
+}
+
+// faqListResponse trả về danh sách câu hỏi kèm JSON-LD FAQPage
+func faqListResponse(faqs []model.FAQ) ([]model.FAQResponse, map[string]interface{}) {
+	responses := make([]model.FAQResponse, 0, len(faqs))
+	for i := range faqs {
+		responses = append(responses, faqs[i].ToResponse())
+	}
+	jsonLD := buildFAQPageJSONLD(responses)
+	jsonLD["@context"] = "https://schema.org"
+	return responses, jsonLD
+}
+
+// validateFAQInput chuẩn hóa locale, kiểm tra danh mục và tạo bản text thuần của câu trả lời
+func (h *FAQHandler) validateFAQInput(input *model.FAQInput) (*helpers.APIError, error) {
+	input.Question = strings.TrimSpace(input.Question)
+	if input.Question == "" {
+		return helpers.ErrInvalidFAQ, errors.New("câu hỏi không được để trống")
+	}
+
+	locale, err := normalizeContentLocale(input.Locale)
+	if err != nil {
+		return helpers.ErrInvalidLocale, err
+	}
+	input.Locale = locale
+
+	if input.CategoryID != nil {
+		if _, err := h.categoryRepo.GetByID(*input.CategoryID); err != nil {
+			return helpers.ErrInvalidCategory, errors.New("không tìm thấy danh mục")
+		}
+	}
+
+	input.AnswerText = strings.TrimSpace(input.AnswerText)
+	if input.AnswerText == "" {
+		input.AnswerText = helpers.RichTextToPlain(input.Answer)
+	}
+	if input.AnswerText == "" {
+		return helpers.ErrInvalidFAQ, errors.New("câu trả lời không được để trống")
+	}
+
+	return nil, nil
+}
+
+// applyFAQInput gán dữ liệu đã kiểm tra vào câu hỏi
+func applyFAQInput(faq *model.FAQ, input model.FAQInput) {
+	faq.Question = input.Question
+	faq.Answer = datatypes.JSON(input.Answer)
+	faq.AnswerText = input.AnswerText
+	faq.CategoryID = input.CategoryID
+	faq.Locale = input.Locale
+	if input.DisplayOrder != nil {
+		faq.DisplayOrder = *input.DisplayOrder
+	}
+	if input.IsActive != nil {
+		faq.IsActive = *input.IsActive
+	}
+}
+
+// GetPublicFAQs lấy toàn bộ câu hỏi đang hiển thị theo ngôn ngữ của request
+func (h *FAQHandler) GetPublicFAQs(c *gin.Context) {
+	faqs, err := h.faqRepo.GetActive(repo.FAQFilter{Locale: helpers.ResolveLocale(c)}, faqPublicLimit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQListFailed, err)
+		return
+	}
+
+	responses, jsonLD := faqListResponse(faqs)
+	helpers.SuccessResponse(c, "Lấy danh sách câu hỏi thành công", map[string]interface{}{
+		"faqs":    responses,
+		"json_ld": jsonLD,
+	})
+}
+
+// GetPublicFAQsByCategorySlug lấy câu hỏi đang hiển thị của một danh mục theo ngôn ngữ của request
+func (h *FAQHandler) GetPublicFAQsByCategorySlug(c *gin.Context) {
+	category, err := h.categoryRepo.GetBySlug(c.Param("slug"))
+	if err != nil || !category.IsActive {
+		if err == nil {
+			err = errors.New("category not found")
+		}
+		helpers.ErrorResponse(c, helpers.ErrCategoryNotFound, err)
+		return
+	}
+
+	faqs, err := h.faqRepo.GetActive(repo.FAQFilter{CategoryID: &category.ID, Locale: helpers.ResolveLocale(c)}, faqPublicLimit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQListFailed, err)
+		return
+	}
+
+	responses, jsonLD := faqListResponse(faqs)
+	helpers.SuccessResponse(c, "Lấy danh sách câu hỏi thành công", map[string]interface{}{
+		"category": model.CategorySimpleResponse{
+			ID:   category.ID,
+			Name: category.Name,
+			Slug: category.Slug,
+		},
+		"faqs":    responses,
+		"json_ld": jsonLD,
+	})
+}
+
+// GetFAQs lấy danh sách câu hỏi (admin)
+// Query: search, category_id, locale, is_active, page, limit
+func (h *FAQHandler) GetFAQs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := repo.FAQFilter{
+		Keyword: strings.TrimSpace(c.Query("search")),
+	}
+	if v := strings.TrimSpace(c.Query("category_id")); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidFAQQuery, err)
+			return
+		}
+		filter.CategoryID = &id
+	}
+	if v := strings.TrimSpace(c.Query("locale")); v != "" {
+		filter.Locale = helpers.NormalizeLocale(v)
+		if filter.Locale == "" {
+			helpers.ErrorResponse(c, helpers.ErrInvalidFAQQuery, fmt.Errorf("locale không hợp lệ: %s", v))
+			return
+		}
+	}
+	if v := strings.TrimSpace(c.Query("is_active")); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidFAQQuery, err)
+			return
+		}
+		filter.IsActive = &active
+	}
+
+	faqs, total, err := h.faqRepo.GetAllWithPagination(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQListFailed, err)
+		return
+	}
+
+	responses := make([]model.FAQResponse, 0, len(faqs))
+	for i := range faqs {
+		responses = append(responses, faqs[i].ToResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách câu hỏi thành công", map[string]interface{}{
+		"faqs": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetFAQByID lấy câu hỏi theo ID (admin)
+func (h *FAQHandler) GetFAQByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidFAQID, err)
+		return
+	}
+
+	faq, err := h.faqRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin câu hỏi thành công", faq.ToResponse())
+}
+
+// CreateFAQ tạo câu hỏi mới
+func (h *FAQHandler) CreateFAQ(c *gin.Context) {
+	var input model.FAQInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if apiErr, err := h.validateFAQInput(&input); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	faq := model.FAQ{IsActive: true}
+	applyFAQInput(&faq, input)
+
+	if err := h.faqRepo.Create(&faq); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQCreateFailed, err)
+		return
+	}
+
+	created, err := h.faqRepo.GetByID(faq.ID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQNotFound, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Tạo câu hỏi thành công",
+		Data:    created.ToResponse(),
+	})
+}
+
+// UpdateFAQ cập nhật câu hỏi
+func (h *FAQHandler) UpdateFAQ(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidFAQID, err)
+		return
+	}
+
+	var input model.FAQInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	faq, err := h.faqRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQNotFound, err)
+		return
+	}
+
+	if apiErr, err := h.validateFAQInput(&input); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	applyFAQInput(faq, input)
+
+	if err := h.faqRepo.Update(faq); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQUpdateFailed, err)
+		return
+	}
+
+	updated, err := h.faqRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật câu hỏi thành công", updated.ToResponse())
+}
+
+// DeleteFAQ xóa câu hỏi (gỡ khỏi các bài viết đang nhúng)
+func (h *FAQHandler) DeleteFAQ(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidFAQID, err)
+		return
+	}
+
+	if _, err := h.faqRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQNotFound, err)
+		return
+	}
+
+	if err := h.faqRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQDeleteFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xóa câu hỏi thành công", nil)
+}
+
+// ReorderFAQs cập nhật thứ tự hiển thị của nhiều câu hỏi cùng lúc
+func (h *FAQHandler) ReorderFAQs(c *gin.Context) {
+	var input model.FAQReorderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(input.Items))
+	seen := make(map[uuid.UUID]struct{}, len(input.Items))
+	for _, item := range input.Items {
+		if _, ok := seen[item.ID]; ok {
+			helpers.ErrorResponse(c, helpers.ErrInvalidFAQ, fmt.Errorf("câu hỏi %s xuất hiện nhiều lần", item.ID))
+			return
+		}
+		seen[item.ID] = struct{}{}
+		ids = append(ids, item.ID)
+	}
+	faqs, err := h.faqRepo.GetByIDs(ids)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if len(faqs) != len(ids) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidFAQ, errors.New("một hoặc nhiều câu hỏi không tồn tại"))
+		return
+	}
+
+	if err := h.faqRepo.UpdateOrder(input.Items); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật thứ tự câu hỏi thành công", nil)
+}
+
+// GetArticleFAQs lấy các câu hỏi nhúng trong bài viết (admin)
+func (h *FAQHandler) GetArticleFAQs(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	faqs, err := h.faqRepo.GetByArticle(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQListFailed, err)
+		return
+	}
+
+	responses := make([]model.FAQResponse, 0, len(faqs))
+	for i := range faqs {
+		responses = append(responses, faqs[i].ToResponse())
+	}
+
+	helpers.SuccessResponse(c, "Lấy câu hỏi của bài viết thành công", responses)
+}
+
+// SetArticleFAQs thay thế danh sách câu hỏi nhúng trong bài viết (theo thứ tự)
+func (h *FAQHandler) SetArticleFAQs(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.ArticleFAQsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(input.FAQIDs))
+	for _, faqID := range input.FAQIDs {
+		if _, ok := seen[faqID]; ok {
+			helpers.ErrorResponse(c, helpers.ErrInvalidFAQ, fmt.Errorf("câu hỏi %s xuất hiện nhiều lần", faqID))
+			return
+		}
+		seen[faqID] = struct{}{}
+	}
+	faqs, err := h.faqRepo.GetByIDs(input.FAQIDs)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if len(faqs) != len(input.FAQIDs) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidFAQ, errors.New("một hoặc nhiều câu hỏi không tồn tại"))
+		return
+	}
+
+	if err := h.faqRepo.SetArticleFAQs(id, input.FAQIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQUpdateFailed, err)
+		return
+	}
+
+	linked, err := h.faqRepo.GetByArticle(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFAQListFailed, err)
+		return
+	}
+
+	responses := make([]model.FAQResponse, 0, len(linked))
+	for i := range linked {
+		responses = append(responses, linked[i].ToResponse())
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật câu hỏi của bài viết thành công", responses)
+}
@@ -0,0 +1,200 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FeedbackHandler struct {
+	feedbackRepo *repo.FeedbackRepo
+	articleRepo  *repo.ArticleRepo
+}
+
+func NewFeedbackHandler() *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackRepo: repo.NewFeedbackRepo(),
+		articleRepo:  repo.NewArticleRepo(),
+	}
+}
+
+// FeedbackRateLimit đọc giới hạn gửi đánh giá theo IP từ env
+// FEEDBACK_RATE_LIMIT (mặc định 30 lần) và FEEDBACK_RATE_WINDOW_MINUTES (mặc định 60 phút)
+func FeedbackRateLimit() (int, time.Duration) {
+	limit := 30
+	if v, err := strconv.Atoi(os.Getenv("FEEDBACK_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 60 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("FEEDBACK_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+// SubmitFeedback ghi nhận "bài viết có hữu ích không?"; mỗi khách chỉ có một phiếu cho mỗi bài (gửi lại sẽ cập nhật)
+func (h *FeedbackHandler) SubmitFeedback(c *gin.Context) {
+	var input model.FeedbackInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	article, err := h.articleRepo.GetPublishedBySlug(c.Param("slug"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	feedback := model.ArticleFeedback{
+		ArticleID:   article.ID,
+		VisitorHash: helpers.VisitorHash(c),
+		Helpful:     *input.Helpful,
+		Reason:      strings.TrimSpace(input.Reason),
+		Locale:      helpers.ResolveLocale(c),
+	}
+	if err := h.feedbackRepo.Upsert(&feedback); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackSaveFailed, err)
+		return
+	}
+
+	summary, err := h.feedbackRepo.GetSummary(article.ID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackStatsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cảm ơn bạn đã đánh giá", map[string]interface{}{
+		"helpful": feedback.Helpful,
+		"summary": summary,
+	})
+}
+
+// GetArticleFeedbackStats thống kê đánh giá theo bài viết (admin)
+// Query: category_id, min_votes, sort (not_helpful|helpful|total|ratio), page, limit
+func (h *FeedbackHandler) GetArticleFeedbackStats(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	minVotes, _ := strconv.Atoi(c.DefaultQuery("min_votes", "0"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := repo.FeedbackStatsFilter{
+		MinVotes: minVotes,
+		Sort:     c.Query("sort"),
+	}
+	if categoryID := strings.TrimSpace(c.Query("category_id")); categoryID != "" {
+		id, err := uuid.Parse(categoryID)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidCategoryID, err)
+			return
+		}
+		filter.CategoryID = &id
+	}
+
+	stats, total, err := h.feedbackRepo.GetArticleStats(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackStatsFailed, err)
+		return
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy thống kê đánh giá theo bài viết thành công", map[string]interface{}{
+		"articles": stats,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetCategoryFeedbackStats thống kê đánh giá theo danh mục (admin)
+func (h *FeedbackHandler) GetCategoryFeedbackStats(c *gin.Context) {
+	stats, err := h.feedbackRepo.GetCategoryStats()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackStatsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê đánh giá theo danh mục thành công", stats)
+}
+
+// GetArticleFeedback lấy tổng hợp và các lý do độc giả để lại cho một bài viết (admin)
+// Query: helpful (true|false), page, limit
+func (h *FeedbackHandler) GetArticleFeedback(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	var helpful *bool
+	if v, err := strconv.ParseBool(c.Query("helpful")); err == nil {
+		helpful = &v
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	summary, err := h.feedbackRepo.GetSummary(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackStatsFailed, err)
+		return
+	}
+
+	feedback, total, err := h.feedbackRepo.GetReasons(id, helpful, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrFeedbackStatsFailed, err)
+		return
+	}
+
+	reasons := make([]model.FeedbackReasonResponse, 0, len(feedback))
+	for _, f := range feedback {
+		reasons = append(reasons, model.FeedbackReasonResponse{
+			ID:        f.ID,
+			Helpful:   f.Helpful,
+			Reason:    f.Reason,
+			Locale:    f.Locale,
+			CreatedAt: f.CreatedAt,
+		})
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy đánh giá bài viết thành công", map[string]interface{}{
+		"summary": summary,
+		"reasons": reasons,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package handle
+
+import (
+	"backend/internal/model"
+	"backend/internal/sectiontype"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultHomepageCacheTTL = 5 * time.Minute
+
+// Cache toàn bộ trang chủ (sections + nội dung động) theo ngôn ngữ
+var (
+	homepageCache   = map[string]homepageCacheEntry{}
+	homepageCacheMu sync.RWMutex
+)
+
+type homepageCacheEntry struct {
+	data    []model.HomepageSectionPublicResponse
+	expires time.Time
+}
+
+// homepageCacheTTL đọc HOMEPAGE_CACHE_TTL_SECONDS (mặc định 5 phút, 0 = tắt cache)
+func homepageCacheTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("HOMEPAGE_CACHE_TTL_SECONDS")); err == nil && v >= 0 {
+		return time.Duration(v) * time.Second
+	}
+	return defaultHomepageCacheTTL
+}
+
+func getHomepageCache(locale string) ([]model.HomepageSectionPublicResponse, bool) {
+	homepageCacheMu.RLock()
+	defer homepageCacheMu.RUnlock()
+	entry, ok := homepageCache[locale]
+	if !ok || !time.Now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func setHomepageCache(locale string, data []model.HomepageSectionPublicResponse) {
+	ttl := homepageCacheTTL()
+	if ttl == 0 {
+		return
+	}
+	homepageCacheMu.Lock()
+	homepageCache[locale] = homepageCacheEntry{data: data, expires: time.Now().Add(ttl)}
+	homepageCacheMu.Unlock()
+}
+
+// invalidateHomepageCache xóa cache trang chủ (gọi khi section, bài viết, tác giả, thẩm định, tag, danh mục hoặc chuỗi thay đổi)
+func invalidateHomepageCache() {
+	homepageCacheMu.Lock()
+	homepageCache = map[string]homepageCacheEntry{}
+	homepageCacheMu.Unlock()
+}
+
+// sectionQuery - Tham số truy vấn của section động, lấy từ metadata (đã validate theo schema khi lưu)
+type sectionQuery struct {
+	CategorySlug string      `json:"category_slug"`
+	Limit        int         `json:"limit"`
+	ArticleIDs   []uuid.UUID `json:"article_ids"`
+}
+
+// sectionLimit lấy limit trong metadata, không có thì dùng giá trị mặc định trong schema của loại section
+func sectionLimit(sectionType *sectiontype.Type, query sectionQuery) int {
+	if query.Limit > 0 {
+		return query.Limit
+	}
+	if property, ok := sectionType.Schema.Properties["limit"]; ok {
+		if def, ok := property.Default.(int); ok {
+			return def
+		}
+	}
+	return 6
+}
+
+// articleRange - Vị trí các bài viết của một section trong danh sách bài viết chung
+type articleRange struct {
+	section    int
+	start, end int
+}
+
+// buildPublicSections tạo response công khai và lấy nội dung cho các section động.
+// Tên tag và chuỗi bài viết được gắn một lần cho mọi bài viết của trang. Section lấy nội dung lỗi vẫn được trả về
+// với items rỗng; complete = false để kết quả không bị cache
+func (h *HomepageSectionHandler) buildPublicSections(sections []model.HomepageSection, locale string) ([]model.HomepageSectionPublicResponse, bool) {
+	responses := make([]model.HomepageSectionPublicResponse, 0, len(sections))
+	articles := []model.ArticleResponse{}
+	var ranges []articleRange
+	complete := true
+
+	for i := range sections {
+		response := sections[i].ToPublicResponse()
+		sectionType, ok := sectiontype.Get(sections[i].Type())
+		if !ok || !sectionType.IsDynamic() {
+			responses = append(responses, response)
+			continue
+		}
+
+		var query sectionQuery
+		if len(sections[i].Metadata) > 0 {
+			if err := json.Unmarshal(sections[i].Metadata, &query); err != nil {
+				logrus.Warnf("Invalid metadata for homepage section %s: %v", sections[i].TypeKey, err)
+			}
+		}
+
+		var found []model.Article
+		var err error
+		switch sectionType.Source {
+		case sectiontype.SourceCategoryArticles:
+			found, _, err = h.articles.articleRepo.GetPublishedByCategorySlug(query.CategorySlug, 1, sectionLimit(sectionType, query), locale)
+		case sectiontype.SourceHotArticles:
+			found, err = h.articles.articleRepo.GetFeatured(sectionLimit(sectionType, query), locale)
+		case sectiontype.SourceSelectedArticles:
+			found, err = h.articles.articleRepo.GetPublishedByIDs(query.ArticleIDs)
+		case sectiontype.SourcePopularTags:
+			var tags []model.Tag
+			tags, err = h.articles.tagRepo.GetPopularTags(sectionLimit(sectionType, query), locale)
+			items := make([]model.TagResponse, 0, len(tags))
+			for _, tag := range tags {
+				items = append(items, tag.ToResponse())
+			}
+			response.Items = items
+		default:
+			err = fmt.Errorf("nguồn nội dung %s chưa được hỗ trợ", sectionType.Source)
+		}
+		if err != nil {
+			logrus.Warnf("Failed to resolve homepage section %s: %v", sections[i].TypeKey, err)
+			complete = false
+			response.Items = []interface{}{}
+		}
+
+		if response.Items == nil {
+			start := len(articles)
+			for j := range found {
+				article := found[j].ToResponse()
+				article.Content = nil // Trang chủ chỉ hiển thị thẻ bài viết
+				articles = append(articles, article)
+			}
+			ranges = append(ranges, articleRange{section: len(responses), start: start, end: len(articles)})
+		}
+		responses = append(responses, response)
+	}
+
+	h.articles.attachTagNamesToResponses(articles)
+	h.articles.attachSeriesToResponses(articles, true)
+	for _, r := range ranges {
+		responses[r.section].Items = articles[r.start:r.end]
+	}
+	return responses, complete
+}
@@ -1,11 +1,15 @@
 package handle
 
 import (
+	"backend/internal/consts"
 	"backend/internal/helpers"
 	"backend/internal/model"
 	"backend/internal/repo"
+	"backend/internal/sectiontype"
+	"encoding/json"
 	"errors"
-	"net/http"
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -16,11 +20,13 @@ import (
 
 type HomepageSectionHandler struct {
 	sectionRepo *repo.HomepageSectionRepo
+	articles    *ArticleHandler // Lấy nội dung section động (bài viết, tags)
 }
 
 func NewHomepageSectionHandler() *HomepageSectionHandler {
 	return &HomepageSectionHandler{
 		sectionRepo: repo.NewHomepageSectionRepo(),
+		articles:    NewArticleHandler(),
 	}
 }
 
@@ -28,21 +34,35 @@ func NewHomepageSectionHandler() *HomepageSectionHandler {
 func (h *HomepageSectionHandler) CreateSection(c *gin.Context) {
 	var input model.HomepageSectionInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
-	// Chuẩn hóa type_key
+	// Chuẩn hóa type_key; loại section bỏ trống thì trùng type_key
 	input.TypeKey = strings.ToUpper(strings.TrimSpace(input.TypeKey))
+	sectionType := sectiontype.NormalizeKey(input.SectionType)
+	if sectionType == "" {
+		sectionType = input.TypeKey
+	}
+
+	locale, err := normalizeContentLocale(input.Locale)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+		return
+	}
 
-	// Kiểm tra type_key đã tồn tại chưa
-	exists, err := h.sectionRepo.CheckTypeKeyExists(input.TypeKey, uuid.Nil)
+	if !validateSectionMetadata(c, sectionType, input.Metadata) {
+		return
+	}
+
+	// Kiểm tra type_key đã tồn tại trong ngôn ngữ này chưa
+	exists, err := h.sectionRepo.CheckTypeKeyExists(input.TypeKey, locale, uuid.Nil)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "TypeKey đã tồn tại", errors.New("section với type_key này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrTypeKeyExists, errors.New("section với type_key này đã tồn tại cho ngôn ngữ đã chọn"))
 		return
 	}
 
@@ -61,15 +81,18 @@ func (h *HomepageSectionHandler) CreateSection(c *gin.Context) {
 		Title:       strings.TrimSpace(input.Title),
 		Description: strings.TrimSpace(input.Description),
 		TypeKey:     input.TypeKey,
+		SectionType: sectionType,
 		Metadata:    datatypes.JSON(input.Metadata),
 		Position:    position,
 		ShowHome:    showHome,
+		Locale:      locale,
 	}
 
 	if err := h.sectionRepo.Create(section); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tạo section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionCreateFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	helpers.SuccessResponse(c, "Tạo section thành công", section.ToResponse())
 }
@@ -79,6 +102,7 @@ func (h *HomepageSectionHandler) GetSections(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	search := strings.TrimSpace(c.Query("search"))
+	locale := helpers.NormalizeLocale(c.Query("locale"))
 
 	if page < 1 {
 		page = 1
@@ -92,9 +116,9 @@ func (h *HomepageSectionHandler) GetSections(c *gin.Context) {
 	var err error
 
 	// Use search function that supports both search and pagination
-	sections, total, err = h.sectionRepo.SearchByTitle(search, page, limit)
+	sections, total, err = h.sectionRepo.SearchByTitle(search, locale, page, limit)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách sections", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionListFailed, err)
 		return
 	}
 
@@ -118,32 +142,72 @@ func (h *HomepageSectionHandler) GetSections(c *gin.Context) {
 	})
 }
 
+// validateSectionMetadata kiểm tra loại section đã đăng ký và metadata khớp schema của loại section.
+// Đã trả lỗi theo từng trường về client khi trả false
+func validateSectionMetadata(c *gin.Context, typeKey string, metadata json.RawMessage) bool {
+	sectionType, ok := sectiontype.Get(typeKey)
+	if !ok {
+		helpers.ErrorResponseWithData(c, helpers.ErrUnknownSectionType, nil, gin.H{"available": sectiontype.Keys()})
+		return false
+	}
+
+	violations := sectionType.Validate(metadata)
+	if len(violations) == 0 {
+		return true
+	}
+	fields := make([]helpers.FieldError, 0, len(violations))
+	for _, v := range violations {
+		fields = append(fields, helpers.NewFieldError(c, v.Field, v.Rule, v.Param))
+	}
+	helpers.FieldErrorsResponse(c, fmt.Errorf("metadata không khớp schema của %s", sectionType.Key), fields)
+	return false
+}
+
+// sameJSON so sánh hai giá trị JSON theo nội dung (bỏ qua khoảng trắng và thứ tự khóa)
+func sameJSON(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// GetSectionTypes lấy các loại section và schema metadata, để trang quản trị dựng form theo từng loại
+func (h *HomepageSectionHandler) GetSectionTypes(c *gin.Context) {
+	helpers.SuccessResponse(c, "Lấy danh sách loại section thành công", sectiontype.All())
+}
+
 // GetSectionByID lấy section theo ID
 func (h *HomepageSectionHandler) GetSectionByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidID, err)
 		return
 	}
 
 	section, err := h.sectionRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionNotFound, err)
 		return
 	}
 
 	helpers.SuccessResponse(c, "Lấy section thành công", section.ToResponse())
 }
 
-// GetSectionByTypeKey lấy section theo TypeKey
+// GetSectionByTypeKey lấy section theo TypeKey (query `locale`, mặc định ngôn ngữ mặc định)
 func (h *HomepageSectionHandler) GetSectionByTypeKey(c *gin.Context) {
 	typeKey := c.Param("type_key")
 	typeKey = strings.ToUpper(strings.TrimSpace(typeKey))
 
-	section, err := h.sectionRepo.GetByTypeKey(typeKey)
+	locale := helpers.NormalizeLocale(c.Query("locale"))
+	if locale == "" {
+		locale = consts.DefaultLocale
+	}
+
+	section, err := h.sectionRepo.GetByTypeKey(typeKey, locale, false)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionNotFound, err)
 		return
 	}
 
@@ -155,34 +219,63 @@ func (h *HomepageSectionHandler) UpdateSection(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidID, err)
 		return
 	}
 
 	section, err := h.sectionRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionNotFound, err)
 		return
 	}
 
 	var input model.HomepageSectionInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
-	// Chuẩn hóa type_key
+	// Chuẩn hóa type_key. Loại section bỏ trống thì giữ nguyên, trừ section kiểu cũ (type_key trùng loại)
+	// đổi type_key sang một loại đã đăng ký khác
 	input.TypeKey = strings.ToUpper(strings.TrimSpace(input.TypeKey))
+	sectionType := sectiontype.NormalizeKey(input.SectionType)
+	if sectionType == "" {
+		sectionType = section.Type()
+		if _, registered := sectiontype.Get(input.TypeKey); registered && section.Type() == section.TypeKey {
+			sectionType = input.TypeKey
+		}
+	}
+
+	locale := section.Locale
+	if strings.TrimSpace(input.Locale) != "" {
+		locale, err = normalizeContentLocale(input.Locale)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+			return
+		}
+	}
+
+	// Metadata không gửi lên thì giữ nguyên. Chỉ kiểm tra schema khi đổi loại section hoặc metadata, để section lưu
+	// trước khi có schema (hoặc trước khi schema chặt hơn) vẫn sửa được tiêu đề, vị trí, hiển thị
+	metadata := json.RawMessage(section.Metadata)
+	if input.Metadata != nil {
+		metadata = input.Metadata
+	}
+	if sectionType != section.Type() || !sameJSON(metadata, section.Metadata) {
+		if !validateSectionMetadata(c, sectionType, metadata) {
+			return
+		}
+	}
 
-	// Kiểm tra type_key đã tồn tại ở section khác chưa
-	if input.TypeKey != section.TypeKey {
-		exists, err := h.sectionRepo.CheckTypeKeyExists(input.TypeKey, id)
+	// Kiểm tra type_key đã tồn tại ở section khác cùng ngôn ngữ chưa
+	if input.TypeKey != section.TypeKey || locale != section.Locale {
+		exists, err := h.sectionRepo.CheckTypeKeyExists(input.TypeKey, locale, id)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 			return
 		}
 		if exists {
-			helpers.ErrorResponse(c, http.StatusConflict, "TypeKey đã tồn tại", errors.New("section với type_key này đã tồn tại"))
+			helpers.ErrorResponse(c, helpers.ErrTypeKeyExists, errors.New("section với type_key này đã tồn tại cho ngôn ngữ đã chọn"))
 			return
 		}
 	}
@@ -191,10 +284,10 @@ func (h *HomepageSectionHandler) UpdateSection(c *gin.Context) {
 	section.Title = strings.TrimSpace(input.Title)
 	section.Description = strings.TrimSpace(input.Description)
 	section.TypeKey = input.TypeKey
+	section.SectionType = sectionType
+	section.Locale = locale
 
-	if input.Metadata != nil {
-		section.Metadata = datatypes.JSON(input.Metadata)
-	}
+	section.Metadata = datatypes.JSON(metadata)
 
 	if input.Position != nil {
 		section.Position = *input.Position
@@ -205,9 +298,10 @@ func (h *HomepageSectionHandler) UpdateSection(c *gin.Context) {
 	}
 
 	if err := h.sectionRepo.Update(section); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể cập nhật section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionUpdateFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	helpers.SuccessResponse(c, "Cập nhật section thành công", section.ToResponse())
 }
@@ -217,57 +311,68 @@ func (h *HomepageSectionHandler) DeleteSection(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID không hợp lệ", err)
+		helpers.ErrorResponse(c, helpers.ErrInvalidID, err)
 		return
 	}
 
 	// Kiểm tra section có tồn tại không
 	_, err = h.sectionRepo.GetByID(id)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionNotFound, err)
 		return
 	}
 
 	if err := h.sectionRepo.Delete(id); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể xóa section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionDeleteFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	helpers.SuccessResponse(c, "Xóa section thành công", nil)
 }
 
-// GetPublicSections lấy danh sách sections công khai (cho người dùng)
+// GetPublicSections lấy toàn bộ trang chủ trong một request: sections công khai kèm nội dung của section động
+// (bài viết, tags). Kết quả được cache theo ngôn ngữ (HOMEPAGE_CACHE_TTL_SECONDS)
 func (h *HomepageSectionHandler) GetPublicSections(c *gin.Context) {
-	sections, err := h.sectionRepo.GetPublic()
+	locale := helpers.ResolveLocale(c)
+	if responses, ok := getHomepageCache(locale); ok {
+		helpers.SuccessResponse(c, "Lấy danh sách sections thành công", responses)
+		return
+	}
+
+	sections, err := h.sectionRepo.GetPublic(locale)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách sections", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionListFailed, err)
 		return
 	}
 
-	var responses []model.HomepageSectionPublicResponse
-	for _, section := range sections {
-		responses = append(responses, section.ToPublicResponse())
+	responses, complete := h.buildPublicSections(sections, locale)
+	if complete {
+		setHomepageCache(locale, responses)
 	}
 
 	helpers.SuccessResponse(c, "Lấy danh sách sections thành công", responses)
 }
 
-// GetPublicSectionByTypeKey lấy section công khai theo TypeKey
+// GetPublicSectionByTypeKey lấy section công khai theo TypeKey (kèm nội dung nếu là section động)
+// Nếu chưa có bản cho ngôn ngữ của request, bản ngôn ngữ mặc định được trả về
 func (h *HomepageSectionHandler) GetPublicSectionByTypeKey(c *gin.Context) {
 	typeKey := c.Param("type_key")
 	typeKey = strings.ToUpper(strings.TrimSpace(typeKey))
 
-	section, err := h.sectionRepo.GetByTypeKey(typeKey)
+	locale := helpers.ResolveLocale(c)
+	section, err := h.sectionRepo.GetByTypeKey(typeKey, locale, true)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy section", err)
+		helpers.ErrorResponse(c, helpers.ErrSectionNotFound, err)
 		return
 	}
 
 	// Chỉ trả về nếu show_home = true
 	if !section.ShowHome {
-		helpers.ErrorResponse(c, http.StatusNotFound, "Section không được hiển thị", errors.New("section is hidden"))
+		helpers.ErrorResponse(c, helpers.ErrSectionHidden, errors.New("section is hidden"))
 		return
 	}
 
-	helpers.SuccessResponse(c, "Lấy section thành công", section.ToPublicResponse())
+	responses, _ := h.buildPublicSections([]model.HomepageSection{*section}, locale)
+	helpers.SuccessResponse(c, "Lấy section thành công", responses[0])
 }
@@ -0,0 +1,486 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Số bài viết trích dẫn tối đa hiển thị ở trang chi tiết văn bản
+const legalDocumentCitingLimit = 20
+
+type LegalDocumentHandler struct {
+	documentRepo *repo.LegalDocumentRepo
+	articleRepo  *repo.ArticleRepo
+	categoryRepo *repo.CategoryRepo
+	mediaRepo    *repo.MediaRepo
+}
+
+func NewLegalDocumentHandler() *LegalDocumentHandler {
+	return &LegalDocumentHandler{
+		documentRepo: repo.NewLegalDocumentRepo(),
+		articleRepo:  repo.NewArticleRepo(),
+		categoryRepo: repo.NewCategoryRepo(),
+		mediaRepo:    repo.NewMediaRepo(),
+	}
+}
+
+// toLegalDocumentResponse thêm nhãn loại văn bản và link tải file đính kèm
+func toLegalDocumentResponse(doc *model.LegalDocument) model.LegalDocumentResponse {
+	resp := doc.ToResponse()
+	resp.DocTypeLabel = consts.LegalDocTypeLabels[doc.DocType]
+	for i := range resp.Attachments {
+		resp.Attachments[i].URL = objectURL(resp.Attachments[i].Key)
+	}
+	return resp
+}
+
+// parseLegalDocumentFilter đọc bộ lọc từ query: search, doc_type, status, issuing_authority, category_id, year
+func parseLegalDocumentFilter(c *gin.Context) (repo.LegalDocumentFilter, error) {
+	filter := repo.LegalDocumentFilter{
+		Keyword:          strings.TrimSpace(c.Query("search")),
+		DocType:          strings.TrimSpace(c.Query("doc_type")),
+		Status:           strings.TrimSpace(c.Query("status")),
+		IssuingAuthority: strings.TrimSpace(c.Query("issuing_authority")),
+	}
+
+	if _, ok := consts.LegalDocTypeLabels[filter.DocType]; filter.DocType != "" && !ok {
+		return filter, fmt.Errorf("doc_type không hợp lệ: %s", filter.DocType)
+	}
+	if filter.Status != "" && !isLegalDocStatus(filter.Status) {
+		return filter, fmt.Errorf("status không hợp lệ: %s", filter.Status)
+	}
+	if v := strings.TrimSpace(c.Query("category_id")); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.CategoryID = &id
+	}
+	if v := strings.TrimSpace(c.Query("year")); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil || year < 1900 || year > 2100 {
+			return filter, fmt.Errorf("year không hợp lệ: %s", v)
+		}
+		filter.Year = year
+	}
+	return filter, nil
+}
+
+// validateDocumentInput chuẩn hóa slug, kiểm tra slug, danh mục, ngày hiệu lực và file đính kèm.
+// existing là văn bản đang sửa (nil khi tạo mới)
+func (h *LegalDocumentHandler) validateDocumentInput(input *model.LegalDocumentInput, existing *model.LegalDocument) (*helpers.APIError, error) {
+	documentID := uuid.Nil
+	if existing != nil {
+		documentID = existing.ID
+	}
+	input.Title = strings.TrimSpace(input.Title)
+	input.DocumentNumber = strings.TrimSpace(input.DocumentNumber)
+	input.IssuingAuthority = strings.TrimSpace(input.IssuingAuthority)
+	input.Slug = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input.Slug), " ", "-"))
+
+	exists, err := h.documentRepo.CheckSlugExists(input.Slug, documentID)
+	if err != nil {
+		return helpers.ErrDatabase, err
+	}
+	if exists {
+		return helpers.ErrSlugExists, errors.New("văn bản với slug này đã tồn tại")
+	}
+
+	if input.CategoryID != nil {
+		if _, err := h.categoryRepo.GetByID(*input.CategoryID); err != nil {
+			return helpers.ErrInvalidCategory, errors.New("không tìm thấy danh mục")
+		}
+	}
+
+	if input.EffectiveDate != nil && input.ExpiryDate != nil && input.ExpiryDate.Before(*input.EffectiveDate) {
+		return helpers.ErrInvalidLegalDocumentDates, errors.New("ngày hết hiệu lực phải sau ngày có hiệu lực")
+	}
+	if input.IssueDate != nil && input.EffectiveDate != nil && input.EffectiveDate.Before(*input.IssueDate) {
+		return helpers.ErrInvalidLegalDocumentDates, errors.New("ngày có hiệu lực phải sau ngày ban hành")
+	}
+
+	return h.validateAttachments(input.Attachments, existing)
+}
+
+// validateAttachments chỉ nhận file công khai trong thư viện media chưa bị từ chối (link tải là link trực tiếp).
+// File đã đính kèm sẵn trong văn bản đang sửa được giữ nguyên; loại file và dung lượng lấy theo thư viện
+func (h *LegalDocumentHandler) validateAttachments(attachments []model.DocumentAttachment, existing *model.LegalDocument) (*helpers.APIError, error) {
+	current := map[string]bool{}
+	if existing != nil {
+		for _, attachment := range existing.GetAttachments() {
+			current[attachment.Key] = true
+		}
+	}
+
+	for i := range attachments {
+		key := attachments[i].Key
+		if current[key] {
+			continue
+		}
+		if model.MediaKeyPattern.FindString(key) != key {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("key file đính kèm không hợp lệ: %q", key)
+		}
+		asset, err := h.mediaRepo.GetByKey(key)
+		if err != nil {
+			return helpers.ErrDatabase, err
+		}
+		if asset == nil || asset.DeletedAt.Valid {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("file đính kèm %q không có trong thư viện media", key)
+		}
+		if asset.Status == model.MediaStatusRejected || asset.IsPrivate() {
+			return helpers.ErrInvalidAttachment, fmt.Errorf("file đính kèm %q bị từ chối hoặc là file riêng tư", key)
+		}
+		attachments[i].ContentType = asset.ContentType
+		attachments[i].Size = asset.Size
+	}
+	return nil, nil
+}
+
+// GetPublicLegalDocuments lấy thư viện văn bản công khai kèm bộ lọc (facets)
+// Query: search, doc_type, status, issuing_authority, category_id, year, page, limit
+func (h *LegalDocumentHandler) GetPublicLegalDocuments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter, err := parseLegalDocumentFilter(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocumentQuery, err)
+		return
+	}
+	filter.PublicOnly = true
+
+	docs, total, err := h.documentRepo.Search(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentListFailed, err)
+		return
+	}
+
+	facets, err := h.documentRepo.GetFacets(filter)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentListFailed, err)
+		return
+	}
+	for i := range facets.DocTypes {
+		facets.DocTypes[i].Label = consts.LegalDocTypeLabels[facets.DocTypes[i].Value]
+	}
+
+	responses := make([]model.LegalDocumentResponse, 0, len(docs))
+	for i := range docs {
+		resp := toLegalDocumentResponse(&docs[i])
+		resp.Content = nil
+		responses = append(responses, resp)
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách văn bản thành công", map[string]interface{}{
+		"documents": responses,
+		"facets":    facets,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetPublicLegalDocumentBySlug lấy chi tiết văn bản công khai kèm các bài viết trích dẫn
+func (h *LegalDocumentHandler) GetPublicLegalDocumentBySlug(c *gin.Context) {
+	doc, err := h.documentRepo.GetActiveBySlug(c.Param("slug"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentNotFound, err)
+		return
+	}
+
+	articles, err := h.documentRepo.GetCitingArticles(doc.ID, legalDocumentCitingLimit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentFetchFailed, err)
+		return
+	}
+
+	resp := toLegalDocumentResponse(doc)
+	resp.CitingArticles = make([]model.ArticleSummary, 0, len(articles))
+	for _, article := range articles {
+		resp.CitingArticles = append(resp.CitingArticles, model.ArticleSummary{
+			ID:          article.ID,
+			Title:       article.Title,
+			Slug:        article.Slug,
+			Description: article.Description,
+			Status:      article.Status,
+			PublishedAt: article.PublishedAt,
+			CreatedAt:   article.CreatedAt,
+			UpdatedAt:   article.UpdatedAt,
+		})
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin văn bản thành công", resp)
+}
+
+// GetLegalDocuments lấy danh sách văn bản (admin)
+// Query: search, doc_type, status, issuing_authority, category_id, year, page, limit
+func (h *LegalDocumentHandler) GetLegalDocuments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter, err := parseLegalDocumentFilter(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocumentQuery, err)
+		return
+	}
+
+	docs, total, err := h.documentRepo.Search(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentListFailed, err)
+		return
+	}
+
+	responses := make([]model.LegalDocumentResponse, 0, len(docs))
+	for i := range docs {
+		responses = append(responses, toLegalDocumentResponse(&docs[i]))
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách văn bản thành công", map[string]interface{}{
+		"documents": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetLegalDocumentByID lấy văn bản theo ID (admin)
+func (h *LegalDocumentHandler) GetLegalDocumentByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocumentID, err)
+		return
+	}
+
+	doc, err := h.documentRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin văn bản thành công", toLegalDocumentResponse(doc))
+}
+
+// CreateLegalDocument tạo văn bản mới
+func (h *LegalDocumentHandler) CreateLegalDocument(c *gin.Context) {
+	var input model.LegalDocumentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if apiErr, err := h.validateDocumentInput(&input, nil); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	doc := model.LegalDocument{
+		Status:   consts.LegalDocStatusInForce,
+		IsActive: true,
+	}
+	doc.ApplyInput(input)
+
+	if err := h.documentRepo.Create(&doc); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentCreateFailed, err)
+		return
+	}
+
+	created, err := h.documentRepo.GetByID(doc.ID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentFetchFailed, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Tạo văn bản thành công",
+		Data:    toLegalDocumentResponse(created),
+	})
+}
+
+// UpdateLegalDocument cập nhật văn bản
+func (h *LegalDocumentHandler) UpdateLegalDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocumentID, err)
+		return
+	}
+
+	var input model.LegalDocumentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	doc, err := h.documentRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentNotFound, err)
+		return
+	}
+
+	if apiErr, err := h.validateDocumentInput(&input, doc); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	doc.ApplyInput(input)
+
+	if err := h.documentRepo.Update(doc); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentUpdateFailed, err)
+		return
+	}
+
+	updated, err := h.documentRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentFetchFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật văn bản thành công", toLegalDocumentResponse(updated))
+}
+
+// DeleteLegalDocument xóa văn bản (gỡ liên kết khỏi các bài viết trích dẫn)
+func (h *LegalDocumentHandler) DeleteLegalDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocumentID, err)
+		return
+	}
+
+	if _, err := h.documentRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentNotFound, err)
+		return
+	}
+
+	if err := h.documentRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentDeleteFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xóa văn bản thành công", nil)
+}
+
+// GetArticleLegalDocuments lấy các văn bản bài viết trích dẫn (admin)
+func (h *LegalDocumentHandler) GetArticleLegalDocuments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	docs, err := h.documentRepo.GetByArticle(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentListFailed, err)
+		return
+	}
+
+	responses := make([]model.LegalDocumentSimpleResponse, 0, len(docs))
+	for i := range docs {
+		responses = append(responses, docs[i].ToSimpleResponse())
+	}
+
+	helpers.SuccessResponse(c, "Lấy văn bản trích dẫn thành công", responses)
+}
+
+// SetArticleLegalDocuments thay thế danh sách văn bản bài viết trích dẫn (theo thứ tự)
+func (h *LegalDocumentHandler) SetArticleLegalDocuments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.ArticleLegalDocumentsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(input.DocumentIDs))
+	for _, documentID := range input.DocumentIDs {
+		if _, ok := seen[documentID]; ok {
+			helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocument, fmt.Errorf("văn bản %s xuất hiện nhiều lần", documentID))
+			return
+		}
+		seen[documentID] = struct{}{}
+	}
+	docs, err := h.documentRepo.GetByIDs(input.DocumentIDs)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if len(docs) != len(input.DocumentIDs) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLegalDocument, errors.New("một hoặc nhiều văn bản không tồn tại"))
+		return
+	}
+
+	if err := h.documentRepo.SetArticleDocuments(id, input.DocumentIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentUpdateFailed, err)
+		return
+	}
+
+	linked, err := h.documentRepo.GetByArticle(id, false)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalDocumentListFailed, err)
+		return
+	}
+
+	responses := make([]model.LegalDocumentSimpleResponse, 0, len(linked))
+	for i := range linked {
+		responses = append(responses, linked[i].ToSimpleResponse())
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật văn bản trích dẫn thành công", responses)
+}
+
+func isLegalDocStatus(status string) bool {
+	for _, s := range consts.LegalDocStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
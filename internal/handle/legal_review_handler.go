@@ -0,0 +1,139 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/legalreview"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LegalReviewHandler quản lý hàng đợi rà soát pháp lý của bài viết
+type LegalReviewHandler struct {
+	reviewRepo  *repo.LegalReviewRepo
+	articleRepo *repo.ArticleRepo
+	profileRepo *repo.AuthorProfileRepo
+}
+
+func NewLegalReviewHandler() *LegalReviewHandler {
+	return &LegalReviewHandler{
+		reviewRepo:  repo.NewLegalReviewRepo(),
+		articleRepo: repo.NewArticleRepo(),
+		profileRepo: repo.NewAuthorProfileRepo(),
+	}
+}
+
+// GetNeedsReviewArticles lấy hàng đợi bài viết cần rà soát pháp lý
+// Query: author_id, page, limit
+func (h *LegalReviewHandler) GetNeedsReviewArticles(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	var authorID *uuid.UUID
+	if v := strings.TrimSpace(c.Query("author_id")); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidUserID, err)
+			return
+		}
+		authorID = &id
+	}
+
+	articles, total, err := h.reviewRepo.GetNeedsReview(authorID, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalReviewListFailed, err)
+		return
+	}
+
+	responses := make([]model.NeedsReviewArticleResponse, 0, len(articles))
+	for i := range articles {
+		responses = append(responses, articles[i].ToNeedsReviewResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách bài viết cần rà soát thành công", map[string]interface{}{
+		"articles": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// MarkArticleReviewed ghi nhận bài viết đã được rà soát pháp lý và gỡ khỏi hàng đợi
+func (h *LegalReviewHandler) MarkArticleReviewed(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	var input model.LegalReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.articleRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	now := time.Now()
+	if input.ReviewedByID != nil {
+		if _, err := h.profileRepo.GetByID(*input.ReviewedByID); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidReviewer, err)
+			return
+		}
+	}
+	if input.NextReviewBy != nil && !input.NextReviewBy.After(now) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidReviewDate, nil)
+		return
+	}
+
+	if err := h.reviewRepo.MarkReviewed(id, input.ReviewedByID, now, input.NextReviewBy); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrLegalReviewFailed, err)
+		return
+	}
+	invalidateRelatedCache()
+	invalidateHomepageCache()
+
+	updated, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleReloadFailed, err)
+		return
+	}
+
+	resp := updated.ToResponse()
+	legalReview := updated.ToLegalReviewResponse()
+	resp.LegalReview = &legalReview
+
+	helpers.SuccessResponse(c, "Đã ghi nhận rà soát pháp lý", resp)
+}
+
+// RunLegalReviewCheck chạy ngay job quét bài viết cần rà soát (không chờ lịch định kỳ)
+func (h *LegalReviewHandler) RunLegalReviewCheck(c *gin.Context) {
+	result, err := legalreview.Run(time.Now())
+	if err != nil {
+		// Các bài đã đánh dấu trước khi lỗi vẫn được gửi tổng hợp
+		helpers.ErrorResponseWithData(c, helpers.ErrLegalReviewFailed, err, result)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Đã quét bài viết cần rà soát", result)
+}
@@ -0,0 +1,145 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/storage"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocalMediaHandler - Route /media cho driver lưu trữ local: nhận upload qua form đã ký và phục vụ file
+type LocalMediaHandler struct{}
+
+func NewLocalMediaHandler() *LocalMediaHandler {
+	return &LocalMediaHandler{}
+}
+
+// localStorage lấy driver local; route /media không dùng được với driver khác
+func localStorage(c *gin.Context) (*storage.Local, bool) {
+	store, err := storage.Get()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
+		return nil, false
+	}
+	local, ok := store.(*storage.Local)
+	if !ok {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, nil)
+		return nil, false
+	}
+	return local, true
+}
+
+// ServeMedia trả file (GET/HEAD). File công khai như bucket public; link có chữ ký thì kiểm tra hạn.
+// File riêng tư và file bị cách ly chỉ xem được qua link có chữ ký
+func (h *LocalMediaHandler) ServeMedia(c *gin.Context) {
+	local, ok := localStorage(c)
+	if !ok {
+		return
+	}
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	signed := c.Query("signature") != ""
+	if signed {
+		if err := local.VerifySignature(http.MethodGet, key, c.Request.URL.Query(), time.Now()); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidStorageSignature, err)
+			return
+		}
+	}
+
+	// Serve kiểm tra khu không công khai trên key đã chuẩn hóa
+	if err := local.Serve(c.Writer, c.Request, key, signed); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+	}
+}
+
+// Dung lượng dành cho các field của form upload (ngoài file)
+const localUploadFormOverheadBytes = 64 << 10
+
+// sizeLimitReader trả lỗi storage.ErrTooLarge khi đọc quá max bytes
+type sizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, storage.ErrTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, storage.ErrTooLarge
+	}
+	return n, err
+}
+
+// UploadMedia nhận form POST do /upload/s3 cấp (thay cho presigned POST của S3): các field có chữ ký
+// đứng trước, field "file" đứng cuối; content type và kích thước file phải đúng điều kiện đã ký
+func (h *LocalMediaHandler) UploadMedia(c *gin.Context) {
+	local, ok := localStorage(c)
+	if !ok {
+		return
+	}
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, local.MaxUploadBytes+localUploadFormOverheadBytes)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidUploadForm, err)
+		return
+	}
+
+	fields := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidUploadForm, err)
+			return
+		}
+		if part.FormName() != "file" {
+			value, err := io.ReadAll(io.LimitReader(part, localUploadFormOverheadBytes))
+			if err != nil {
+				helpers.ErrorResponse(c, helpers.ErrInvalidUploadForm, err)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		policy, err := local.VerifyPost(key, fields, time.Now())
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidStorageSignature, err)
+			return
+		}
+		info, err := local.Put(c.Request.Context(), key, &sizeLimitReader{r: part, remaining: policy.MaxSize}, -1, storage.PutOptions{
+			ContentType: policy.ContentType,
+		})
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.Is(err, storage.ErrTooLarge) || errors.As(err, &tooLarge) {
+				helpers.ErrorResponse(c, helpers.ErrUploadTooLarge, err)
+				return
+			}
+			helpers.ErrorResponse(c, helpers.ErrStorageError, err)
+			return
+		}
+		if info.Size < policy.MinSize {
+			_ = local.Remove(c.Request.Context(), key)
+			helpers.ErrorResponse(c, helpers.ErrInvalidUploadForm, nil)
+			return
+		}
+
+		// Giống S3: 204 kèm ETag để client kiểm tra
+		c.Header("ETag", `"`+info.ETag+`"`)
+		c.Status(http.StatusNoContent)
+		return
+	}
+}
@@ -0,0 +1,417 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/newsletter"
+	"backend/internal/storage"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMediaDownloadURLTTL    = 60 // Giây hiệu lực của link ký khi chuyển hướng tải (MEDIA_DOWNLOAD_URL_TTL_SECONDS)
+	defaultSubscriberLinkTTLHours = 72 // Giờ hiệu lực của link tải gửi qua email (MEDIA_SUBSCRIBER_LINK_TTL_HOURS)
+)
+
+var errInvalidDownloadToken = errors.New("link tải không hợp lệ")
+
+func mediaDownloadURLTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_DOWNLOAD_URL_TTL_SECONDS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return defaultMediaDownloadURLTTL * time.Second
+}
+
+func subscriberLinkTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_SUBSCRIBER_LINK_TTL_HOURS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return defaultSubscriberLinkTTLHours * time.Hour
+}
+
+// DownloadLinkRateLimit đọc cấu hình giới hạn số lần xin link tải qua email mỗi IP (mặc định 5 lần / 60 phút)
+func DownloadLinkRateLimit() (int, time.Duration) {
+	limit := 5
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_DOWNLOAD_LINK_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 60 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_DOWNLOAD_LINK_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+// signDownloadToken ký bài viết + file + người đăng ký + hạn của link (MEDIA_DOWNLOAD_SECRET, mặc định dùng khóa JWT)
+func signDownloadToken(articleID, mediaID, subscriberID uuid.UUID, expires int64) string {
+	secret := os.Getenv("MEDIA_DOWNLOAD_SECRET")
+	if secret == "" {
+		secret = consts.JWT_SECRET_KEY
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "media-download\n%s\n%s\n%s\n%d", articleID, mediaID, subscriberID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encodeDownloadToken tạo token dạng {subscriber_id}.{expires}.{signature}
+func encodeDownloadToken(articleID, mediaID, subscriberID uuid.UUID, expiresAt time.Time) string {
+	exp := expiresAt.Unix()
+	return fmt.Sprintf("%s.%d.%s", subscriberID, exp, signDownloadToken(articleID, mediaID, subscriberID, exp))
+}
+
+// parseDownloadToken kiểm tra chữ ký và hạn của token, trả về ID người đăng ký
+func parseDownloadToken(raw string, articleID, mediaID uuid.UUID, now time.Time) (uuid.UUID, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, errInvalidDownloadToken
+	}
+	subscriberID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errInvalidDownloadToken
+	}
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() > exp {
+		return uuid.Nil, errInvalidDownloadToken
+	}
+	if !hmac.Equal([]byte(signDownloadToken(articleID, mediaID, subscriberID, exp)), []byte(parts[2])) {
+		return uuid.Nil, errInvalidDownloadToken
+	}
+	return subscriberID, nil
+}
+
+// currentUserID lấy người dùng đã đăng nhập (route dùng OptionalAuthMiddleware)
+func currentUserID(c *gin.Context) *uuid.UUID {
+	if v, exists := c.Get("userID"); exists {
+		id := v.(uuid.UUID)
+		return &id
+	}
+	return nil
+}
+
+// isStaff kiểm tra người gọi đã đăng nhập với vai trò quản trị; có token hợp lệ chưa đủ vì ai cũng tự đăng ký được
+func isStaff(c *gin.Context) bool {
+	return currentUserID(c) != nil && helpers.IsStaffRole(c.GetString("user_role"))
+}
+
+// checkDownloadable kiểm tra file tải được: file bị từ chối đã bị cách ly, file chưa quét xong chỉ quản trị tải được.
+// Đã trả lỗi về client khi trả false
+func checkDownloadable(c *gin.Context, asset *model.MediaAsset, staff bool) bool {
+	if asset.Status == model.MediaStatusRejected {
+		helpers.ErrorResponse(c, helpers.ErrMediaRejected, nil)
+		return false
+	}
+	if asset.Status != model.MediaStatusClean && !staff {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotClean, nil)
+		return false
+	}
+	return true
+}
+
+// sendDownload ghi nhật ký tải rồi chuyển hướng tới link ký ngắn hạn của file gốc.
+// Query redirect=false trả về link dạng JSON (dùng cho client tự tải)
+func (h *MediaHandler) sendDownload(c *gin.Context, asset *model.MediaAsset, download *model.MediaDownload) {
+	store, err := storage.Get()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
+		return
+	}
+	ttl := mediaDownloadURLTTL()
+	url, err := store.PresignGet(c.Request.Context(), asset.StorageKey(), ttl)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaDownloadFailed, err)
+		return
+	}
+
+	download.MediaID = asset.ID
+	download.UserID = currentUserID(c)
+	download.IPAddress = c.ClientIP()
+	download.UserAgent = truncateUTF8(c.Request.UserAgent(), 500)
+	if err := h.mediaRepo.RecordDownload(download); err != nil {
+		logrus.Warn("Failed to record media download: ", err)
+	}
+
+	c.Header("Cache-Control", "no-store")
+	if c.Query("redirect") == "false" {
+		helpers.SuccessResponse(c, "Tạo link tải file thành công", gin.H{
+			"url":        url,
+			"expires_at": time.Now().Add(ttl),
+		})
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+// DownloadMedia tải file qua backend: file công khai ai cũng tải được, file riêng tư cần đăng nhập quản trị.
+// Mỗi lượt tải được đếm và ghi nhật ký
+func (h *MediaHandler) DownloadMedia(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	staff := isStaff(c)
+	if !checkDownloadable(c, asset, staff) {
+		return
+	}
+	access := model.MediaAccessPublic
+	if asset.IsPrivate() {
+		if !staff {
+			helpers.ErrorResponse(c, helpers.ErrMediaAccessDenied, nil)
+			return
+		}
+		access = model.MediaAccessStaff
+	}
+
+	h.sendDownload(c, asset, &model.MediaDownload{Access: access})
+}
+
+// DownloadArticleAttachment tải tệp đính kèm của bài viết đã xuất bản.
+// Tệp chỉ dành cho người đăng ký cần token trong link gửi qua email (xem RequestAttachmentLink) hoặc đăng nhập quản trị
+func (h *MediaHandler) DownloadArticleAttachment(c *gin.Context) {
+	mediaID, err := uuid.Parse(c.Param("media_id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	staff := isStaff(c)
+	article, err := h.articleRepo.GetBySlug(c.Param("slug"))
+	if err != nil || (!staff && !article.IsPublished()) {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+	attachment, ok := article.FindAttachment(mediaID)
+	if !ok {
+		helpers.ErrorResponse(c, helpers.ErrAttachmentNotFound, nil)
+		return
+	}
+	asset, err := h.mediaRepo.GetByID(mediaID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAttachmentNotFound, err)
+		return
+	}
+	if !checkDownloadable(c, asset, staff) {
+		return
+	}
+
+	download := &model.MediaDownload{ArticleID: &article.ID, Access: model.MediaAccessPublic}
+	switch {
+	case staff:
+		download.Access = model.MediaAccessStaff
+	case attachment.SubscribersOnly:
+		token := c.Query("token")
+		if token == "" {
+			helpers.ErrorResponse(c, helpers.ErrSubscribersOnly, nil)
+			return
+		}
+		subscriberID, err := parseDownloadToken(token, article.ID, asset.ID, time.Now())
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidDownloadToken, err)
+			return
+		}
+		// Người đã hủy đăng ký không dùng được link cũ
+		subscriber, err := h.newsletterRepo.GetSubscriberByID(subscriberID)
+		if err != nil || subscriber.Status != consts.SubscriberStatusConfirmed {
+			helpers.ErrorResponse(c, helpers.ErrInvalidDownloadToken, err)
+			return
+		}
+		download.SubscriberID = &subscriber.ID
+		download.Access = model.MediaAccessSubscriber
+	}
+
+	h.sendDownload(c, asset, download)
+}
+
+// RequestAttachmentLink gửi link tải tệp chỉ dành cho người đăng ký tới email đã xác nhận đăng ký bản tin.
+// Luôn trả về cùng một thông báo để không lộ email nào đã đăng ký
+func (h *MediaHandler) RequestAttachmentLink(c *gin.Context) {
+	mediaID, err := uuid.Parse(c.Param("media_id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	var input model.AttachmentLinkInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	article, err := h.articleRepo.GetBySlug(c.Param("slug"))
+	if err != nil || !article.IsPublished() {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+	attachment, ok := article.FindAttachment(mediaID)
+	if !ok {
+		helpers.ErrorResponse(c, helpers.ErrAttachmentNotFound, nil)
+		return
+	}
+	if !attachment.SubscribersOnly {
+		helpers.ErrorResponse(c, helpers.ErrInvalidAttachment, errors.New("tệp không giới hạn người tải, dùng trực tiếp download_url"))
+		return
+	}
+
+	const message = "Nếu email đã đăng ký nhận bản tin, link tải sẽ được gửi tới hộp thư của bạn"
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	subscriber, err := h.newsletterRepo.GetSubscriberByEmail(email)
+	if err != nil && err.Error() != "subscriber not found" {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if subscriber == nil || subscriber.Status != consts.SubscriberStatusConfirmed {
+		helpers.SuccessResponse(c, message, nil)
+		return
+	}
+
+	expiresAt := time.Now().Add(subscriberLinkTTL())
+	token := encodeDownloadToken(article.ID, mediaID, subscriber.ID, expiresAt)
+	title := attachment.Title
+	if title == "" {
+		title = attachment.FileName
+	}
+	newsletter.SendDownloadLink(*subscriber, title, newsletter.DownloadURL(article.AttachmentDownloadPath(mediaID), token), expiresAt)
+
+	helpers.SuccessResponse(c, message, nil)
+}
+
+// GetMediaDownloads lấy nhật ký tải của một file. Query: page, limit
+func (h *MediaHandler) GetMediaDownloads(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	downloads, total, err := h.mediaRepo.GetDownloads(asset.ID, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy nhật ký tải file thành công", map[string]interface{}{
+		"download_count": asset.DownloadCount,
+		"downloads":      downloads,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// UpdateMediaVisibility đổi phạm vi truy cập file: private chuyển file gốc vào private/ và xóa variant
+// (link công khai cũ không còn dùng được), public chuyển file về key cũ và tạo lại variant
+func (h *MediaHandler) UpdateMediaVisibility(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	var input model.MediaVisibilityInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+	if asset.Visibility == input.Visibility {
+		helpers.SuccessResponse(c, "Cập nhật phạm vi truy cập file thành công", toMediaResponse(asset))
+		return
+	}
+	if asset.Status == model.MediaStatusRejected {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaRejected, nil, toMediaResponse(asset))
+		return
+	}
+	// Không đổi chỗ file khi đang quét
+	if _, busy := mediaScanning.LoadOrStore(asset.Key, true); busy {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotClean, errors.New("file đang được kiểm tra"))
+		return
+	}
+	defer mediaScanning.Delete(asset.Key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaScanTimeout)
+	defer cancel()
+
+	from := asset.StorageKey()
+	asset.Visibility = input.Visibility
+	asset.PrivateKey = ""
+	if asset.IsPrivate() {
+		asset.PrivateKey = asset.NewPrivateKey()
+	}
+	if _, err := statObject(stagingKey(asset.Key)); err == nil {
+		// File còn trong khu chờ: chỉ đổi đích, file được chuyển tới đó khi kiểm tra xong
+		if err := h.mediaRepo.Save(asset); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrMediaSaveFailed, err)
+			return
+		}
+		helpers.SuccessResponse(c, "Cập nhật phạm vi truy cập file thành công", toMediaResponse(asset))
+		return
+	}
+	if err := moveObject(ctx, from, asset.StorageKey(), ""); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaVisibilityFailed, err)
+		return
+	}
+	if asset.IsPrivate() {
+		clearMediaVariants(asset)
+	} else if asset.Status == model.MediaStatusClean {
+		refreshMediaImage(asset)
+	}
+
+	if err := h.mediaRepo.Save(asset); err != nil {
+		// Trả file về chỗ cũ để khớp với dữ liệu đã lưu
+		if moveErr := moveObject(ctx, asset.StorageKey(), from, ""); moveErr != nil {
+			logrus.Error("Failed to restore media after visibility change: ", moveErr)
+		}
+		helpers.ErrorResponse(c, helpers.ErrMediaSaveFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật phạm vi truy cập file thành công", toMediaResponse(asset))
+}
@@ -0,0 +1,233 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"backend/internal/storage"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errMediaGCRunning - Đang có một lần dọn file khác chạy
+var errMediaGCRunning = errors.New("đang dọn file không dùng")
+
+var mediaGCMu sync.Mutex
+
+// Số ngày mặc định một file không được tham chiếu phải chờ trước khi bị dọn
+const defaultMediaGCMinAgeDays = 30
+
+// MediaGCObject - File trên storage đủ điều kiện dọn
+type MediaGCObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// MediaGCReport - Kết quả một lần dọn file (dry-run chỉ liệt kê, không xóa)
+type MediaGCReport struct {
+	DryRun     bool            `json:"dry_run"`
+	MinAgeDays int             `json:"min_age_days"`
+	Indexed    int             `json:"indexed"`    // Số tham chiếu sau khi dựng lại chỉ mục
+	Scanned    int             `json:"scanned"`    // Số object trên storage
+	Unmanaged  int             `json:"unmanaged"`  // Key không theo định dạng upload, không đụng tới
+	Referenced int             `json:"referenced"` // Đang được nội dung tham chiếu
+	InLibrary  int             `json:"in_library"` // Đã xác nhận trong thư viện media, chỉ xóa thủ công
+	TooRecent  int             `json:"too_recent"` // Chưa đủ tuổi, có thể đang upload dở
+	Candidates []MediaGCObject `json:"candidates"`
+	Deleted    int             `json:"deleted"`
+	FreedBytes int64           `json:"freed_bytes"`
+	Failed     []string        `json:"failed"`
+	// Sổ upload: số lần upload chưa xác nhận được cập nhật kích thước thật / được trả lại quota vì không có file
+	UploadsSynced   int       `json:"uploads_synced"`
+	UploadsReleased int       `json:"uploads_released"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+}
+
+// mediaGCMinAgeDays đọc MEDIA_GC_MIN_AGE_DAYS (mặc định 30 ngày)
+func mediaGCMinAgeDays() int {
+	days, err := strconv.Atoi(os.Getenv("MEDIA_GC_MIN_AGE_DAYS"))
+	if err != nil || days < 1 {
+		return defaultMediaGCMinAgeDays
+	}
+	return days
+}
+
+// runMediaGC dựng lại chỉ mục tham chiếu rồi dọn các file upload không ai dùng và cũ hơn minAgeDays.
+// Chỉ xét key đúng định dạng do /upload/s3 tạo; file đã xác nhận trong thư viện media được giữ lại
+func runMediaGC(dryRun bool, minAgeDays int) (MediaGCReport, error) {
+	if !mediaGCMu.TryLock() {
+		return MediaGCReport{}, errMediaGCRunning
+	}
+	defer mediaGCMu.Unlock()
+
+	report := MediaGCReport{
+		DryRun:     dryRun,
+		MinAgeDays: minAgeDays,
+		Candidates: []MediaGCObject{},
+		Failed:     []string{},
+		StartedAt:  time.Now(),
+	}
+
+	// Dựng lại chỉ mục trước để không xóa nhầm file được gắn qua đường ngoài repo
+	usageRepo := repo.NewMediaUsageRepo()
+	indexed, err := usageRepo.Rebuild()
+	if err != nil {
+		return report, err
+	}
+	report.Indexed = indexed
+
+	referenced, err := usageRepo.GetReferencedKeys()
+	if err != nil {
+		return report, err
+	}
+	mediaRepo := repo.NewMediaRepo()
+	library, err := mediaRepo.GetLibraryKeys()
+	if err != nil {
+		return report, err
+	}
+
+	// Lần upload chưa xác nhận mà URL upload đã hết hạn: có file thì tính theo kích thước thật, không có thì trả lại quota
+	uploadRepo := repo.NewUploadRepo()
+	pending, err := uploadRepo.GetPendingKeys(report.StartedAt.Add(-uploadURLExpiry))
+	if err != nil {
+		return report, err
+	}
+	uploadedSizes := map[string]int64{}
+
+	store, err := storage.Get()
+	if err != nil {
+		return report, err
+	}
+
+	ctx := context.Background()
+	cutoff := report.StartedAt.AddDate(0, 0, -minAgeDays)
+	err = store.List(ctx, func(object storage.ObjectInfo) error {
+		report.Scanned++
+		// File chưa kiểm tra nằm trong khu chờ, sổ upload ghi theo key chính thức
+		uploadKey := strings.TrimPrefix(object.Key, storage.PendingPrefix)
+		if _, ok := pending[uploadKey]; ok {
+			uploadedSizes[uploadKey] = object.Size
+			delete(pending, uploadKey)
+		}
+
+		if !model.IsMediaKey(object.Key) {
+			report.Unmanaged++
+			return nil
+		}
+		if _, ok := referenced[object.Key]; ok {
+			report.Referenced++
+			return nil
+		}
+		if _, ok := library[object.Key]; ok {
+			report.InLibrary++
+			return nil
+		}
+		if object.LastModified.After(cutoff) {
+			report.TooRecent++
+			return nil
+		}
+		report.Candidates = append(report.Candidates, MediaGCObject{
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	if !dryRun {
+		for _, candidate := range report.Candidates {
+			if err := store.Remove(ctx, candidate.Key); err != nil {
+				report.Failed = append(report.Failed, candidate.Key)
+				continue
+			}
+			_ = mediaRepo.DeleteByKey(candidate.Key)
+			_ = uploadRepo.DeleteByKey(candidate.Key)
+			delete(uploadedSizes, candidate.Key)
+			report.Deleted++
+			report.FreedBytes += candidate.Size
+		}
+		for key, size := range uploadedSizes {
+			if err := uploadRepo.MarkSize(key, size); err == nil {
+				report.UploadsSynced++
+			}
+		}
+		for key := range pending {
+			if err := uploadRepo.DeleteByKey(key); err == nil {
+				report.UploadsReleased++
+			}
+		}
+	} else {
+		report.UploadsSynced = len(uploadedSizes)
+		report.UploadsReleased = len(pending)
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+// RunMediaGC dọn file không dùng. Query: dry_run (mặc định true, chỉ báo cáo), min_age_days
+func (h *MediaHandler) RunMediaGC(c *gin.Context) {
+	dryRun := c.DefaultQuery("dry_run", "true") != "false"
+	minAgeDays := mediaGCMinAgeDays()
+	if v := c.Query("min_age_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			helpers.ErrorResponse(c, helpers.ErrInvalidMediaQuery, errors.New("min_age_days phải là số nguyên dương"))
+			return
+		}
+		minAgeDays = days
+	}
+
+	report, err := runMediaGC(dryRun, minAgeDays)
+	if err != nil {
+		if errors.Is(err, errMediaGCRunning) {
+			helpers.ErrorResponse(c, helpers.ErrMediaGCRunning, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrMediaGCFailed, err)
+		return
+	}
+
+	message := "Dọn file không dùng thành công"
+	if dryRun {
+		message = "Lấy báo cáo file không dùng thành công"
+	}
+	helpers.SuccessResponse(c, message, report)
+}
+
+// StartMediaGC dọn file không dùng mỗi MEDIA_GC_INTERVAL_HOURS giờ (mặc định 0 = tắt).
+// MEDIA_GC_DRY_RUN=true chỉ ghi log báo cáo, không xóa
+func StartMediaGC() {
+	hours, _ := strconv.Atoi(os.Getenv("MEDIA_GC_INTERVAL_HOURS"))
+	if hours <= 0 {
+		return
+	}
+	dryRun := os.Getenv("MEDIA_GC_DRY_RUN") == "true"
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(hours) * time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			report, err := runMediaGC(dryRun, mediaGCMinAgeDays())
+			if err != nil {
+				log.Printf("⚠️  Warning: Media GC failed: %v", err)
+			} else if len(report.Candidates) > 0 {
+				log.Printf("🧹 Media GC: %d unreferenced, %d deleted (%d bytes), %d failed, dry_run=%t",
+					len(report.Candidates), report.Deleted, report.FreedBytes, len(report.Failed), dryRun)
+			}
+		}
+	}()
+}
@@ -0,0 +1,541 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"backend/internal/storage"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Các thư mục theo loại file (xem getFolder)
+var mediaFolders = []string{"images", "documents", "media", "other"}
+
+type MediaHandler struct {
+	mediaRepo      *repo.MediaRepo
+	usageRepo      *repo.MediaUsageRepo
+	uploadRepo     *repo.UploadRepo
+	articleRepo    *repo.ArticleRepo
+	newsletterRepo *repo.NewsletterRepo
+}
+
+func NewMediaHandler() *MediaHandler {
+	return &MediaHandler{
+		mediaRepo:      repo.NewMediaRepo(),
+		usageRepo:      repo.NewMediaUsageRepo(),
+		uploadRepo:     repo.NewUploadRepo(),
+		articleRepo:    repo.NewArticleRepo(),
+		newsletterRepo: repo.NewNewsletterRepo(),
+	}
+}
+
+// toMediaResponse thêm link truy cập file. File riêng tư không có link trực tiếp, chỉ tải qua download_url
+func toMediaResponse(asset *model.MediaAsset) model.MediaAssetResponse {
+	resp := asset.ToResponse()
+	if asset.IsPrivate() {
+		resp.Variants = []model.MediaVariantResponse{}
+		return resp
+	}
+	resp.URL = objectURL(asset.Key)
+	resp.Variants, resp.SrcSet = model.BuildMediaVariants(asset.GetVariants(), objectURL)
+	return resp
+}
+
+// normalizeMediaKey nhận key hoặc direct URL, trả về object key trên storage hiện tại
+func normalizeMediaKey(raw string) (string, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return "", err
+	}
+	return store.KeyFromURL(raw)
+}
+
+// ConfirmUpload ghi nhận file đã upload qua presigned URL: kiểm tra object trên storage (HEAD),
+// lưu kích thước, loại, kích thước ảnh, người upload và mô tả vào thư viện media.
+// File được kiểm tra loại thật và quét mã độc trước khi dùng (status pending → clean/rejected);
+// ảnh JPEG/PNG sạch được tạo các variant resize (xem MEDIA_IMAGE_VARIANTS).
+// visibility=private chuyển file gốc vào khu riêng tư ngay khi xác nhận
+func (h *MediaHandler) ConfirmUpload(c *gin.Context) {
+	var input model.MediaConfirmInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	key, err := normalizeMediaKey(input.Key)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaKey, err)
+		return
+	}
+	if storage.IsProtectedKey(key) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaKey, errors.New("không xác nhận được file trong khu riêng tư hoặc khu cách ly"))
+		return
+	}
+
+	// File mới nằm trong khu chờ cho tới khi được kiểm tra sạch
+	info, _, staged, err := locateUpload(key, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			helpers.ErrorResponse(c, helpers.ErrMediaObjectMissing, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrStorageError, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByKey(key)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	isNew := asset == nil
+	if isNew {
+		asset = &model.MediaAsset{Key: key, Visibility: model.MediaVisibilityPublic}
+		if userID, exists := c.Get("userID"); exists {
+			id := userID.(uuid.UUID)
+			asset.UploadedByID = &id
+		}
+	}
+
+	if isNew || asset.ETag != info.ETag || asset.ContentType != info.ContentType {
+		// File mới hoặc file gốc đã thay đổi: cách ly cho tới khi kiểm tra lại xong
+		asset.Status = model.MediaStatusPending
+		asset.DetectedType = ""
+		asset.ScanResult = ""
+		asset.ScanAttempts = 0
+		asset.ScannedAt = nil
+	}
+	asset.ContentType = info.ContentType
+	asset.Folder = getFolder(info.ContentType)
+	asset.Size = info.Size
+	asset.ETag = info.ETag
+	asset.DeletedAt = gorm.DeletedAt{}
+
+	// File trong khu chờ được chuyển thẳng tới chỗ chính thức khi quét xong; file đã ở key công khai
+	// mà chọn riêng tư thì được chuyển vào private/ trước khi lưu
+	if input.Visibility != "" {
+		asset.Visibility = input.Visibility
+	}
+	stalePrivateKey := ""
+	if !isNew {
+		stalePrivateKey = storage.PrivatePrefix + key
+		if asset.PrivateKey != "" {
+			stalePrivateKey = asset.PrivateKey
+		}
+	}
+	asset.PrivateKey = ""
+	if asset.IsPrivate() {
+		asset.PrivateKey = asset.NewPrivateKey()
+		if !staged {
+			if err := moveObject(c.Request.Context(), key, asset.StorageKey(), ""); err != nil {
+				helpers.ErrorResponse(c, helpers.ErrMediaVisibilityFailed, err)
+				return
+			}
+		}
+		clearMediaVariants(asset)
+	}
+	if stalePrivateKey != "" {
+		// Bản riêng tư cũ (nếu có) đã được thay bằng file vừa upload
+		if err := removeObject(stalePrivateKey); err != nil {
+			logrus.Warn("Failed to remove stale private media: ", err)
+		}
+	}
+
+	// Xác nhận lại cùng key chỉ ghi đè các mô tả được gửi lên
+	input.FileName = strings.TrimSpace(input.FileName)
+	input.Title = strings.TrimSpace(input.Title)
+	input.AltText = strings.TrimSpace(input.AltText)
+	input.Caption = strings.TrimSpace(input.Caption)
+	if isNew || input.FileName != "" {
+		asset.FileName = input.FileName
+	}
+	if isNew || input.Title != "" {
+		asset.Title = input.Title
+	}
+	if isNew || input.AltText != "" {
+		asset.AltText = input.AltText
+	}
+	if isNew || input.Caption != "" {
+		asset.Caption = input.Caption
+	}
+
+	if isNew {
+		err = h.mediaRepo.Create(asset)
+	} else {
+		err = h.mediaRepo.Save(asset)
+	}
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaSaveFailed, err)
+		return
+	}
+	// Quota tính theo kích thước thật thay cho dung lượng khai báo lúc xin URL
+	if err := h.uploadRepo.MarkSize(key, info.Size); err != nil {
+		logrus.Warn("Failed to update upload size: ", err)
+	}
+
+	// Kiểm tra loại file, quét mã độc và tạo variant: file nhỏ làm ngay, file lớn làm nền (trả về trạng thái pending)
+	if asset.Status == model.MediaStatusPending {
+		if info.Size <= envInt64("MEDIA_SCAN_SYNC_MAX_BYTES", defaultMediaScanSyncMaxBytes) {
+			if err := scanMediaAsset(h.mediaRepo, asset); err != nil {
+				logrus.Warn("Media scan failed, will retry in background: ", err)
+			}
+		} else {
+			pending := *asset
+			go func() {
+				if err := scanMediaAsset(h.mediaRepo, &pending); err != nil {
+					logrus.Warn("Media scan failed, will retry in background: ", err)
+				}
+			}()
+		}
+	}
+
+	saved, err := h.mediaRepo.GetByID(asset.ID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+	if saved.Status == model.MediaStatusRejected {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaRejected, nil, toMediaResponse(saved))
+		return
+	}
+
+	status := http.StatusOK
+	if isNew {
+		status = http.StatusCreated
+	}
+	c.JSON(status, helpers.Response{
+		Success: true,
+		Message: "Xác nhận upload thành công",
+		Data:    toMediaResponse(saved),
+	})
+}
+
+// GetMediaAssets lấy thư viện media (dùng cho hộp thoại chọn ảnh có sẵn)
+// Query: search, folder, content_type, uploaded_by, status (pending, clean, rejected), visibility (public, private), page, limit
+func (h *MediaHandler) GetMediaAssets(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "24"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 24
+	}
+
+	filter := repo.MediaFilter{
+		Keyword:     strings.TrimSpace(c.Query("search")),
+		Folder:      strings.TrimSpace(c.Query("folder")),
+		ContentType: strings.TrimSpace(c.Query("content_type")),
+		Status:      strings.TrimSpace(c.Query("status")),
+		Visibility:  strings.TrimSpace(c.Query("visibility")),
+	}
+	switch filter.Status {
+	case "", model.MediaStatusPending, model.MediaStatusClean, model.MediaStatusRejected:
+	default:
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaQuery, errors.New("status phải là pending, clean hoặc rejected"))
+		return
+	}
+	switch filter.Visibility {
+	case "", model.MediaVisibilityPublic, model.MediaVisibilityPrivate:
+	default:
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaQuery, errors.New("visibility phải là public hoặc private"))
+		return
+	}
+	if filter.Folder != "" && !isMediaFolder(filter.Folder) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaQuery, fmt.Errorf("folder phải là một trong: %s", strings.Join(mediaFolders, ", ")))
+		return
+	}
+	if v := strings.TrimSpace(c.Query("uploaded_by")); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidMediaQuery, err)
+			return
+		}
+		filter.UploadedByID = &id
+	}
+
+	assets, total, err := h.mediaRepo.Search(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaListFailed, err)
+		return
+	}
+
+	responses := make([]model.MediaAssetResponse, 0, len(assets))
+	for i := range assets {
+		responses = append(responses, toMediaResponse(&assets[i]))
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy thư viện media thành công", map[string]interface{}{
+		"assets": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetMediaAssetByID lấy thông tin một file
+func (h *MediaHandler) GetMediaAssetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin file thành công", toMediaResponse(asset))
+}
+
+// UpdateMediaAsset cập nhật tiêu đề, alt text, chú thích của file
+func (h *MediaHandler) UpdateMediaAsset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	var input model.MediaUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	if input.Title != nil {
+		asset.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.AltText != nil {
+		asset.AltText = strings.TrimSpace(*input.AltText)
+	}
+	if input.Caption != nil {
+		asset.Caption = strings.TrimSpace(*input.Caption)
+	}
+
+	if err := h.mediaRepo.Save(asset); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaSaveFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật thông tin file thành công", toMediaResponse(asset))
+}
+
+// GetMediaAssetUsages lấy các nội dung đang dùng file (bài viết, danh mục, tag, avatar...)
+func (h *MediaHandler) GetMediaAssetUsages(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	usages, err := h.usageRepo.GetByKeys(asset.ObjectKeys())
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaUsageFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin sử dụng file thành công", gin.H{
+		"key":    asset.Key,
+		"usages": usages,
+	})
+}
+
+// GetMediaUsagesByKey lấy các nội dung đang dùng file theo key hoặc direct URL (kể cả file chưa vào thư viện)
+// Query: key
+func (h *MediaHandler) GetMediaUsagesByKey(c *gin.Context) {
+	key, err := normalizeMediaKey(c.Query("key"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaKey, err)
+		return
+	}
+
+	usages, err := h.usageRepo.GetByKey(key)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaUsageFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin sử dụng file thành công", gin.H{
+		"key":    key,
+		"usages": usages,
+	})
+}
+
+// RebuildMediaUsages quét lại toàn bộ nội dung và dựng lại chỉ mục tham chiếu file
+func (h *MediaHandler) RebuildMediaUsages(c *gin.Context) {
+	indexed, err := h.usageRepo.Rebuild()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaUsageFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Dựng lại chỉ mục sử dụng file thành công", gin.H{
+		"indexed": indexed,
+	})
+}
+
+// DeleteMediaAsset xóa file (kèm các variant) khỏi thư viện và storage.
+// File đang được tham chiếu chỉ bị xóa khi có query force=true
+func (h *MediaHandler) DeleteMediaAsset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+
+	usages, err := h.usageRepo.GetByKeys(asset.ObjectKeys())
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaUsageFailed, err)
+		return
+	}
+	if len(usages) > 0 && c.Query("force") != "true" {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaInUse, nil, gin.H{"usages": usages})
+		return
+	}
+
+	for _, key := range mediaStorageKeys(asset) {
+		if err := removeObject(key); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrFileDeleteFailed, err)
+			return
+		}
+	}
+	if err := h.mediaRepo.Delete(asset.ID); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if err := h.uploadRepo.DeleteByKey(asset.Key); err != nil {
+		logrus.Warn("Failed to release upload quota: ", err)
+	}
+
+	helpers.SuccessResponse(c, "Xóa file thành công", nil)
+}
+
+// mediaStorageKeys - Mọi object của file trên storage: file gốc, variant và bản trong khu chờ, khu cách ly hoặc khu riêng tư
+func mediaStorageKeys(asset *model.MediaAsset) []string {
+	keys := asset.ObjectKeys()
+	switch asset.Status {
+	case model.MediaStatusRejected:
+		keys = append(keys, storage.QuarantinePrefix+asset.Key)
+	case model.MediaStatusPending:
+		keys = append(keys, stagingKey(asset.Key))
+	}
+	if asset.IsPrivate() {
+		keys = append(keys, asset.StorageKey())
+	}
+	return keys
+}
+
+// RegenerateMediaVariants tạo lại variant của ảnh (vd sau khi đổi cấu hình MEDIA_IMAGE_VARIANTS)
+func (h *MediaHandler) RegenerateMediaVariants(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+	if asset.Status != model.MediaStatusClean {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotClean, nil)
+		return
+	}
+	if asset.IsPrivate() {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotPublic, errors.New("file riêng tư không có ảnh thu nhỏ"))
+		return
+	}
+	if !canProcessImage(asset.ContentType) {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotProcessable, fmt.Errorf("không xử lý được file %s", asset.ContentType))
+		return
+	}
+
+	if err := processMediaImage(asset); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaProcessFailed, err)
+		return
+	}
+	if err := h.mediaRepo.Save(asset); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaSaveFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Tạo lại ảnh thu nhỏ thành công", toMediaResponse(asset))
+}
+
+// ScanMediaAsset kiểm tra lại file (vd sau khi cập nhật mẫu mã độc hoặc khi quét nền lỗi quá số lần thử).
+// File đã bị từ chối nằm trong khu cách ly, không quét lại được
+func (h *MediaHandler) ScanMediaAsset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMediaID, err)
+		return
+	}
+
+	asset, err := h.mediaRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaNotFound, err)
+		return
+	}
+	if asset.Status == model.MediaStatusRejected {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaRejected, nil, toMediaResponse(asset))
+		return
+	}
+
+	asset.Status = model.MediaStatusPending
+	asset.ScanAttempts = 0
+	if err := scanMediaAsset(h.mediaRepo, asset); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaScanFailed, err)
+		return
+	}
+	if asset.Status == model.MediaStatusRejected {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaRejected, nil, toMediaResponse(asset))
+		return
+	}
+
+	helpers.SuccessResponse(c, "Kiểm tra file thành công", toMediaResponse(asset))
+}
+
+func isMediaFolder(folder string) bool {
+	for _, f := range mediaFolders {
+		if f == folder {
+			return true
+		}
+	}
+	return false
+}
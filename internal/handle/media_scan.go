@@ -0,0 +1,361 @@
+package handle
+
+import (
+	"backend/internal/model"
+	"backend/internal/repo"
+	"backend/internal/scanner"
+	"backend/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMediaSVGMaxBytes      = 2 << 20  // SVG được phân tích toàn bộ nên giới hạn nhỏ (MEDIA_SVG_MAX_BYTES)
+	defaultMediaScanSyncMaxBytes = 25 << 20 // File nhỏ hơn được quét ngay khi xác nhận, lớn hơn quét nền (MEDIA_SCAN_SYNC_MAX_BYTES)
+	defaultMediaScanMaxAttempts  = 5        // Số lần quét lại khi scanner/storage lỗi (MEDIA_SCAN_MAX_ATTEMPTS)
+	defaultMediaScanInterval     = 5        // Phút giữa các lần quét lại file đang chờ (MEDIA_SCAN_INTERVAL_MINUTES, 0 = tắt)
+	mediaScanTimeout             = 10 * time.Minute
+	mediaScanBatchSize           = 50
+)
+
+// Object key các file đang được quét hoặc chuyển chỗ, tránh quét trùng giữa request xác nhận và job nền
+var mediaScanning sync.Map
+
+// scanMediaObject đối chiếu nội dung thật với content type khai báo, kiểm tra SVG và quét mã độc.
+// Lỗi bọc scanner.ErrRejected là file phải cách ly; lỗi khác (storage, clamd) có thể quét lại sau
+func scanMediaObject(ctx context.Context, key, contentType string, size int64) (string, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return "", err
+	}
+
+	head, err := readObjectHead(ctx, store, key, scanner.SniffBytes)
+	if err != nil {
+		return "", err
+	}
+	detected, err := scanner.CheckContent(contentType, head)
+	if err != nil {
+		return detected, err
+	}
+
+	if detected == "image/svg+xml" {
+		maxBytes := envInt64("MEDIA_SVG_MAX_BYTES", defaultMediaSVGMaxBytes)
+		if size > maxBytes {
+			return detected, fmt.Errorf("%w: SVG lớn hơn %d bytes", scanner.ErrRejected, maxBytes)
+		}
+		data, err := readObject(key, maxBytes)
+		if err != nil {
+			return detected, err
+		}
+		if err := scanner.CheckSVG(data); err != nil {
+			return detected, err
+		}
+	}
+
+	if scanner.NeedsFullPolyglotScan(detected) {
+		object, err := store.Open(ctx, key, 0, 0)
+		if err != nil {
+			return detected, err
+		}
+		err = scanner.CheckPolyglot(detected, object)
+		object.Close()
+		if err != nil {
+			return detected, err
+		}
+	}
+
+	object, err := store.Open(ctx, key, 0, 0)
+	if err != nil {
+		return detected, err
+	}
+	defer object.Close()
+
+	s := scanner.Get()
+	result, err := s.Scan(ctx, object)
+	if err != nil {
+		return detected, err
+	}
+	if !result.Clean {
+		return detected, fmt.Errorf("%w: %s phát hiện mã độc %s", scanner.ErrRejected, s.Name(), result.Signature)
+	}
+	return detected, nil
+}
+
+// readObjectHead đọc tối đa n byte đầu của object
+func readObjectHead(ctx context.Context, store storage.Storage, key string, n int64) ([]byte, error) {
+	object, err := store.Open(ctx, key, 0, n)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	return io.ReadAll(io.LimitReader(object, n))
+}
+
+// moveObject chuyển object sang key khác (ghi bản sao rồi xóa bản gốc); contentType rỗng thì giữ nguyên
+func moveObject(ctx context.Context, from, to, contentType string) error {
+	store, err := storage.Get()
+	if err != nil {
+		return err
+	}
+	info, err := store.Stat(ctx, from)
+	if err != nil {
+		return err
+	}
+	object, err := store.Open(ctx, from, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+	if _, err := store.Put(ctx, to, object, info.Size, storage.PutOptions{ContentType: contentType}); err != nil {
+		return err
+	}
+	return removeObject(from)
+}
+
+// quarantineObject chuyển file bị từ chối (đang ở from) sang quarantine/{key}: link công khai và link tải
+// không còn dùng được, quản trị vẫn có thể tải về kiểm tra
+func quarantineObject(ctx context.Context, from, key string) error {
+	return moveObject(ctx, from, storage.QuarantinePrefix+key, "application/octet-stream")
+}
+
+// locateUpload tìm file của key: còn trong khu chờ (staged = true) hoặc đã ở chỗ chính thức fallback
+// (file upload trước khi có khu chờ, hoặc đã được chuyển)
+func locateUpload(key, fallback string) (storage.ObjectInfo, string, bool, error) {
+	info, err := statObject(stagingKey(key))
+	if err == nil {
+		return info, stagingKey(key), true, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return info, "", false, err
+	}
+	info, err = statObject(fallback)
+	return info, fallback, false, err
+}
+
+// scanAndPlace quét file ở source: sạch thì chuyển tới target (nếu khác chỗ), bị từ chối thì chuyển vào khu cách ly.
+// Lỗi hạ tầng (kể cả lỗi chuyển file sạch) giữ file tại chỗ để quét lại
+func scanAndPlace(ctx context.Context, key, source, target, contentType string, size int64) (string, error) {
+	detected, err := scanMediaObject(ctx, source, contentType, size)
+	switch {
+	case err == nil:
+		if source != target {
+			if err := moveObject(ctx, source, target, ""); err != nil {
+				return detected, fmt.Errorf("chuyển file đã kiểm tra: %w", err)
+			}
+		}
+	case errors.Is(err, scanner.ErrRejected):
+		logrus.Warnf("Upload %s rejected: %v", key, err)
+		if qErr := quarantineObject(ctx, source, key); qErr != nil {
+			logrus.Error("Failed to quarantine rejected upload: ", qErr)
+		}
+	}
+	return detected, err
+}
+
+// markUploadScanned ghi kết quả kiểm tra vào sổ upload
+func markUploadScanned(uploadRepo *repo.UploadRepo, key string, scanErr error, size int64, now time.Time) {
+	var err error
+	switch {
+	case scanErr == nil:
+		err = uploadRepo.MarkScanned(key, model.MediaStatusClean, "", size, now)
+	case errors.Is(scanErr, scanner.ErrRejected):
+		err = uploadRepo.MarkScanned(key, model.MediaStatusRejected, truncateScanResult(scanErr.Error()), size, now)
+	default:
+		err = uploadRepo.MarkScanFailed(key, truncateScanResult(scanErr.Error()))
+	}
+	if err != nil {
+		logrus.Warn("Failed to update upload scan result: ", err)
+	}
+}
+
+// refreshMediaImage tạo variant và lấy kích thước ảnh sau khi file được xác nhận sạch.
+// File riêng tư không có variant (variant là link công khai). Lỗi xử lý ảnh chỉ ghi log, không ảnh hưởng trạng thái file
+func refreshMediaImage(asset *model.MediaAsset) {
+	if asset.IsPrivate() {
+		clearMediaVariants(asset)
+		return
+	}
+	if canProcessImage(asset.ContentType) && asset.VariantsETag == asset.ETag && len(asset.GetVariants()) > 0 {
+		return
+	}
+	asset.Width, asset.Height = nil, nil
+	if canProcessImage(asset.ContentType) {
+		if err := processMediaImage(asset); err != nil {
+			logrus.Warn("Failed to generate image variants: ", err)
+		}
+	} else {
+		clearMediaVariants(asset)
+	}
+	if asset.Width == nil && asset.Folder == "images" && asset.ContentType != "image/svg+xml" {
+		if width, height, err := readImageSize(asset.Key); err == nil {
+			asset.Width, asset.Height = &width, &height
+		}
+	}
+}
+
+// scanMediaAsset quét file đang chờ và lưu kết quả: sạch thì chuyển file từ khu chờ tới chỗ chính thức (key công khai
+// hoặc key riêng tư) rồi tạo variant, bị từ chối thì chuyển file gốc vào khu cách ly và xóa variant,
+// lỗi hạ tầng thì giữ trạng thái chờ để job nền quét lại. Kết quả được ghi cả vào sổ upload
+func scanMediaAsset(mediaRepo *repo.MediaRepo, asset *model.MediaAsset) error {
+	if _, busy := mediaScanning.LoadOrStore(asset.Key, true); busy {
+		return nil
+	}
+	defer mediaScanning.Delete(asset.Key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaScanTimeout)
+	defer cancel()
+
+	var detected string
+	info, source, staged, scanErr := locateUpload(asset.Key, asset.StorageKey())
+	if scanErr == nil {
+		detected, scanErr = scanAndPlace(ctx, asset.Key, source, asset.StorageKey(), asset.ContentType, info.Size)
+	}
+	now := time.Now()
+	switch {
+	case scanErr == nil:
+		if staged {
+			// Bản chuyển tới chỗ chính thức là object mới (ETag có thể khác bản trong khu chờ)
+			if moved, err := statObject(asset.StorageKey()); err == nil {
+				asset.ETag = moved.ETag
+			}
+		}
+		asset.Status = model.MediaStatusClean
+		asset.DetectedType = detected
+		asset.ScanResult = ""
+		asset.ScannedAt = &now
+		refreshMediaImage(asset)
+	case errors.Is(scanErr, scanner.ErrRejected):
+		asset.Status = model.MediaStatusRejected
+		asset.DetectedType = detected
+		asset.ScanResult = truncateScanResult(scanErr.Error())
+		asset.ScannedAt = &now
+		asset.Width, asset.Height = nil, nil
+		clearMediaVariants(asset)
+	default:
+		asset.ScanAttempts++
+		asset.ScanResult = truncateScanResult(scanErr.Error())
+	}
+	markUploadScanned(repo.NewUploadRepo(), asset.Key, scanErr, info.Size, now)
+	if errors.Is(scanErr, scanner.ErrRejected) {
+		scanErr = nil
+	}
+
+	if err := mediaRepo.Save(asset); err != nil {
+		return err
+	}
+	return scanErr
+}
+
+// scanUpload kiểm tra một lần upload trong sổ (kể cả file khách đính kèm, chưa vào thư viện media).
+// File đã xác nhận vào thư viện được quét qua scanMediaAsset; file chưa từng được upload mà URL đã hết hạn
+// thì trả lại quota
+func scanUpload(mediaRepo *repo.MediaRepo, uploadRepo *repo.UploadRepo, upload *model.Upload) error {
+	asset, err := mediaRepo.GetByKey(upload.ObjectKey)
+	if err != nil {
+		return err
+	}
+	if asset != nil {
+		if asset.Status != model.MediaStatusPending {
+			// Thư viện đã có kết quả (vd file có từ trước khi có khu chờ)
+			return uploadRepo.MarkScanned(upload.ObjectKey, asset.Status, asset.ScanResult, asset.Size, time.Now())
+		}
+		return scanMediaAsset(mediaRepo, asset)
+	}
+
+	if _, busy := mediaScanning.LoadOrStore(upload.ObjectKey, true); busy {
+		return nil
+	}
+	defer mediaScanning.Delete(upload.ObjectKey)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mediaScanTimeout)
+	defer cancel()
+
+	info, source, _, err := locateUpload(upload.ObjectKey, upload.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		if time.Since(upload.CreatedAt) > uploadURLExpiry {
+			return uploadRepo.DeleteByKey(upload.ObjectKey)
+		}
+		return nil
+	}
+	if err == nil {
+		_, err = scanAndPlace(ctx, upload.ObjectKey, source, upload.ObjectKey, upload.ContentType, info.Size)
+	}
+	markUploadScanned(uploadRepo, upload.ObjectKey, err, info.Size, time.Now())
+	if errors.Is(err, scanner.ErrRejected) {
+		return nil
+	}
+	return err
+}
+
+func truncateScanResult(s string) string {
+	runes := []rune(s)
+	if len(runes) > 500 {
+		return string(runes[:500])
+	}
+	return s
+}
+
+func mediaScanMaxAttempts() int {
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_SCAN_MAX_ATTEMPTS")); err == nil && v > 0 {
+		return v
+	}
+	return defaultMediaScanMaxAttempts
+}
+
+// StartMediaScan quét các file đang chờ mỗi MEDIA_SCAN_INTERVAL_MINUTES phút (mặc định 5, 0 = tắt):
+// mọi lần upload chưa kiểm tra trong sổ (kể cả của khách), file lớn được quét nền, file quét lỗi do clamd/storage,
+// file có từ trước khi bật kiểm tra
+func StartMediaScan() {
+	minutes := defaultMediaScanInterval
+	if v, err := strconv.Atoi(os.Getenv("MEDIA_SCAN_INTERVAL_MINUTES")); err == nil && v >= 0 {
+		minutes = v
+	}
+	if minutes == 0 {
+		return
+	}
+
+	go func() {
+		interval := time.Duration(minutes) * time.Minute
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			mediaRepo := repo.NewMediaRepo()
+			// Bỏ qua file vừa xác nhận, đang được request quét
+			assets, err := mediaRepo.GetPendingScan(mediaScanMaxAttempts(), time.Now().Add(-time.Minute), mediaScanBatchSize)
+			if err != nil {
+				log.Printf("⚠️  Warning: Failed to load pending media: %v", err)
+				continue
+			}
+			for i := range assets {
+				if err := scanMediaAsset(mediaRepo, &assets[i]); err != nil {
+					log.Printf("⚠️  Warning: Media scan failed for %s: %v", assets[i].Key, err)
+				}
+			}
+
+			uploadRepo := repo.NewUploadRepo()
+			uploads, err := uploadRepo.GetPendingScan(mediaScanMaxAttempts(), time.Now().Add(-time.Minute), mediaScanBatchSize)
+			if err != nil {
+				log.Printf("⚠️  Warning: Failed to load pending uploads: %v", err)
+				continue
+			}
+			for i := range uploads {
+				if err := scanUpload(mediaRepo, uploadRepo, &uploads[i]); err != nil {
+					log.Printf("⚠️  Warning: Upload scan failed for %s: %v", uploads[i].ObjectKey, err)
+				}
+			}
+		}
+	}()
+}
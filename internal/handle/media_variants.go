@@ -0,0 +1,285 @@
+package handle
+
+import (
+	"backend/internal/imaging"
+	"backend/internal/model"
+	"bytes"
+	"fmt"
+	"image"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Variant mặc định: name:WIDTHxHEIGHT:mode (0 = không giới hạn chiều đó).
+// Ghi đè bằng MEDIA_IMAGE_VARIANTS cùng định dạng, phân tách bằng dấu phẩy
+const defaultMediaImageVariants = "thumbnail:320x320:fit,card:768x0:fit,og:1200x630:fill,full:1920x0:fit"
+
+const (
+	defaultMediaImageMaxBytes  = 25 << 20   // Không xử lý file gốc lớn hơn 25MB (MEDIA_IMAGE_MAX_BYTES)
+	defaultMediaImageMaxPixels = 40_000_000 // Chặn ảnh "bom" giải nén (MEDIA_IMAGE_MAX_PIXELS)
+	defaultMediaImageQuality   = 82         // Chất lượng JPEG (MEDIA_IMAGE_QUALITY)
+	mediaOriginalQuality       = 92         // Chất lượng khi phải mã hóa lại file gốc JPEG (xoay theo EXIF)
+)
+
+var mediaVariantNamePattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// mediaVariantPreset - Cấu hình một variant
+type mediaVariantPreset struct {
+	Name   string
+	Width  int
+	Height int
+	Mode   string
+}
+
+// parseMediaVariantPresets đọc cấu hình variant dạng "thumbnail:320x320:fit,og:1200x630:fill"
+func parseMediaVariantPresets(spec string) ([]mediaVariantPreset, error) {
+	var presets []mediaVariantPreset
+	seen := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("variant %q phải có dạng name:WIDTHxHEIGHT:mode", item)
+		}
+		preset := mediaVariantPreset{Name: parts[0], Mode: parts[2]}
+		if !mediaVariantNamePattern.MatchString(preset.Name) || seen[preset.Name] {
+			return nil, fmt.Errorf("tên variant %q không hợp lệ hoặc bị trùng", preset.Name)
+		}
+		seen[preset.Name] = true
+
+		size := strings.SplitN(parts[1], "x", 2)
+		if len(size) != 2 {
+			return nil, fmt.Errorf("kích thước variant %q không hợp lệ", parts[1])
+		}
+		var err error
+		if preset.Width, err = strconv.Atoi(size[0]); err != nil || preset.Width < 0 {
+			return nil, fmt.Errorf("chiều rộng variant %q không hợp lệ", parts[1])
+		}
+		if preset.Height, err = strconv.Atoi(size[1]); err != nil || preset.Height < 0 {
+			return nil, fmt.Errorf("chiều cao variant %q không hợp lệ", parts[1])
+		}
+
+		switch preset.Mode {
+		case model.MediaVariantFit:
+			if preset.Width == 0 && preset.Height == 0 {
+				return nil, fmt.Errorf("variant %q cần ít nhất một chiều", preset.Name)
+			}
+		case model.MediaVariantFill:
+			if preset.Width == 0 || preset.Height == 0 {
+				return nil, fmt.Errorf("variant %q kiểu fill cần đủ hai chiều", preset.Name)
+			}
+		default:
+			return nil, fmt.Errorf("kiểu variant %q phải là fit hoặc fill", preset.Mode)
+		}
+		presets = append(presets, preset)
+	}
+	return presets, nil
+}
+
+var (
+	mediaVariantPresetsOnce sync.Once
+	mediaVariantPresetList  []mediaVariantPreset
+)
+
+// mediaVariantPresets lấy cấu hình variant, cấu hình sai thì ghi log và dùng mặc định
+func mediaVariantPresets() []mediaVariantPreset {
+	mediaVariantPresetsOnce.Do(func() {
+		spec := os.Getenv("MEDIA_IMAGE_VARIANTS")
+		if spec != "" {
+			presets, err := parseMediaVariantPresets(spec)
+			if err == nil {
+				mediaVariantPresetList = presets
+				return
+			}
+			logrus.Warn("Invalid MEDIA_IMAGE_VARIANTS, using defaults: ", err)
+		}
+		mediaVariantPresetList, _ = parseMediaVariantPresets(defaultMediaImageVariants)
+	})
+	return mediaVariantPresetList
+}
+
+func envInt64(name string, fallback int64) int64 {
+	v, err := strconv.ParseInt(os.Getenv(name), 10, 64)
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
+
+// mediaImageFormat đọc MEDIA_IMAGE_FORMAT (jpeg mặc định)
+func mediaImageFormat() string {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv("MEDIA_IMAGE_FORMAT")))
+	if format == "" {
+		return imaging.FormatJPEG
+	}
+	return format
+}
+
+// ValidateMediaImageFormat kiểm tra MEDIA_IMAGE_FORMAT có encoder đã đăng ký. Gọi khi khởi động để cấu hình sai
+// (vd webp trong bản build không có encoder WebP) dừng server thay vì lặng lẽ tạo variant JPEG
+func ValidateMediaImageFormat() error {
+	format := mediaImageFormat()
+	if _, ok := imaging.EncoderFor(format); !ok {
+		return fmt.Errorf("chưa đăng ký encoder cho MEDIA_IMAGE_FORMAT=%s", format)
+	}
+	return nil
+}
+
+// mediaVariantEncoder chọn encoder theo MEDIA_IMAGE_FORMAT (đã kiểm tra khi khởi động bằng ValidateMediaImageFormat).
+// Ảnh trong suốt dùng PNG khi encoder được chọn không hỗ trợ kênh alpha
+func mediaVariantEncoder(opaque bool) (imaging.Encoder, error) {
+	encoder, ok := imaging.EncoderFor(mediaImageFormat())
+	if !ok {
+		return nil, ValidateMediaImageFormat()
+	}
+	if encoder.Format() == imaging.FormatJPEG {
+		encoder = imaging.JPEGEncoder{Quality: int(envInt64("MEDIA_IMAGE_QUALITY", defaultMediaImageQuality))}
+	}
+	if !opaque && !encoder.SupportsAlpha() {
+		encoder, _ = imaging.EncoderFor(imaging.FormatPNG)
+	}
+	return encoder, nil
+}
+
+// canProcessImage - Chỉ xử lý ảnh tĩnh decode được bằng thư viện chuẩn (GIF động và SVG giữ nguyên)
+func canProcessImage(contentType string) bool {
+	return contentType == "image/jpeg" || contentType == "image/png"
+}
+
+// mediaVariantKey đặt variant cạnh file gốc: {uuid}/images/{yyyymm}/{uuid}_{name}{ext}
+func mediaVariantKey(key, name, ext string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + name + ext
+}
+
+// sanitizeMediaOriginal bỏ metadata (EXIF có thể chứa tọa độ GPS, thông tin thiết bị) khỏi file gốc và ghi đè object,
+// cập nhật ETag và dung lượng của asset. JPEG có Orientation khác 1 được mã hóa lại theo hướng đã xoay
+// vì bỏ EXIF mà giữ nguyên điểm ảnh sẽ làm ảnh hiển thị sai hướng
+func sanitizeMediaOriginal(asset *model.MediaAsset, data []byte, oriented *image.RGBA, orientation int) error {
+	var clean []byte
+	changed := false
+	switch {
+	case asset.ContentType == "image/jpeg" && orientation > 1:
+		var buf bytes.Buffer
+		if err := (imaging.JPEGEncoder{Quality: mediaOriginalQuality}).Encode(&buf, oriented); err != nil {
+			return err
+		}
+		clean, changed = buf.Bytes(), true
+	case asset.ContentType == "image/jpeg":
+		clean, changed = imaging.StripJPEGMetadata(data)
+	case asset.ContentType == "image/png":
+		clean, changed = imaging.StripPNGMetadata(data)
+	}
+	if !changed {
+		return nil
+	}
+
+	info, err := replaceObject(asset.Key, asset.ContentType, clean)
+	if err != nil {
+		return err
+	}
+	asset.ETag = info.ETag
+	asset.Size = int64(len(clean))
+	return nil
+}
+
+// processMediaImage bỏ metadata của file gốc rồi tạo các variant của ảnh (xoay theo EXIF, resize, mã hóa lại nên
+// không còn EXIF), cập nhật kích thước hiển thị và danh sách variant của asset, xóa variant cũ không còn dùng
+func processMediaImage(asset *model.MediaAsset) error {
+	data, err := readObject(asset.Key, envInt64("MEDIA_IMAGE_MAX_BYTES", defaultMediaImageMaxBytes))
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > envInt64("MEDIA_IMAGE_MAX_PIXELS", defaultMediaImageMaxPixels) {
+		return fmt.Errorf("ảnh %dx%d vượt quá số điểm ảnh cho phép", cfg.Width, cfg.Height)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	src := imaging.ToRGBA(decoded)
+	orientation := 1
+	if asset.ContentType == "image/jpeg" {
+		orientation = imaging.JPEGOrientation(data)
+		src = imaging.Orient(src, orientation)
+	}
+	encoder, err := mediaVariantEncoder(src.Opaque())
+	if err != nil {
+		return err
+	}
+	if err := sanitizeMediaOriginal(asset, data, src, orientation); err != nil {
+		return fmt.Errorf("bỏ metadata file gốc: %w", err)
+	}
+
+	variants := []model.MediaVariant{}
+	for _, preset := range mediaVariantPresets() {
+		var out *image.RGBA
+		if preset.Mode == model.MediaVariantFill {
+			out = imaging.Fill(src, preset.Width, preset.Height)
+		} else {
+			out = imaging.Fit(src, preset.Width, preset.Height)
+		}
+
+		var buf bytes.Buffer
+		if err := encoder.Encode(&buf, out); err != nil {
+			return fmt.Errorf("mã hóa variant %s: %w", preset.Name, err)
+		}
+		key := mediaVariantKey(asset.Key, preset.Name, encoder.Extension())
+		if _, err := putObject(key, encoder.ContentType(), buf.Bytes()); err != nil {
+			return fmt.Errorf("lưu variant %s: %w", preset.Name, err)
+		}
+		variants = append(variants, model.MediaVariant{
+			Name:        preset.Name,
+			Key:         key,
+			Mode:        preset.Mode,
+			Width:       out.Rect.Dx(),
+			Height:      out.Rect.Dy(),
+			ContentType: encoder.ContentType(),
+			Size:        int64(buf.Len()),
+		})
+	}
+
+	removeStaleMediaVariants(asset.GetVariants(), variants)
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	asset.Width, asset.Height = &width, &height
+	asset.SetVariants(variants)
+	asset.VariantsETag = asset.ETag
+	return nil
+}
+
+// clearMediaVariants xóa toàn bộ variant (file gốc đổi sang loại không xử lý được)
+func clearMediaVariants(asset *model.MediaAsset) {
+	removeStaleMediaVariants(asset.GetVariants(), nil)
+	asset.SetVariants(nil)
+	asset.VariantsETag = ""
+}
+
+// removeStaleMediaVariants xóa object của các variant cũ không còn trong danh sách mới
+func removeStaleMediaVariants(old, current []model.MediaVariant) {
+	keep := map[string]bool{}
+	for _, variant := range current {
+		keep[variant.Key] = true
+	}
+	for _, variant := range old {
+		if keep[variant.Key] {
+			continue
+		}
+		if err := removeObject(variant.Key); err != nil {
+			logrus.Warn("Failed to remove stale image variant: ", err)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"backend/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Thư mục được upload nhiều phần (video, âm thanh - các loại "media" trong getFolder)
+var multipartUploadFolders = map[string]bool{"media": true}
+
+const (
+	defaultMultipartPartSize        = 16 << 20 // MULTIPART_PART_SIZE_BYTES
+	minMultipartPartSize            = 5 << 20  // S3 yêu cầu mọi phần trừ phần cuối tối thiểu 5MB
+	maxMultipartParts               = 10000    // Giới hạn số phần của S3
+	defaultMultipartTTL             = 24       // Giờ phiên upload còn mở (MULTIPART_UPLOAD_TTL_HOURS)
+	defaultMultipartCleanupInterval = 60       // Phút giữa các lần dọn phiên hết hạn (MULTIPART_CLEANUP_INTERVAL_MINUTES, 0 = tắt)
+	multipartPartURLExpiry          = time.Hour
+	multipartCleanupBatchSize       = 50
+)
+
+// multipartLayout chia file size bytes thành các phần bằng nhau (trừ phần cuối), không vượt quá 10000 phần
+func multipartLayout(size int64) (int64, int) {
+	partSize := envInt64("MULTIPART_PART_SIZE_BYTES", defaultMultipartPartSize)
+	if partSize < minMultipartPartSize {
+		partSize = minMultipartPartSize
+	}
+	if minSize := (size + maxMultipartParts - 1) / maxMultipartParts; partSize < minSize {
+		partSize = minSize
+	}
+	return partSize, int((size + partSize - 1) / partSize)
+}
+
+func multipartTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("MULTIPART_UPLOAD_TTL_HOURS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return defaultMultipartTTL * time.Hour
+}
+
+// multipartBackend - Storage hỗ trợ upload nhiều phần
+type multipartBackend interface {
+	storage.Storage
+	storage.MultipartStorage
+}
+
+// multipartStore lấy storage hỗ trợ upload nhiều phần. Đã trả lỗi về client khi trả false
+func multipartStore(c *gin.Context) (multipartBackend, bool) {
+	store, err := storage.Get()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
+		return nil, false
+	}
+	backend, ok := store.(multipartBackend)
+	if !ok {
+		helpers.ErrorResponse(c, helpers.ErrMultipartUnsupported, nil)
+		return nil, false
+	}
+	return backend, true
+}
+
+// getOwnedMultipart lấy phiên upload theo :id; phiên của người khác coi như không tồn tại (trừ super admin).
+// Đã trả lỗi về client khi trả nil
+func (h *S3Handler) getOwnedMultipart(c *gin.Context) *model.MultipartUpload {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidMultipartID, err)
+		return nil
+	}
+	multipart, err := h.uploadRepo.GetMultipart(id)
+	if err != nil {
+		if err.Error() == "multipart upload not found" {
+			helpers.ErrorResponse(c, helpers.ErrMultipartNotFound, nil)
+			return nil
+		}
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return nil
+	}
+	userID, _ := c.Get("userID")
+	if multipart.UploadedByID != userID.(uuid.UUID) && c.GetString("user_role") != "super_admin" {
+		helpers.ErrorResponse(c, helpers.ErrMultipartNotFound, nil)
+		return nil
+	}
+	return multipart
+}
+
+// InitiateMultipartUpload mở phiên upload nhiều phần cho video/âm thanh lớn: client upload từng phần qua URL ký,
+// phần lỗi chỉ cần upload lại phần đó. Áp dụng cùng giới hạn dung lượng và quota như /upload/s3
+func (h *S3Handler) InitiateMultipartUpload(c *gin.Context) {
+	var input model.MultipartInitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	folder := getFolder(input.ContentType)
+	if !multipartUploadFolders[folder] {
+		helpers.ErrorResponse(c, helpers.ErrMultipartTypeNotAllowed, nil)
+		return
+	}
+	if maxSize := uploadMaxSize(folder); input.Size > maxSize {
+		helpers.ErrorResponseWithData(c, helpers.ErrUploadTooLarge, nil, gin.H{"max_size": maxSize})
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	uploaderID := userID.(uuid.UUID)
+
+	store, ok := multipartStore(c)
+	if !ok {
+		return
+	}
+
+	objectKey := newObjectKey(folder, input.ContentType)
+	uploadID, err := store.NewMultipartUpload(c.Request.Context(), stagingKey(objectKey), input.ContentType)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+		return
+	}
+
+	partSize, partCount := multipartLayout(input.Size)
+	multipart := &model.MultipartUpload{
+		UploadID:     uploadID,
+		ObjectKey:    objectKey,
+		Folder:       folder,
+		ContentType:  input.ContentType,
+		FileName:     input.FileName,
+		Size:         input.Size,
+		PartSize:     partSize,
+		PartCount:    partCount,
+		Status:       model.MultipartStatusUploading,
+		UploadedByID: uploaderID,
+		ExpiresAt:    time.Now().Add(multipartTTL()),
+	}
+	// Ghi sổ upload để tính quota (theo dung lượng khai báo cho tới khi file được xác nhận)
+	upload := &model.Upload{
+		ObjectKey:    objectKey,
+		Folder:       folder,
+		ContentType:  input.ContentType,
+		DeclaredSize: input.Size,
+		UploadedByID: &uploaderID,
+		IPAddress:    c.ClientIP(),
+	}
+	// Xét quota và ghi sổ cùng một transaction
+	quota := uploadQuotaFor(c)
+	used, reserved, err := h.uploadRepo.CreateMultipart(multipart, upload, quota)
+	if err != nil || !reserved {
+		if abortErr := store.AbortMultipartUpload(context.Background(), stagingKey(objectKey), uploadID); abortErr != nil {
+			logrus.Error("Failed to abort multipart upload: ", abortErr)
+		}
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+			return
+		}
+		respondQuotaExceeded(c, false, used, quota)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Mở phiên upload nhiều phần thành công",
+		Data: gin.H{
+			"id":         multipart.ID,
+			"key":        objectKey,
+			"part_size":  partSize,  // Mọi phần trừ phần cuối phải đúng kích thước này
+			"part_count": partCount, // Part number từ 1 tới part_count
+			"expires_at": multipart.ExpiresAt,
+		},
+	})
+}
+
+// PresignMultipartParts tạo URL PUT cho các phần (tối đa 100 phần mỗi lần). Client lưu ETag trả về
+// không bắt buộc: server lấy danh sách phần từ storage khi hoàn tất
+func (h *S3Handler) PresignMultipartParts(c *gin.Context) {
+	multipart := h.getOwnedMultipart(c)
+	if multipart == nil {
+		return
+	}
+	var input model.MultipartPartsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	now := time.Now()
+	if !multipart.IsActive(now) {
+		helpers.ErrorResponse(c, helpers.ErrMultipartNotActive, nil)
+		return
+	}
+	for _, number := range input.PartNumbers {
+		if number > multipart.PartCount {
+			helpers.ErrorResponseWithData(c, helpers.ErrInvalidPartNumber, nil, gin.H{"part_count": multipart.PartCount})
+			return
+		}
+	}
+
+	store, ok := multipartStore(c)
+	if !ok {
+		return
+	}
+
+	// URL không sống lâu hơn phiên upload
+	expires := multipartPartURLExpiry
+	if left := multipart.ExpiresAt.Sub(now); left < expires {
+		expires = left
+	}
+	parts := make([]gin.H, 0, len(input.PartNumbers))
+	for _, number := range input.PartNumbers {
+		url, err := store.PresignPart(c.Request.Context(), stagingKey(multipart.ObjectKey), multipart.UploadID, number, expires)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+			return
+		}
+		parts = append(parts, gin.H{"part_number": number, "url": url})
+	}
+
+	helpers.SuccessResponse(c, "Tạo URL upload các phần thành công", gin.H{
+		"method":     http.MethodPut,
+		"parts":      parts,
+		"expires_at": now.Add(expires),
+	})
+}
+
+// GetMultipartUpload lấy trạng thái phiên và các phần đã upload, để client tiếp tục sau khi mất kết nối
+func (h *S3Handler) GetMultipartUpload(c *gin.Context) {
+	multipart := h.getOwnedMultipart(c)
+	if multipart == nil {
+		return
+	}
+
+	parts := []storage.UploadedPart{}
+	if multipart.IsActive(time.Now()) {
+		store, ok := multipartStore(c)
+		if !ok {
+			return
+		}
+		var err error
+		parts, err = store.ListParts(c.Request.Context(), stagingKey(multipart.ObjectKey), multipart.UploadID)
+		if err != nil && !errors.Is(err, storage.ErrMultipartNotFound) {
+			helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+			return
+		}
+	}
+
+	helpers.SuccessResponse(c, "Lấy phiên upload thành công", gin.H{
+		"upload":         multipart,
+		"uploaded_parts": parts,
+	})
+}
+
+// checkMultipartParts kiểm tra đã upload đủ các phần 1..part_count, đúng kích thước và tổng không vượt dung lượng khai báo.
+// Trả về các part number còn thiếu hoặc sai kích thước
+func checkMultipartParts(multipart *model.MultipartUpload, parts []storage.UploadedPart) ([]int, error) {
+	uploaded := make(map[int]storage.UploadedPart, len(parts))
+	for _, part := range parts {
+		uploaded[part.PartNumber] = part
+	}
+
+	var invalid []int
+	var total int64
+	for number := 1; number <= multipart.PartCount; number++ {
+		part, ok := uploaded[number]
+		switch {
+		case !ok:
+			invalid = append(invalid, number)
+		case number < multipart.PartCount && part.Size != multipart.PartSize,
+			number == multipart.PartCount && (part.Size < 1 || part.Size > multipart.PartSize):
+			invalid = append(invalid, number)
+		default:
+			total += part.Size
+		}
+	}
+	if len(invalid) > 0 {
+		return invalid, fmt.Errorf("thiếu hoặc sai kích thước %d phần", len(invalid))
+	}
+	if len(uploaded) != multipart.PartCount {
+		return nil, fmt.Errorf("có %d phần, cần đúng %d phần", len(uploaded), multipart.PartCount)
+	}
+	if total > multipart.Size {
+		return nil, fmt.Errorf("tổng %d bytes lớn hơn dung lượng khai báo %d bytes", total, multipart.Size)
+	}
+	return nil, nil
+}
+
+// CompleteMultipartUpload ghép các phần thành file. Sau đó client xác nhận file vào thư viện như upload thường
+// (/manage/media/confirm với key trả về)
+func (h *S3Handler) CompleteMultipartUpload(c *gin.Context) {
+	multipart := h.getOwnedMultipart(c)
+	if multipart == nil {
+		return
+	}
+	if !multipart.IsActive(time.Now()) {
+		helpers.ErrorResponse(c, helpers.ErrMultipartNotActive, nil)
+		return
+	}
+
+	store, ok := multipartStore(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+
+	parts, err := store.ListParts(ctx, stagingKey(multipart.ObjectKey), multipart.UploadID)
+	if err != nil {
+		if errors.Is(err, storage.ErrMultipartNotFound) {
+			helpers.ErrorResponse(c, helpers.ErrMultipartNotActive, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+		return
+	}
+	if invalid, err := checkMultipartParts(multipart, parts); err != nil {
+		helpers.ErrorResponseWithData(c, helpers.ErrMultipartIncomplete, err, gin.H{
+			"invalid_parts": invalid,
+			"part_size":     multipart.PartSize,
+			"part_count":    multipart.PartCount,
+		})
+		return
+	}
+
+	info, err := store.CompleteMultipartUpload(ctx, stagingKey(multipart.ObjectKey), multipart.UploadID, parts)
+	if err != nil {
+		if errors.Is(err, storage.ErrMultipartNotFound) {
+			helpers.ErrorResponse(c, helpers.ErrMultipartNotActive, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+		return
+	}
+
+	// File đã ghép xong; nếu chưa đánh dấu được phiên completed thì báo lỗi, job dọn phiên quá hạn nhận ra file đã có
+	// và chỉ đóng phiên chứ không xóa file, trả quota
+	if _, err := h.uploadRepo.FinishMultipart(multipart.ID, model.MultipartStatusCompleted, time.Now()); err != nil {
+		logrus.Error("Failed to mark multipart upload completed: ", err)
+		helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+		return
+	}
+	if err := h.uploadRepo.MarkSize(multipart.ObjectKey, info.Size); err != nil {
+		logrus.Error("Failed to update upload size: ", err)
+	}
+
+	// ✅ Tạo URL xem file an toàn (GET có thời hạn) tới bản trong khu chờ; direct_url dùng được sau khi file được kiểm tra sạch
+	viewURL, err := store.PresignGet(ctx, stagingKey(multipart.ObjectKey), 24*time.Hour)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrViewURLFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Hoàn tất upload thành công", gin.H{
+		"key":        multipart.ObjectKey,
+		"size":       info.Size,
+		"etag":       info.ETag,
+		"view_url":   viewURL,
+		"direct_url": store.PublicURL(multipart.ObjectKey),
+	})
+}
+
+// AbortMultipartUpload hủy phiên upload, storage xóa các phần đã upload và trả lại quota
+func (h *S3Handler) AbortMultipartUpload(c *gin.Context) {
+	multipart := h.getOwnedMultipart(c)
+	if multipart == nil {
+		return
+	}
+	if multipart.Status != model.MultipartStatusUploading {
+		helpers.ErrorResponse(c, helpers.ErrMultipartNotActive, nil)
+		return
+	}
+
+	store, ok := multipartStore(c)
+	if !ok {
+		return
+	}
+	if err := h.abortMultipart(c.Request.Context(), store, multipart); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMultipartFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Hủy upload thành công", nil)
+}
+
+// abortMultipart hủy phiên trên storage, đánh dấu aborted và xóa bản ghi upload
+func (h *S3Handler) abortMultipart(ctx context.Context, store storage.MultipartStorage, multipart *model.MultipartUpload) error {
+	if err := store.AbortMultipartUpload(ctx, stagingKey(multipart.ObjectKey), multipart.UploadID); err != nil {
+		return err
+	}
+	finished, err := h.uploadRepo.FinishMultipart(multipart.ID, model.MultipartStatusAborted, time.Now())
+	if err != nil {
+		return err
+	}
+	if finished {
+		return h.uploadRepo.DeleteByKey(multipart.ObjectKey)
+	}
+	return nil
+}
+
+// StartMultipartCleanup hủy các phiên upload nhiều phần quá hạn mỗi MULTIPART_CLEANUP_INTERVAL_MINUTES phút
+// (mặc định 60, 0 = tắt) để các phần đã upload không chiếm dung lượng storage và quota
+func StartMultipartCleanup() {
+	minutes := defaultMultipartCleanupInterval
+	if v, err := strconv.Atoi(os.Getenv("MULTIPART_CLEANUP_INTERVAL_MINUTES")); err == nil && v >= 0 {
+		minutes = v
+	}
+	if minutes == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			cleanupExpiredMultiparts()
+		}
+	}()
+}
+
+func cleanupExpiredMultiparts() {
+	store, err := storage.Get()
+	if err != nil {
+		log.Printf("⚠️  Warning: Multipart cleanup skipped: %v", err)
+		return
+	}
+	backend, ok := store.(storage.MultipartStorage)
+	if !ok {
+		return
+	}
+
+	h := &S3Handler{uploadRepo: repo.NewUploadRepo()}
+	multiparts, err := h.uploadRepo.GetExpiredMultiparts(time.Now(), multipartCleanupBatchSize)
+	if err != nil {
+		log.Printf("⚠️  Warning: Failed to load expired multipart uploads: %v", err)
+		return
+	}
+	for i := range multiparts {
+		completed, err := multipartAssembled(h.uploadRepo, &multiparts[i])
+		if err != nil {
+			log.Printf("⚠️  Warning: Failed to check multipart upload %s: %v", multiparts[i].ObjectKey, err)
+			continue
+		}
+		if completed {
+			if _, err := h.uploadRepo.FinishMultipart(multiparts[i].ID, model.MultipartStatusCompleted, time.Now()); err != nil {
+				log.Printf("⚠️  Warning: Failed to close multipart upload %s: %v", multiparts[i].ObjectKey, err)
+			}
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		err = h.abortMultipart(ctx, backend, &multiparts[i])
+		cancel()
+		if err != nil {
+			log.Printf("⚠️  Warning: Failed to abort multipart upload %s: %v", multiparts[i].ObjectKey, err)
+		}
+	}
+}
+
+// multipartAssembled kiểm tra phiên còn mở nhưng file đã được ghép (hoàn tất trên storage mà chưa đánh dấu completed):
+// file còn trong khu chờ, đã được chuyển tới key chính thức hoặc sổ upload đã có kết quả quét
+func multipartAssembled(uploadRepo *repo.UploadRepo, multipart *model.MultipartUpload) (bool, error) {
+	upload, err := uploadRepo.GetByKey(multipart.ObjectKey)
+	if err != nil {
+		return false, err
+	}
+	if upload != nil && upload.Status != model.MediaStatusPending {
+		return true, nil
+	}
+	_, _, _, err = locateUpload(multipart.ObjectKey, multipart.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
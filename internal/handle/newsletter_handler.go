@@ -0,0 +1,445 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/newsletter"
+	"backend/internal/repo"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type NewsletterHandler struct {
+	newsletterRepo *repo.NewsletterRepo
+	categoryRepo   *repo.CategoryRepo
+	tagRepo        *repo.TagRepo
+}
+
+func NewNewsletterHandler() *NewsletterHandler {
+	return &NewsletterHandler{
+		newsletterRepo: repo.NewNewsletterRepo(),
+		categoryRepo:   repo.NewCategoryRepo(),
+		tagRepo:        repo.NewTagRepo(),
+	}
+}
+
+// NewsletterRateLimit trả về giới hạn số lần đăng ký bản tin theo IP
+// (env NEWSLETTER_RATE_LIMIT, NEWSLETTER_RATE_WINDOW_MINUTES)
+func NewsletterRateLimit() (int, time.Duration) {
+	limit := 5
+	if v, err := strconv.Atoi(os.Getenv("NEWSLETTER_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 60 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("NEWSLETTER_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+// newsletterToken đọc token từ query (link một chạm) hoặc body JSON
+func newsletterToken(c *gin.Context) (string, error) {
+	if token := strings.TrimSpace(c.Query("token")); token != "" {
+		return token, nil
+	}
+	var input model.NewsletterTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(input.Token), nil
+}
+
+// validateTopics kiểm tra danh mục và tag đăng ký theo dõi có tồn tại
+func (h *NewsletterHandler) validateTopics(categoryIDs, tagIDs []uuid.UUID) error {
+	seen := map[uuid.UUID]struct{}{}
+	for _, id := range append(append([]uuid.UUID{}, categoryIDs...), tagIDs...) {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("chủ đề %s xuất hiện nhiều lần", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	if len(categoryIDs) > 0 {
+		categories, err := h.categoryRepo.GetByIDs(categoryIDs)
+		if err != nil {
+			return err
+		}
+		if len(categories) != len(categoryIDs) {
+			return errors.New("một hoặc nhiều danh mục không tồn tại")
+		}
+	}
+	if len(tagIDs) > 0 {
+		tags, err := h.tagRepo.GetByIDs(tagIDs)
+		if err != nil {
+			return err
+		}
+		if len(tags) != len(tagIDs) {
+			return errors.New("một hoặc nhiều tag không tồn tại")
+		}
+	}
+	return nil
+}
+
+// Subscribe đăng ký nhận bản tin và gửi email xác nhận (double opt-in).
+// Luôn trả về cùng một thông báo để không lộ email nào đã đăng ký
+func (h *NewsletterHandler) Subscribe(c *gin.Context) {
+	var input model.SubscribeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	locale := helpers.ResolveLocale(c)
+	if input.Locale != "" {
+		normalized, err := normalizeContentLocale(input.Locale)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+			return
+		}
+		locale = normalized
+	}
+
+	if err := h.validateTopics(input.CategoryIDs, input.TagIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterTopic, err)
+		return
+	}
+
+	const message = "Vui lòng kiểm tra email để xác nhận đăng ký"
+
+	subscriber, err := h.newsletterRepo.GetSubscriberByEmail(email)
+	if err != nil && err.Error() != "subscriber not found" {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if subscriber != nil && subscriber.Status == consts.SubscriberStatusConfirmed {
+		helpers.SuccessResponse(c, message, nil)
+		return
+	}
+
+	isNew := subscriber == nil
+	if isNew {
+		subscriber = &model.Subscriber{Email: email}
+	}
+
+	token, err := newsletter.NewToken()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrNewsletterSubscribeFailed, err)
+		return
+	}
+	if subscriber.UnsubscribeToken == "" {
+		if subscriber.UnsubscribeToken, err = newsletter.NewToken(); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrNewsletterSubscribeFailed, err)
+			return
+		}
+	}
+
+	expiresAt := time.Now().Add(newsletter.ConfirmTTL())
+	subscriber.Name = strings.TrimSpace(input.Name)
+	subscriber.Locale = locale
+	subscriber.Status = consts.SubscriberStatusPending
+	subscriber.ConfirmTokenHash = newsletter.HashToken(token)
+	subscriber.ConfirmTokenExpiresAt = &expiresAt
+	subscriber.SetTopics(input.CategoryIDs, input.TagIDs)
+
+	if isNew {
+		err = h.newsletterRepo.CreateSubscriber(subscriber)
+	} else {
+		err = h.newsletterRepo.UpdateSubscriber(subscriber)
+	}
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrNewsletterSubscribeFailed, err)
+		return
+	}
+
+	newsletter.SendConfirmation(*subscriber, token)
+
+	helpers.SuccessResponse(c, message, nil)
+}
+
+// ConfirmSubscription xác nhận email từ link trong email xác nhận
+func (h *NewsletterHandler) ConfirmSubscription(c *gin.Context) {
+	token, err := newsletterToken(c)
+	if err != nil || token == "" {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterToken, err)
+		return
+	}
+
+	subscriber, err := h.newsletterRepo.GetSubscriberByConfirmToken(newsletter.HashToken(token))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterToken, err)
+		return
+	}
+	now := time.Now()
+	if subscriber.ConfirmTokenExpiresAt == nil || now.After(*subscriber.ConfirmTokenExpiresAt) {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterToken, errors.New("link xác nhận đã hết hạn"))
+		return
+	}
+
+	subscriber.Status = consts.SubscriberStatusConfirmed
+	subscriber.ConfirmedAt = &now
+	subscriber.UnsubscribedAt = nil
+	subscriber.ConfirmTokenHash = ""
+	subscriber.ConfirmTokenExpiresAt = nil
+
+	if err := h.newsletterRepo.UpdateSubscriber(subscriber); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrNewsletterUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xác nhận đăng ký thành công", map[string]interface{}{
+		"email":  subscriber.Email,
+		"status": subscriber.Status,
+	})
+}
+
+// Unsubscribe hủy đăng ký bằng token trong email (hỗ trợ List-Unsubscribe-Post một chạm)
+func (h *NewsletterHandler) Unsubscribe(c *gin.Context) {
+	token, err := newsletterToken(c)
+	if err != nil || token == "" {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterToken, err)
+		return
+	}
+
+	subscriber, err := h.newsletterRepo.GetSubscriberByUnsubscribeToken(token)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidNewsletterToken, err)
+		return
+	}
+
+	if subscriber.Status != consts.SubscriberStatusUnsubscribed {
+		now := time.Now()
+		subscriber.Status = consts.SubscriberStatusUnsubscribed
+		subscriber.UnsubscribedAt = &now
+		subscriber.ConfirmTokenHash = ""
+		subscriber.ConfirmTokenExpiresAt = nil
+		if err := h.newsletterRepo.UpdateSubscriber(subscriber); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrNewsletterUpdateFailed, err)
+			return
+		}
+	}
+
+	helpers.SuccessResponse(c, "Hủy đăng ký thành công", map[string]interface{}{
+		"email":  subscriber.Email,
+		"status": subscriber.Status,
+	})
+}
+
+// parseSubscriberFilter đọc bộ lọc từ query: search, status
+func parseSubscriberFilter(c *gin.Context) (repo.SubscriberFilter, error) {
+	filter := repo.SubscriberFilter{
+		Keyword: strings.TrimSpace(c.Query("search")),
+		Status:  strings.TrimSpace(c.Query("status")),
+	}
+	if filter.Status != "" {
+		for _, s := range consts.SubscriberStatuses {
+			if s == filter.Status {
+				return filter, nil
+			}
+		}
+		return filter, fmt.Errorf("status không hợp lệ: %s", filter.Status)
+	}
+	return filter, nil
+}
+
+// GetSubscribers lấy danh sách người đăng ký (admin)
+// Query: search, status, page, limit
+func (h *NewsletterHandler) GetSubscribers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter, err := parseSubscriberFilter(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSubscriberQuery, err)
+		return
+	}
+
+	subscribers, total, err := h.newsletterRepo.GetSubscribers(filter, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSubscriberListFailed, err)
+		return
+	}
+
+	responses := make([]model.SubscriberResponse, 0, len(subscribers))
+	for i := range subscribers {
+		responses = append(responses, subscribers[i].ToResponse())
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách người đăng ký thành công", map[string]interface{}{
+		"subscribers": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// ExportSubscribers xuất danh sách người đăng ký ra file CSV (cùng bộ lọc với danh sách)
+func (h *NewsletterHandler) ExportSubscribers(c *gin.Context) {
+	filter, err := parseSubscriberFilter(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSubscriberQuery, err)
+		return
+	}
+
+	filename := fmt.Sprintf("subscribers-%s.csv", time.Now().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// BOM để Excel đọc đúng tiếng Việt
+	_, _ = c.Writer.Write([]byte("\xEF\xBB\xBF"))
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"email", "name", "locale", "status", "category_ids", "tag_ids", "confirmed_at", "unsubscribed_at", "last_digest_at", "created_at"})
+
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	joinIDs := func(ids []uuid.UUID) string {
+		parts := make([]string, 0, len(ids))
+		for _, id := range ids {
+			parts = append(parts, id.String())
+		}
+		return strings.Join(parts, ";")
+	}
+
+	err = h.newsletterRepo.FindSubscribersInBatches(filter, 500, func(batch []model.Subscriber) error {
+		for i := range batch {
+			s := &batch[i]
+			record := []string{
+				csvSafe(s.Email),
+				csvSafe(s.Name),
+				s.Locale,
+				s.Status,
+				joinIDs(s.GetCategoryIDs()),
+				joinIDs(s.GetTagIDs()),
+				formatTime(s.ConfirmedAt),
+				formatTime(s.UnsubscribedAt),
+				formatTime(s.LastDigestAt),
+				s.CreatedAt.Format(time.RFC3339),
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	})
+	w.Flush()
+	if err != nil {
+		// Header đã được gửi, chỉ có thể ghi log
+		log.Printf("⚠️  Warning: Subscriber export interrupted: %v", err)
+	}
+}
+
+// csvSafe thêm dấu ' trước ô bắt đầu bằng ký tự công thức (=, +, -, @, tab, CR) để Excel/Sheets
+// không chạy nội dung người dùng nhập như công thức (CSV injection)
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// DeleteSubscriber xóa hẳn người đăng ký (theo yêu cầu xóa dữ liệu cá nhân)
+func (h *NewsletterHandler) DeleteSubscriber(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSubscriberID, err)
+		return
+	}
+
+	if _, err := h.newsletterRepo.GetSubscriberByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSubscriberNotFound, err)
+		return
+	}
+
+	if err := h.newsletterRepo.DeleteSubscriber(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrNewsletterUpdateFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Xóa người đăng ký thành công", nil)
+}
+
+// GetDigests lấy lịch sử gửi bản tin
+func (h *NewsletterHandler) GetDigests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	digests, total, err := h.newsletterRepo.GetDigests(page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy lịch sử gửi bản tin thành công", map[string]interface{}{
+		"digests": digests,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// PreviewDigest soạn thử bản tin kế tiếp (số bài, số người nhận và một email mẫu)
+func (h *NewsletterHandler) PreviewDigest(c *gin.Context) {
+	preview, err := newsletter.PreviewDigest(time.Now())
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrNewsletterDigestFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Soạn thử bản tin thành công", preview)
+}
+
+// SendDigest gửi ngay bản tin tổng hợp các bài mới từ lần gửi trước
+func (h *NewsletterHandler) SendDigest(c *gin.Context) {
+	result, err := newsletter.Run(time.Now())
+	if err != nil {
+		if errors.Is(err, newsletter.ErrDigestRunning) {
+			helpers.ErrorResponse(c, helpers.ErrNewsletterDigestRunning, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrNewsletterDigestFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Gửi bản tin thành công", result)
+}
@@ -2,224 +2,293 @@ package handle
 
 import (
 	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"backend/internal/storage"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"net/url"
-	"os"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/sirupsen/logrus"
 )
 
-type S3Handler struct{}
+type S3Handler struct {
+	mediaRepo  *repo.MediaRepo
+	usageRepo  *repo.MediaUsageRepo
+	uploadRepo *repo.UploadRepo
+}
 
 func NewS3Handler() *S3Handler {
-	return &S3Handler{}
+	return &S3Handler{
+		mediaRepo:  repo.NewMediaRepo(),
+		usageRepo:  repo.NewMediaUsageRepo(),
+		uploadRepo: repo.NewUploadRepo(),
+	}
 }
 
 type PutObjectUpload struct {
 	Key             string `json:"key" binding:"required"`
 	ContentEncoding string `json:"content_encoding" binding:"required"`
 	ContentType     string `json:"content_type" binding:"required"`
+	Size            int64  `json:"size" binding:"required,min=1"` // Kích thước file (bytes), storage từ chối file lớn hơn
+	Ticket          string `json:"ticket"`                        // Vé upload (khách chưa đăng nhập, xem /upload/ticket)
 }
 
-type S3Config struct {
-	AccessKey  string
-	SecretKey  string
-	Endpoint   string
-	BucketName string
-	IsSSL      bool
-	Region     string
-}
-
-// getS3Config lấy cấu hình S3 từ environment variables với validation
-func getS3Config() S3Config {
-	config := S3Config{
-		AccessKey:  strings.TrimSpace(os.Getenv("S3_ACCESS_KEY")),
-		SecretKey:  strings.TrimSpace(os.Getenv("S3_SECRET_KEY")),
-		Endpoint:   strings.TrimSpace(os.Getenv("S3_ENDPOINT")),
-		BucketName: strings.TrimSpace(os.Getenv("S3_BUCKET")),
-		IsSSL:      os.Getenv("S3_SSL") == "true",
-		Region:     strings.TrimSpace(os.Getenv("S3_REGION")),
-	}
-
-	// Set default region if not provided
-	if config.Region == "" {
-		config.Region = "us-east-1"
+// GetUploadUrl tạo form upload trực tiếp (presigned POST của S3 hoặc form ký của storage local) + URL xem file an toàn.
+// Chỉ nhận loại file trong danh sách cho phép, giới hạn dung lượng theo thư mục và quota mỗi người;
+// chỉ tài khoản quản trị được upload theo quota riêng, khách và người dùng thường cần vé upload dùng một lần
+// và chung một hạn mức dung lượng mỗi ngày
+func (h *S3Handler) GetUploadUrl(c *gin.Context) {
+	var data PutObjectUpload
+	if err := c.ShouldBindJSON(&data); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
 	}
 
-	return config
-}
-
-// createMinioClient tạo kết nối minio client với cấu hình cải thiện
-func createMinioClient(config S3Config) (*minio.Client, error) {
-	// Validate required config
-	if config.AccessKey == "" || config.SecretKey == "" || config.Endpoint == "" {
-		return nil, fmt.Errorf("missing required S3 configuration: access_key, secret_key, or endpoint")
+	folder := getFolder(data.ContentType)
+	maxSize := uploadMaxSize(folder)
+	if maxSize == 0 {
+		helpers.ErrorResponse(c, helpers.ErrUploadTypeNotAllowed, nil)
+		return
 	}
 
-	// Create MinIO client with proper options
-	options := &minio.Options{
-		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
-		Secure: config.IsSSL,
-		Region: config.Region,
+	upload := &model.Upload{
+		Folder:       folder,
+		ContentType:  data.ContentType,
+		DeclaredSize: data.Size,
+		IPAddress:    c.ClientIP(),
 	}
 
-	client, err := minio.New(config.Endpoint, options)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	// Tài khoản quản trị hoặc khách có vé upload (người dùng thường đăng nhập cũng cần vé như khách)
+	isUser := isStaff(c)
+	var ticketID uuid.UUID
+	if isUser {
+		id := c.MustGet("userID").(uuid.UUID)
+		upload.UploadedByID = &id
+	} else {
+		if data.Ticket == "" {
+			helpers.ErrorResponse(c, helpers.ErrUploadTicketRequired, nil)
+			return
+		}
+		var err error
+		if ticketID, err = parseUploadTicket(data.Ticket, time.Now()); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidUploadTicket, err)
+			return
+		}
+		if !publicUploadFolders[folder] {
+			helpers.ErrorResponse(c, helpers.ErrUploadFolderNotAllowed, nil)
+			return
+		}
+		if publicMax := envInt64("UPLOAD_PUBLIC_MAX_BYTES", defaultPublicUploadMaxBytes); publicMax < maxSize {
+			maxSize = publicMax
+		}
 	}
 
-	return client, nil
-}
-
-// GetUploadUrl tạo presigned URL để upload file lên S3
-// GetUploadUrl tạo presigned URL để upload file lên S3 + URL xem ảnh an toàn
-func (h *S3Handler) GetUploadUrl(c *gin.Context) {
-	var data PutObjectUpload
-	if err := c.ShouldBindJSON(&data); err != nil {
-		helpers.BadRequestResponse(c, "Dữ liệu đầu vào không hợp lệ")
+	if data.Size > maxSize {
+		helpers.ErrorResponseWithData(c, helpers.ErrUploadTooLarge, nil, gin.H{"max_size": maxSize})
 		return
 	}
+	if !isUser {
+		// Chỉ đánh dấu vé đã dùng khi yêu cầu hợp lệ, để lỗi nhập liệu không làm mất vé
+		consumed, err := h.uploadRepo.ConsumeTicket(ticketID, time.Now())
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+			return
+		}
+		if !consumed {
+			helpers.ErrorResponse(c, helpers.ErrInvalidUploadTicket, nil)
+			return
+		}
+		upload.TicketID = &ticketID
+	}
 
-	config := getS3Config()
-	minioClient, err := createMinioClient(config)
+	store, err := storage.Get()
 	if err != nil {
-		helpers.InternalErrorResponse(c, "Không thể kết nối tới storage service", err)
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
 		return
 	}
 
-	// Kiểm tra bucket
-	if err := ensureBucketExists(minioClient, config.BucketName); err != nil {
-		helpers.InternalErrorResponse(c, "Lỗi storage service", err)
+	// Ghi sổ upload để tính quota (theo dung lượng khai báo cho tới khi file được xác nhận)
+	objectKey := newObjectKey(folder, data.ContentType)
+	upload.ObjectKey = objectKey
+	if !h.reserveUpload(c, upload) {
 		return
 	}
 
-	// Tạo tên file
-	uuidKey := uuid.New()
-	folder := getFolder(data.ContentType)
-	ext := getFileExtension(data.ContentType)
-	objectKey := fmt.Sprintf("%s/%s/%s/%s%s",
-		uuid.New().String(),
-		folder,
-		time.Now().Format("200601"),
-		uuidKey.String(),
-		ext,
-	)
-
-	// Thời gian hết hạn Upload
-	expireUploadTime := time.Duration(1800) * time.Second // 30 phút
-
-	// ✅ Tạo URL Upload (Presigned PUT)
-	uploadURL, err := minioClient.PresignedPutObject(
-		context.Background(),
-		config.BucketName,
-		objectKey,
-		expireUploadTime,
-	)
+	// ✅ Tạo form Upload (POST) vào khu chờ: storage chỉ nhận đúng content type và tối đa số byte đã khai báo
+	uploadURL, fields, err := store.PresignPost(c.Request.Context(), storage.UploadPolicy{
+		Key:         stagingKey(objectKey),
+		ContentType: data.ContentType,
+		MinSize:     1,
+		MaxSize:     data.Size,
+		Expires:     uploadURLExpiry,
+	})
 	if err != nil {
-		helpers.InternalErrorResponse(c, "Không thể tạo URL upload", err)
+		h.releaseUpload(objectKey)
+		if errors.Is(err, storage.ErrTooLarge) {
+			helpers.ErrorResponse(c, helpers.ErrUploadTooLarge, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrUploadURLFailed, err)
 		return
 	}
 
-	// ✅ Tạo URL xem ảnh an toàn (Presigned GET)
+	// ✅ Tạo URL xem ảnh an toàn (GET có thời hạn) tới bản trong khu chờ, chỉ người upload có link
 	expireViewTime := time.Duration(24) * time.Hour // Cho xem ảnh 24h
-	viewURL, err := minioClient.PresignedGetObject(
-		context.Background(),
-		config.BucketName,
-		objectKey,
-		expireViewTime,
-		nil,
-	)
+	viewURL, err := store.PresignGet(c.Request.Context(), stagingKey(objectKey), expireViewTime)
 	if err != nil {
-		helpers.InternalErrorResponse(c, "Không thể tạo URL xem file", err)
+		h.releaseUpload(objectKey)
+		helpers.ErrorResponse(c, helpers.ErrViewURLFailed, err)
 		return
 	}
 
-	// ✅ Direct URL (chỉ dùng nếu bucket đã public)
-	directURL := fmt.Sprintf("https://%s/%s/%s", config.Endpoint, config.BucketName, objectKey)
-
 	helpers.SuccessResponse(c, "Tạo URL upload thành công", gin.H{
-		"upload_url": uploadURL.String(),
-		"view_url":   viewURL.String(), // <--- AN TOÀN, LUÔN DÙNG ĐƯỢC
-		"direct_url": directURL,        // <--- Chỉ dùng nếu làm bucket PUBLIC
+		"upload_url": uploadURL,
+		"method":     http.MethodPost,
+		"fields":     fields,                     // Gửi kèm dạng multipart/form-data, field "file" đặt cuối
+		"view_url":   viewURL,                    // <--- AN TOÀN, LUÔN DÙNG ĐƯỢC
+		"direct_url": store.PublicURL(objectKey), // <--- Dùng được sau khi file được kiểm tra sạch (xác nhận upload hoặc job quét)
 		"key":        objectKey,
+		"max_size":   data.Size,
 	})
 }
 
-// DeleteS3Object xóa file từ S3
+// releaseUpload trả lại quota đã giữ khi không cấp được URL upload
+func (h *S3Handler) releaseUpload(key string) {
+	if err := h.uploadRepo.DeleteByKey(key); err != nil {
+		logrus.Warn("Failed to release upload quota: ", err)
+	}
+}
+
+// DeleteS3Object xóa file khỏi storage. File đang được nội dung tham chiếu chỉ bị xóa khi force = true.
+// Key thuộc một file trong thư viện (file gốc, variant, bản riêng tư/khu chờ/khu cách ly) thì xóa cả file đó:
+// kiểm tra tham chiếu tới mọi key của file và xóa mọi object của nó
 func (h *S3Handler) DeleteS3Object(c *gin.Context) {
 	var input struct {
 		FilePath string `json:"file_path" binding:"required"`
+		Force    bool   `json:"force"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.BadRequestResponse(c, "Đường dẫn file là bắt buộc")
+		helpers.ErrorResponse(c, helpers.ErrFilePathRequired, nil)
 		return
 	}
 
-	config := getS3Config()
-	minioClient, err := createMinioClient(config)
+	store, err := storage.Get()
 	if err != nil {
-		logrus.Error("Failed to create minio client: ", err)
-		helpers.InternalErrorResponse(c, "Không thể kết nối tới storage service", err)
+		logrus.Error("Failed to create storage client: ", err)
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
 		return
 	}
 
-	// Trích xuất bucket và key từ URL hoặc path
-	bucketName, objectName, err := parseS3Path(input.FilePath, config.BucketName)
+	// Trích xuất key từ URL hoặc path
+	objectName, err := store.KeyFromURL(input.FilePath)
 	if err != nil {
-		helpers.BadRequestResponse(c, "Đường dẫn file không hợp lệ")
+		helpers.ErrorResponse(c, helpers.ErrInvalidFilePath, nil)
 		return
 	}
 
-	// Xóa object từ S3
-	err = minioClient.RemoveObject(context.Background(), bucketName, objectName, minio.RemoveObjectOptions{})
+	asset, err := h.findMediaAsset(objectName)
 	if err != nil {
-		logrus.Error("Failed to delete object from S3: ", err)
-		helpers.InternalErrorResponse(c, "Không thể xóa file", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
+	keys, usageKeys, uploadKey := []string{objectName}, []string{objectName}, objectName
+	if asset != nil {
+		keys, usageKeys, uploadKey = mediaStorageKeys(asset), asset.ObjectKeys(), asset.Key
+	}
+
+	usages, err := h.usageRepo.GetByKeys(usageKeys)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrMediaUsageFailed, err)
+		return
+	}
+	if len(usages) > 0 && !input.Force {
+		helpers.ErrorResponseWithData(c, helpers.ErrMediaInUse, nil, gin.H{"usages": usages})
+		return
+	}
+
+	for _, key := range keys {
+		if err := removeObject(key); err != nil {
+			logrus.Error("Failed to delete object from storage: ", err)
+			helpers.ErrorResponse(c, helpers.ErrFileDeleteFailed, err)
+			return
+		}
+	}
+
+	if asset != nil {
+		if err := h.mediaRepo.Delete(asset.ID); err != nil {
+			logrus.Error("Failed to remove media asset: ", err)
+		}
+	}
+	if err := h.uploadRepo.DeleteByKey(uploadKey); err != nil {
+		logrus.Error("Failed to release upload quota: ", err)
+	}
 
 	helpers.SuccessResponse(c, "Xóa file thành công", nil)
 }
 
-// GetS3BucketMemoryUsage lấy thông tin sử dụng dung lượng
+// findMediaAsset tìm file trong thư viện chứa object key; key trong khu chờ, khu cách ly hoặc key riêng tư kiểu cũ
+// ({prefix}{key}) được tra theo key gốc
+func (h *S3Handler) findMediaAsset(objectName string) (*model.MediaAsset, error) {
+	asset, err := h.mediaRepo.GetByObjectKey(objectName)
+	if err != nil || asset != nil {
+		return asset, err
+	}
+	for _, prefix := range []string{storage.PendingPrefix, storage.QuarantinePrefix, storage.PrivatePrefix} {
+		if key := strings.TrimPrefix(objectName, prefix); key != objectName {
+			return h.mediaRepo.GetByObjectKey(key)
+		}
+	}
+	return nil, nil
+}
+
+// GetS3BucketMemoryUsage lấy dung lượng thực tế trên storage và dung lượng theo từng người upload (sổ upload)
 func (h *S3Handler) GetS3BucketMemoryUsage(c *gin.Context) {
-	config := getS3Config()
-	minioClient, err := createMinioClient(config)
+	store, err := storage.Get()
 	if err != nil {
-		logrus.Error("Failed to create minio client: ", err)
-		helpers.InternalErrorResponse(c, "Không thể kết nối tới storage service", err)
+		logrus.Error("Failed to create storage client: ", err)
+		helpers.ErrorResponse(c, helpers.ErrStorageUnavailable, err)
 		return
 	}
 
 	var totalSize int64
-	objectCh := minioClient.ListObjects(context.Background(), config.BucketName, minio.ListObjectsOptions{
-		Recursive: true,
+	err = store.List(c.Request.Context(), func(object storage.ObjectInfo) error {
+		totalSize += object.Size
+		return nil
 	})
+	if err != nil {
+		logrus.Error("Error listing objects: ", err)
+		helpers.ErrorResponse(c, helpers.ErrStorageInfoFailed, err)
+		return
+	}
 
-	for object := range objectCh {
-		if object.Err != nil {
-			logrus.Error("Error listing objects: ", object.Err)
-			helpers.InternalErrorResponse(c, "Lỗi khi lấy thông tin storage", object.Err)
-			return
-		}
-		totalSize += object.Size
+	users, err := h.uploadRepo.GetUsageByUser()
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrUploadQuotaFailed, err)
+		return
 	}
 
 	// Chuyển đổi sang MB để dễ đọc
 	totalSizeMB := float64(totalSize) / (1024 * 1024)
 
 	helpers.SuccessResponse(c, "Lấy thông tin storage thành công", gin.H{
-		"total_size_bytes": totalSize,
-		"total_size_mb":    fmt.Sprintf("%.2f", totalSizeMB),
-		"bucket_name":      config.BucketName,
+		"total_size_bytes":  totalSize,
+		"total_size_mb":     fmt.Sprintf("%.2f", totalSizeMB),
+		"bucket_name":       store.Location(),
+		"user_quota_bytes":  userUploadQuota(),
+		"usage_by_uploader": users,
 	})
 }
 
@@ -297,48 +366,113 @@ func getFileExtension(contentType string) string {
 	}
 }
 
-// ensureBucketExists kiểm tra và tạo bucket nếu chưa tồn tại với cấu hình region
-func ensureBucketExists(minioClient *minio.Client, bucketName string) error {
-	ctx := context.Background()
+// newObjectKey tạo tên file mới dạng {uuid}/{folder}/{yyyymm}/{uuid}{ext}
+func newObjectKey(folder, contentType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s%s",
+		uuid.New().String(),
+		folder,
+		time.Now().Format("200601"),
+		uuid.New().String(),
+		getFileExtension(contentType),
+	)
+}
+
+// stagingKey - Chỗ file vừa upload nằm chờ kiểm tra; file sạch được chuyển tới key công khai (xem scanUpload)
+func stagingKey(key string) string {
+	return storage.PendingPrefix + key
+}
 
-	exists, err := minioClient.BucketExists(ctx, bucketName)
+// objectURL tạo direct URL của object - cùng định dạng direct_url trả về khi upload
+func objectURL(key string) string {
+	store, err := storage.Get()
 	if err != nil {
-		return fmt.Errorf("failed to check bucket existence: %w", err)
+		return ""
 	}
+	return store.PublicURL(key)
+}
 
-	if !exists {
-		err = minioClient.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{
-			Region: "us-east-1", // Đảm bảo region consistency
-		})
-		if err != nil {
-			logrus.Error("Failed to create bucket: ", err)
-			return fmt.Errorf("failed to create bucket: %w", err)
-		}
-		logrus.Info("Created bucket: ", bucketName)
+// Số byte đầu file đọc để lấy kích thước ảnh (đủ cho header JPEG có EXIF lớn)
+const imageHeaderReadBytes = 512 * 1024
+
+// statObject kiểm tra object có trên storage và trả về kích thước, content type, etag
+func statObject(key string) (storage.ObjectInfo, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return storage.ObjectInfo{}, err
 	}
+	return store.Stat(context.Background(), key)
+}
 
-	return nil
+// readImageSize đọc chiều rộng/cao của ảnh từ phần đầu object, không tải cả file
+func readImageSize(key string) (int, int, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return 0, 0, err
+	}
+	object, err := store.Open(context.Background(), key, 0, imageHeaderReadBytes)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer object.Close()
+
+	cfg, _, err := image.DecodeConfig(object)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
 }
 
-// parseS3Path trích xuất bucket và object name từ S3 path hoặc URL
-func parseS3Path(filePath, defaultBucket string) (string, string, error) {
-	// Nếu là URL đầy đủ
-	if strings.HasPrefix(filePath, "http") {
-		u, err := url.Parse(filePath)
-		if err != nil {
-			return "", "", err
-		}
+// readObject tải toàn bộ object, lỗi nếu object lớn hơn maxBytes
+func readObject(key string, maxBytes int64) ([]byte, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return nil, err
+	}
+	object, err := store.Open(context.Background(), key, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
 
-		parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
-		if len(parts) < 2 {
-			return "", "", fmt.Errorf("invalid S3 URL format")
-		}
+	data, err := io.ReadAll(io.LimitReader(object, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("file lớn hơn %d bytes", maxBytes)
+	}
+	return data, nil
+}
 
-		bucketName := parts[0]
-		objectName := strings.Join(parts[1:], "/")
-		return bucketName, objectName, nil
+// putObject ghi object do server tạo (vd variant ảnh). Cache 1 ngày vì tạo lại variant ghi đè cùng key
+func putObject(key, contentType string, data []byte) (storage.ObjectInfo, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return storage.ObjectInfo{}, err
 	}
+	return store.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), storage.PutOptions{
+		ContentType:  contentType,
+		CacheControl: "public, max-age=86400",
+	})
+}
 
-	// Nếu chỉ là object key
-	return defaultBucket, strings.TrimPrefix(filePath, "/"), nil
+// replaceObject ghi đè file gốc do người dùng upload (vd sau khi bỏ metadata); giống lúc upload, không đặt Cache-Control
+func replaceObject(key, contentType string, data []byte) (storage.ObjectInfo, error) {
+	store, err := storage.Get()
+	if err != nil {
+		return storage.ObjectInfo{}, err
+	}
+	return store.Put(context.Background(), key, bytes.NewReader(data), int64(len(data)), storage.PutOptions{ContentType: contentType})
+}
+
+// removeObject xóa object, bỏ qua nếu object không còn
+func removeObject(key string) error {
+	store, err := storage.Get()
+	if err != nil {
+		return err
+	}
+	if err := store.Remove(context.Background(), key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,346 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type SeriesHandler struct {
+	seriesRepo  *repo.SeriesRepo
+	articleRepo *repo.ArticleRepo
+}
+
+func NewSeriesHandler() *SeriesHandler {
+	return &SeriesHandler{
+		seriesRepo:  repo.NewSeriesRepo(),
+		articleRepo: repo.NewArticleRepo(),
+	}
+}
+
+// validateSeriesArticles kiểm tra danh sách bài viết: không trùng, tồn tại và chưa thuộc chuỗi khác
+func (h *SeriesHandler) validateSeriesArticles(seriesID uuid.UUID, articleIDs []uuid.UUID) (*helpers.APIError, error) {
+	seen := make(map[uuid.UUID]struct{}, len(articleIDs))
+	for _, id := range articleIDs {
+		if _, ok := seen[id]; ok {
+			return helpers.ErrSeriesDuplicateArticle, fmt.Errorf("bài viết %s xuất hiện nhiều lần", id)
+		}
+		seen[id] = struct{}{}
+		if _, err := h.articleRepo.GetByID(id); err != nil {
+			return helpers.ErrSeriesInvalidArticle, fmt.Errorf("không tìm thấy bài viết %s", id)
+		}
+	}
+
+	conflicts, err := h.seriesRepo.FindArticleConflicts(seriesID, articleIDs)
+	if err != nil {
+		return helpers.ErrDatabase, err
+	}
+	if len(conflicts) > 0 {
+		return helpers.ErrSeriesArticleConflict, fmt.Errorf("bài viết %s đã thuộc chuỗi khác", conflicts[0])
+	}
+	return nil, nil
+}
+
+// CreateSeries tạo chuỗi bài viết mới
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var input model.SeriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	input.Slug = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input.Slug), " ", "-"))
+
+	exists, err := h.seriesRepo.CheckSlugExists(input.Slug, uuid.Nil)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if exists {
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("chuỗi bài viết với slug này đã tồn tại"))
+		return
+	}
+
+	series := model.Series{
+		ID:          uuid.New(),
+		Title:       strings.TrimSpace(input.Title),
+		Slug:        input.Slug,
+		Description: input.Description,
+		IsActive:    true,
+	}
+	if input.IsActive != nil {
+		series.IsActive = *input.IsActive
+	}
+	if input.Metadata != nil {
+		series.Metadata = datatypes.JSON(input.Metadata)
+	}
+
+	if apiErr, err := h.validateSeriesArticles(series.ID, input.ArticleIDs); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	if err := h.seriesRepo.Create(&series); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesCreateFailed, err)
+		return
+	}
+
+	if err := h.seriesRepo.SetArticles(series.ID, input.ArticleIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesArticlesUpdateFailed, err)
+		return
+	}
+	// Thẻ bài viết trên trang chủ kèm thông tin chuỗi
+	invalidateHomepageCache()
+
+	created, err := h.seriesRepo.GetByID(series.ID)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesReloadFailed, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.Response{
+		Success: true,
+		Message: "Tạo chuỗi bài viết thành công",
+		Data:    created.ToResponse(false),
+	})
+}
+
+// GetSeriesList lấy danh sách chuỗi bài viết (admin)
+func (h *SeriesHandler) GetSeriesList(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	search := strings.TrimSpace(c.Query("search"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	series, total, err := h.seriesRepo.Search(search, page, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesListFailed, err)
+		return
+	}
+
+	responses := make([]model.SeriesResponse, 0, len(series))
+	for i := range series {
+		responses = append(responses, series[i].ToResponse(false))
+	}
+
+	totalPages := (total + int64(limit) - 1) / int64(limit)
+
+	helpers.SuccessResponse(c, "Lấy danh sách chuỗi bài viết thành công", map[string]interface{}{
+		"series": responses,
+		"pagination": map[string]interface{}{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+// GetSeriesByID lấy chuỗi bài viết theo ID (admin)
+func (h *SeriesHandler) GetSeriesByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSeriesID, err)
+		return
+	}
+
+	series, err := h.seriesRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thông tin chuỗi bài viết thành công", series.ToResponse(false))
+}
+
+// UpdateSeries cập nhật chuỗi bài viết
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSeriesID, err)
+		return
+	}
+
+	var input model.SeriesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	series, err := h.seriesRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, err)
+		return
+	}
+
+	input.Slug = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input.Slug), " ", "-"))
+
+	exists, err := h.seriesRepo.CheckSlugExists(input.Slug, id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+		return
+	}
+	if exists {
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("chuỗi bài viết khác với slug này đã tồn tại"))
+		return
+	}
+
+	series.Title = strings.TrimSpace(input.Title)
+	series.Slug = input.Slug
+	series.Description = input.Description
+	if input.IsActive != nil {
+		series.IsActive = *input.IsActive
+	}
+	if input.Metadata != nil {
+		series.Metadata = datatypes.JSON(input.Metadata)
+	}
+
+	// article_ids chỉ được thay thế khi client gửi lên
+	if input.ArticleIDs != nil {
+		if apiErr, err := h.validateSeriesArticles(id, input.ArticleIDs); err != nil {
+			helpers.ErrorResponse(c, apiErr, err)
+			return
+		}
+	}
+
+	if err := h.seriesRepo.Update(series); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesUpdateFailed, err)
+		return
+	}
+
+	if input.ArticleIDs != nil {
+		if err := h.seriesRepo.SetArticles(id, input.ArticleIDs); err != nil {
+			helpers.ErrorResponse(c, helpers.ErrSeriesArticlesUpdateFailed, err)
+			return
+		}
+	}
+	invalidateHomepageCache()
+
+	updated, err := h.seriesRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesReloadFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật chuỗi bài viết thành công", updated.ToResponse(false))
+}
+
+// SetSeriesArticles thay thế danh sách và thứ tự các phần của chuỗi
+func (h *SeriesHandler) SetSeriesArticles(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSeriesID, err)
+		return
+	}
+
+	var input model.SeriesArticleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		helpers.ValidationErrorResponse(c, err)
+		return
+	}
+
+	if _, err := h.seriesRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, err)
+		return
+	}
+
+	if apiErr, err := h.validateSeriesArticles(id, input.ArticleIDs); err != nil {
+		helpers.ErrorResponse(c, apiErr, err)
+		return
+	}
+
+	if err := h.seriesRepo.SetArticles(id, input.ArticleIDs); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesArticlesUpdateFailed, err)
+		return
+	}
+	invalidateHomepageCache()
+
+	updated, err := h.seriesRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesReloadFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Cập nhật bài viết của chuỗi thành công", updated.ToResponse(false))
+}
+
+// DeleteSeries xóa chuỗi bài viết (các bài viết vẫn được giữ nguyên)
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidSeriesID, err)
+		return
+	}
+
+	if _, err := h.seriesRepo.GetByID(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, err)
+		return
+	}
+
+	if err := h.seriesRepo.Delete(id); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrSeriesDeleteFailed, err)
+		return
+	}
+	invalidateHomepageCache()
+
+	helpers.SuccessResponse(c, "Xóa chuỗi bài viết thành công", nil)
+}
+
+// GetPublicSeriesBySlug trả về chuỗi bài viết public, chỉ gồm các phần đã xuất bản
+// Query params: article (slug bài viết đang đọc) để tính điều hướng trước/sau
+func (h *SeriesHandler) GetPublicSeriesBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	series, err := h.seriesRepo.GetActiveBySlug(slug)
+	if err != nil {
+		if err.Error() == "series not found" {
+			helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, err)
+			return
+		}
+		helpers.ErrorResponse(c, helpers.ErrSeriesFetchFailed, err)
+		return
+	}
+
+	response := series.ToResponse(true)
+	if len(response.Parts) == 0 {
+		helpers.ErrorResponse(c, helpers.ErrSeriesNotFound, errors.New("series has no published parts"))
+		return
+	}
+
+	// Ẩn trạng thái nội bộ khỏi response công khai
+	for i := range response.Parts {
+		response.Parts[i].Status = ""
+	}
+
+	if current := strings.TrimSpace(c.Query("article")); current != "" {
+		for i := range response.Parts {
+			if response.Parts[i].Slug != current {
+				continue
+			}
+			if position := model.PositionOf(series, response.Parts, response.Parts[i].ID); position != nil {
+				currentPart := response.Parts[i]
+				response.Current = &currentPart
+				response.Prev = position.Prev
+				response.Next = position.Next
+			}
+			break
+		}
+	}
+
+	helpers.SuccessResponse(c, "Lấy chuỗi bài viết thành công", response)
+}
@@ -3,19 +3,23 @@ package handle
 import (
 	"net/http"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
+	"backend/internal/consts"
+	"backend/internal/helpers"
 	"backend/internal/repo"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type SitemapHandler struct {
     tagRepo      *repo.TagRepo
     categoryRepo *repo.CategoryRepo
     articleRepo  *repo.ArticleRepo
+    authorRepo   *repo.AuthorProfileRepo
+    documentRepo *repo.LegalDocumentRepo
 }
 
 func NewSitemapHandler() *SitemapHandler {
@@ -23,28 +27,74 @@ func NewSitemapHandler() *SitemapHandler {
         tagRepo:      repo.NewTagRepo(),
         categoryRepo: repo.NewCategoryRepo(),
         articleRepo:  repo.NewArticleRepo(),
+        authorRepo:   repo.NewAuthorProfileRepo(),
+        documentRepo: repo.NewLegalDocumentRepo(),
     }
 }
 
 // SitemapURL định dạng JSON trả về cho Nuxt
 type SitemapURL struct {
-    Loc        string  `json:"loc"`
-    LastMod    string  `json:"lastmod,omitempty"`
-    ChangeFreq string  `json:"changefreq,omitempty"`
-    Priority   float64 `json:"priority,omitempty"`
+    Loc          string               `json:"loc"`
+    LastMod      string               `json:"lastmod,omitempty"`
+    ChangeFreq   string               `json:"changefreq,omitempty"`
+    Priority     float64              `json:"priority,omitempty"`
+    Alternatives []SitemapAlternative `json:"alternatives,omitempty"`
+}
+
+// SitemapAlternative liên kết hreflang tới bản dịch của cùng trang
+type SitemapAlternative struct {
+    Hreflang string `json:"hreflang"`
+    Href     string `json:"href"`
+}
+
+// sitemapRow là dòng slug trả về từ các repo (cùng cấu trúc với GetAllSlugsWithUpdatedAt)
+type sitemapRow = struct{
+    Slug string
+    UpdatedAt time.Time
+    Locale string
+    TranslationGroupID *uuid.UUID
+}
+
+// localizedPath thêm tiền tố ngôn ngữ cho URL (ngôn ngữ mặc định không có tiền tố)
+func localizedPath(locale, path string) string {
+    return helpers.LocalizedPath(locale, path)
+}
+
+// buildSitemapURLs tạo danh sách SitemapURL, kèm hreflang alternates cho các trang có bản dịch
+func buildSitemapURLs(rows []sitemapRow, base, prefix, changeFreq string, priority float64) []SitemapURL {
+    groups := map[uuid.UUID][]sitemapRow{}
+    for _, r := range rows {
+        if r.TranslationGroupID != nil {
+            groups[*r.TranslationGroupID] = append(groups[*r.TranslationGroupID], r)
+        }
+    }
+
+    out := make([]SitemapURL, 0, len(rows))
+    for _, r := range rows {
+        u := SitemapURL{
+            Loc: base + localizedPath(r.Locale, prefix+r.Slug),
+            LastMod: r.UpdatedAt.Format("2006-01-02"),
+            ChangeFreq: changeFreq,
+            Priority: priority,
+        }
+        if r.TranslationGroupID != nil && len(groups[*r.TranslationGroupID]) > 1 {
+            for _, v := range groups[*r.TranslationGroupID] {
+                href := base + localizedPath(v.Locale, prefix+v.Slug)
+                u.Alternatives = append(u.Alternatives, SitemapAlternative{Hreflang: v.Locale, Href: href})
+                if v.Locale == consts.DefaultLocale {
+                    u.Alternatives = append(u.Alternatives, SitemapAlternative{Hreflang: "x-default", Href: href})
+                }
+            }
+        }
+        out = append(out, u)
+    }
+    return out
 }
 
 // getPublicBase lấy domain public từ env PUBLIC_WEB_DOMAIN (ví dụ https://quantriduanxaydung.vn)
 // fallback về https://quantriduanxaydung.vn nếu không set
 func getPublicBase() string {
-    v := os.Getenv("PUBLIC_WEB_DOMAIN")
-    if v == "" {
-        return "https://quantriduanxaydung.vn"
-    }
-    if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") {
-        return strings.TrimRight(v, "/")
-    }
-    return "https://" + strings.TrimRight(v, "/")
+    return helpers.PublicBaseURL()
 }
 
 // Simple in-memory caches per resource to avoid hitting DB on every request
@@ -61,6 +111,14 @@ var (
         data []SitemapURL
         expires time.Time
     }
+    authorsCache struct{
+        data []SitemapURL
+        expires time.Time
+    }
+    legalDocumentsCache struct{
+        data []SitemapURL
+        expires time.Time
+    }
     cacheMu sync.RWMutex
 )
 
@@ -87,20 +145,11 @@ func (h *SitemapHandler) GetTagsURLs(c *gin.Context) {
 
     rows, err := h.tagRepo.GetAllSlugsWithUpdatedAt(true)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        helpers.ErrorResponse(c, helpers.ErrSitemapFailed, err)
         return
     }
 
-    base := getPublicBase()
-    out := make([]SitemapURL, 0, len(rows))
-    for _, r := range rows {
-        out = append(out, SitemapURL{
-            Loc: base + "/tags/" + r.Slug,
-            LastMod: r.UpdatedAt.Format("2006-01-02"),
-            ChangeFreq: "weekly",
-            Priority: 0.6,
-        })
-    }
+    out := buildSitemapURLs(rows, getPublicBase(), "/tags/", "weekly", 0.6)
 
     cacheMu.Lock()
     tagsCache.data = out
@@ -125,20 +174,11 @@ func (h *SitemapHandler) GetCategoriesURLs(c *gin.Context) {
 
     rows, err := h.categoryRepo.GetAllSlugsWithUpdatedAt(true)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        helpers.ErrorResponse(c, helpers.ErrSitemapFailed, err)
         return
     }
 
-    base := getPublicBase()
-    out := make([]SitemapURL, 0, len(rows))
-    for _, r := range rows {
-        out = append(out, SitemapURL{
-            Loc: base + "/categories/" + r.Slug,
-            LastMod: r.UpdatedAt.Format("2006-01-02"),
-            ChangeFreq: "weekly",
-            Priority: 0.7,
-        })
-    }
+    out := buildSitemapURLs(rows, getPublicBase(), "/categories/", "weekly", 0.7)
 
     cacheMu.Lock()
     categoriesCache.data = out
@@ -163,20 +203,11 @@ func (h *SitemapHandler) GetArticlesURLs(c *gin.Context) {
 
     rows, err := h.articleRepo.GetPublishedSlugsWithUpdatedAt(0)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        helpers.ErrorResponse(c, helpers.ErrSitemapFailed, err)
         return
     }
 
-    base := getPublicBase()
-    out := make([]SitemapURL, 0, len(rows))
-    for _, r := range rows {
-        out = append(out, SitemapURL{
-            Loc: base + "/bai-viet/" + r.Slug,
-            LastMod: r.UpdatedAt.Format("2006-01-02"),
-            ChangeFreq: "weekly",
-            Priority: 0.8,
-        })
-    }
+    out := buildSitemapURLs(rows, getPublicBase(), "/bai-viet/", "weekly", 0.8)
 
     cacheMu.Lock()
     articlesCache.data = out
@@ -186,3 +217,61 @@ func (h *SitemapHandler) GetArticlesURLs(c *gin.Context) {
     c.Header("Cache-Control", "public, max-age=3600")
     c.JSON(http.StatusOK, out)
 }
+
+// GetAuthorsURLs trả về SitemapURL cho hồ sơ tác giả đang hoạt động
+func (h *SitemapHandler) GetAuthorsURLs(c *gin.Context) {
+    cacheMu.RLock()
+    if time.Now().Before(authorsCache.expires) && authorsCache.data != nil {
+        data := authorsCache.data
+        cacheMu.RUnlock()
+        c.Header("Cache-Control", "public, max-age=3600")
+        c.JSON(http.StatusOK, data)
+        return
+    }
+    cacheMu.RUnlock()
+
+    rows, err := h.authorRepo.GetActiveSlugsWithUpdatedAt()
+    if err != nil {
+        helpers.ErrorResponse(c, helpers.ErrSitemapFailed, err)
+        return
+    }
+
+    out := buildSitemapURLs(rows, getPublicBase(), "/tac-gia/", "monthly", 0.5)
+
+    cacheMu.Lock()
+    authorsCache.data = out
+    authorsCache.expires = time.Now().Add(cacheTTL())
+    cacheMu.Unlock()
+
+    c.Header("Cache-Control", "public, max-age=3600")
+    c.JSON(http.StatusOK, out)
+}
+
+// GetLegalDocumentsURLs trả về SitemapURL cho văn bản pháp luật đang hiển thị
+func (h *SitemapHandler) GetLegalDocumentsURLs(c *gin.Context) {
+    cacheMu.RLock()
+    if time.Now().Before(legalDocumentsCache.expires) && legalDocumentsCache.data != nil {
+        data := legalDocumentsCache.data
+        cacheMu.RUnlock()
+        c.Header("Cache-Control", "public, max-age=3600")
+        c.JSON(http.StatusOK, data)
+        return
+    }
+    cacheMu.RUnlock()
+
+    rows, err := h.documentRepo.GetActiveSlugsWithUpdatedAt()
+    if err != nil {
+        helpers.ErrorResponse(c, helpers.ErrSitemapFailed, err)
+        return
+    }
+
+    out := buildSitemapURLs(rows, getPublicBase(), "/van-ban/", "monthly", 0.6)
+
+    cacheMu.Lock()
+    legalDocumentsCache.data = out
+    legalDocumentsCache.expires = time.Now().Add(cacheTTL())
+    cacheMu.Unlock()
+
+    c.Header("Cache-Control", "public, max-age=3600")
+    c.JSON(http.StatusOK, out)
+}
@@ -6,6 +6,7 @@ import (
 	"backend/internal/repo"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -31,7 +32,7 @@ func NewTagHandler() *TagHandler {
 func (h *TagHandler) CreateTag(c *gin.Context) {
 	var input model.TagInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -41,22 +42,53 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 	// Kiểm tra xem slug đã tồn tại chưa
 	exists, err := h.tagRepo.CheckSlugExists(input.Slug, uuid.Nil)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 	if exists {
-		helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("tag với slug này đã tồn tại"))
+		helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("tag với slug này đã tồn tại"))
 		return
 	}
 
+	locale, err := normalizeContentLocale(input.Locale)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidLocale, err)
+		return
+	}
+
+	// Liên kết vào nhóm bản dịch của tag gốc nếu có
+	var translationGroupID *uuid.UUID
+	if input.TranslationOf != nil {
+		source, err := h.tagRepo.GetByID(*input.TranslationOf)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrInvalidTranslationOf, errors.New("không tìm thấy tag gốc của bản dịch"))
+			return
+		}
+		translationGroupID = source.TranslationGroupID
+		if translationGroupID == nil {
+			translationGroupID = &source.ID
+		}
+		exists, err := h.tagRepo.CheckTranslationLocaleExists(*translationGroupID, locale, uuid.Nil)
+		if err != nil {
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
+			return
+		}
+		if exists {
+			helpers.ErrorResponse(c, helpers.ErrTranslationExists, fmt.Errorf("tag đã có bản dịch ngôn ngữ '%s'", locale))
+			return
+		}
+	}
+
 	// Tạo model tag từ input
 	tag := model.Tag{
-		Name:         input.Name,
-		Slug:         input.Slug,
-		Description:  input.Description,
-		DisplayOrder: 0,
-		IsActive:     true,
-		UsageCount:   0,
+		Name:               input.Name,
+		Slug:               input.Slug,
+		Description:        input.Description,
+		DisplayOrder:       0,
+		IsActive:           true,
+		UsageCount:         0,
+		Locale:             locale,
+		TranslationGroupID: translationGroupID,
 	}
 
 	if input.DisplayOrder != nil {
@@ -77,9 +109,11 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 	}
 
 	if err := h.tagRepo.Create(&tag); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tạo tag", err)
+		helpers.ErrorResponse(c, helpers.ErrTagCreateFailed, err)
 		return
 	}
+	// Trang chủ hiển thị tên tag trên thẻ bài viết và khối tags phổ biến
+	invalidateHomepageCache()
 
 	c.JSON(http.StatusCreated, helpers.Response{
 		Success: true,
@@ -110,7 +144,7 @@ func (h *TagHandler) GetTags(c *gin.Context) {
 	tags, total, err = h.tagRepo.SearchTags(search, page, limit, activeOnly)
 
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách tags", err)
+		helpers.ErrorResponse(c, helpers.ErrTagListFailed, err)
 		return
 	}
 
@@ -146,9 +180,9 @@ func (h *TagHandler) GetPublicTags(c *gin.Context) {
 		limit = 20
 	}
 
-	tags, total, err := h.tagRepo.GetAll(page, limit, true)
+	tags, total, err := h.tagRepo.GetAll(page, limit, true, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy danh sách tags", err)
+		helpers.ErrorResponse(c, helpers.ErrTagListFailed, err)
 		return
 	}
 
@@ -175,42 +209,48 @@ func (h *TagHandler) GetTagByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID tag không hợp lệ", errors.New("ID tag phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidTagID, errors.New("ID tag phải là UUID hợp lệ"))
 		return
 	}
 
 	tag, err := h.tagRepo.GetByID(id)
 	if err != nil {
 		if err.Error() == "tag not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy tag", err)
+			helpers.ErrorResponse(c, helpers.ErrTagNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "Lấy thông tin tag thành công", tag.ToResponse())
+	resp := tag.ToResponse()
+	resp.Translations, _ = h.tagRepo.GetTranslations(tag, false)
+
+	helpers.SuccessResponse(c, "Lấy thông tin tag thành công", resp)
 }
 
 // GetTagBySlug lấy tag theo slug
 func (h *TagHandler) GetTagBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Slug không hợp lệ", errors.New("slug không được để trống"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidSlug, errors.New("slug không được để trống"))
 		return
 	}
 
 	tag, err := h.tagRepo.GetBySlug(slug)
 	if err != nil {
 		if err.Error() == "tag not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy tag", err)
+			helpers.ErrorResponse(c, helpers.ErrTagNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
-	helpers.SuccessResponse(c, "Lấy thông tin tag thành công", tag.ToResponse())
+	resp := tag.ToResponse()
+	resp.Translations, _ = h.tagRepo.GetTranslations(tag, false)
+
+	helpers.SuccessResponse(c, "Lấy thông tin tag thành công", resp)
 }
 
 // UpdateTag cập nhật tag
@@ -218,13 +258,13 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID tag không hợp lệ", errors.New("ID tag phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidTagID, errors.New("ID tag phải là UUID hợp lệ"))
 		return
 	}
 
 	var input model.TagUpdateInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", err)
+		helpers.ValidationErrorResponse(c, err)
 		return
 	}
 
@@ -232,10 +272,10 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 	tag, err := h.tagRepo.GetByID(id)
 	if err != nil {
 		if err.Error() == "tag not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy tag", err)
+			helpers.ErrorResponse(c, helpers.ErrTagNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
@@ -246,11 +286,11 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 	if input.Slug != tag.Slug {
 		exists, err := h.tagRepo.CheckSlugExists(input.Slug, id)
 		if err != nil {
-			helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+			helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 			return
 		}
 		if exists {
-			helpers.ErrorResponse(c, http.StatusConflict, "Slug đã tồn tại", errors.New("tag khác với slug này đã tồn tại"))
+			helpers.ErrorResponse(c, helpers.ErrSlugExists, errors.New("tag khác với slug này đã tồn tại"))
 			return
 		}
 	}
@@ -278,9 +318,10 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 	}
 
 	if err := h.tagRepo.Update(tag); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể cập nhật tag", err)
+		helpers.ErrorResponse(c, helpers.ErrTagUpdateFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	helpers.SuccessResponse(c, "Cập nhật tag thành công", tag.ToResponse())
 }
@@ -290,7 +331,7 @@ func (h *TagHandler) DeleteTag(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "ID tag không hợp lệ", errors.New("ID tag phải là UUID hợp lệ"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidTagID, errors.New("ID tag phải là UUID hợp lệ"))
 		return
 	}
 
@@ -298,17 +339,18 @@ func (h *TagHandler) DeleteTag(c *gin.Context) {
 	_, err = h.tagRepo.GetByID(id)
 	if err != nil {
 		if err.Error() == "tag not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy tag", err)
+			helpers.ErrorResponse(c, helpers.ErrTagNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", err)
+		helpers.ErrorResponse(c, helpers.ErrDatabase, err)
 		return
 	}
 
 	if err := h.tagRepo.Delete(id); err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể xóa tag", err)
+		helpers.ErrorResponse(c, helpers.ErrTagDeleteFailed, err)
 		return
 	}
+	invalidateHomepageCache()
 
 	helpers.SuccessResponse(c, "Xóa tag thành công", nil)
 }
@@ -320,9 +362,9 @@ func (h *TagHandler) GetPopularTags(c *gin.Context) {
 		limit = 10
 	}
 
-	tags, err := h.tagRepo.GetPopularTags(limit)
+	tags, err := h.tagRepo.GetPopularTags(limit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy tags phổ biến", err)
+		helpers.ErrorResponse(c, helpers.ErrPopularTagsFailed, err)
 		return
 	}
 
@@ -338,7 +380,7 @@ func (h *TagHandler) GetPopularTags(c *gin.Context) {
 func (h *TagHandler) SearchTags(c *gin.Context) {
 	keyword := strings.TrimSpace(c.Query("q"))
 	if keyword == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Từ khóa tìm kiếm là bắt buộc", nil)
+		helpers.ErrorResponse(c, helpers.ErrSearchKeywordRequired, nil)
 		return
 	}
 
@@ -353,7 +395,7 @@ func (h *TagHandler) SearchTags(c *gin.Context) {
 
 	tags, total, err := h.tagRepo.SearchTags(keyword, page, limit, true)
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể tìm kiếm tags", err)
+		helpers.ErrorResponse(c, helpers.ErrTagSearchFailed, err)
 		return
 	}
 
@@ -382,7 +424,7 @@ func (h *TagHandler) SearchTags(c *gin.Context) {
 func (h *TagHandler) GetArticlesByTagSlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if strings.TrimSpace(slug) == "" {
-		helpers.ErrorResponse(c, http.StatusBadRequest, "Slug không hợp lệ", errors.New("slug không được để trống"))
+		helpers.ErrorResponse(c, helpers.ErrInvalidSlug, errors.New("slug không được để trống"))
 		return
 	}
 
@@ -398,16 +440,16 @@ func (h *TagHandler) GetArticlesByTagSlug(c *gin.Context) {
 	tag, err := h.tagRepo.GetBySlug(slug)
 	if err != nil {
 		if err.Error() == "tag not found" {
-			helpers.ErrorResponse(c, http.StatusNotFound, "Không tìm thấy tag", err)
+			helpers.ErrorResponse(c, helpers.ErrTagNotFound, err)
 			return
 		}
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy tag", err)
+		helpers.ErrorResponse(c, helpers.ErrTagFetchFailed, err)
 		return
 	}
 
-	articles, total, err := h.articleRepo.GetPublishedByTagID(tag.ID, page, limit)
+	articles, total, err := h.articleRepo.GetPublishedByTagID(tag.ID, page, limit, helpers.ResolveLocale(c))
 	if err != nil {
-		helpers.ErrorResponse(c, http.StatusInternalServerError, "Không thể lấy bài viết theo tag", err)
+		helpers.ErrorResponse(c, helpers.ErrTagArticlesFailed, err)
 		return
 	}
 
@@ -416,10 +458,13 @@ func (h *TagHandler) GetArticlesByTagSlug(c *gin.Context) {
 		responses = append(responses, article.ToResponse())
 	}
 
+	tagResp := tag.ToResponse()
+	tagResp.Translations, _ = h.tagRepo.GetTranslations(tag, true)
+
 	totalPages := (total + int64(limit) - 1) / int64(limit)
 
 	helpers.SuccessResponse(c, "Lấy bài viết theo tag thành công", map[string]interface{}{
-		"tag":      tag.ToResponse(),
+		"tag":      tagResp,
 		"articles": responses,
 		"pagination": map[string]interface{}{
 			"page":        page,
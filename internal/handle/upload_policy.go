@@ -0,0 +1,220 @@
+package handle
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Dung lượng tối đa một file theo thư mục (ghi đè bằng UPLOAD_MAX_IMAGE_BYTES, UPLOAD_MAX_DOCUMENT_BYTES, UPLOAD_MAX_MEDIA_BYTES).
+// Chỉ cho phép các loại file có trong getFolder, loại "other" bị từ chối
+var uploadFolderLimits = map[string]struct {
+	env      string
+	fallback int64
+}{
+	"images":    {"UPLOAD_MAX_IMAGE_BYTES", 10 << 20},
+	"documents": {"UPLOAD_MAX_DOCUMENT_BYTES", 25 << 20},
+	"media":     {"UPLOAD_MAX_MEDIA_BYTES", 500 << 20},
+}
+
+// Thư mục khách chưa đăng nhập được upload bằng vé (đính kèm hồ sơ tư vấn)
+var publicUploadFolders = map[string]bool{"images": true, "documents": true}
+
+const (
+	defaultPublicUploadMaxBytes = 10 << 20 // UPLOAD_PUBLIC_MAX_BYTES
+	defaultPublicDailyBytes     = 1 << 30  // UPLOAD_PUBLIC_DAILY_BYTES: tổng dung lượng mọi khách trong 24 giờ, 0 = không giới hạn
+	defaultUserQuotaBytes       = 2 << 30  // UPLOAD_USER_QUOTA_BYTES, 0 = không giới hạn
+	defaultUploadTicketTTL      = 30 * time.Minute
+	uploadURLExpiry             = 30 * time.Minute
+)
+
+var errInvalidUploadTicket = errors.New("vé upload không hợp lệ")
+
+// uploadMaxSize lấy dung lượng tối đa của thư mục; 0 là loại file không được phép
+func uploadMaxSize(folder string) int64 {
+	limit, ok := uploadFolderLimits[folder]
+	if !ok {
+		return 0
+	}
+	return envInt64(limit.env, limit.fallback)
+}
+
+// userUploadQuota lấy quota mỗi người dùng; 0 là không giới hạn
+func userUploadQuota() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("UPLOAD_USER_QUOTA_BYTES"), 10, 64); err == nil && v >= 0 {
+		return v
+	}
+	return defaultUserQuotaBytes
+}
+
+// UploadTicketRateLimit đọc cấu hình giới hạn số vé upload mỗi IP (mặc định 10 vé / 60 phút)
+func UploadTicketRateLimit() (int, time.Duration) {
+	limit := 10
+	if v, err := strconv.Atoi(os.Getenv("UPLOAD_TICKET_RATE_LIMIT")); err == nil && v >= 0 {
+		limit = v
+	}
+	window := 60 * time.Minute
+	if v, err := strconv.Atoi(os.Getenv("UPLOAD_TICKET_RATE_WINDOW_MINUTES")); err == nil && v > 0 {
+		window = time.Duration(v) * time.Minute
+	}
+	return limit, window
+}
+
+func uploadTicketTTL() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("UPLOAD_TICKET_TTL_MINUTES")); err == nil && v > 0 {
+		return time.Duration(v) * time.Minute
+	}
+	return defaultUploadTicketTTL
+}
+
+// signUploadTicket ký ID + hạn của vé (UPLOAD_TICKET_SECRET, mặc định dùng khóa JWT)
+func signUploadTicket(id uuid.UUID, expires int64) string {
+	secret := os.Getenv("UPLOAD_TICKET_SECRET")
+	if secret == "" {
+		secret = consts.JWT_SECRET_KEY
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "upload-ticket\n%s\n%d", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encodeUploadTicket tạo chuỗi vé dạng {id}.{expires}.{signature}
+func encodeUploadTicket(ticket *model.UploadTicket) string {
+	exp := ticket.ExpiresAt.Unix()
+	return fmt.Sprintf("%s.%d.%s", ticket.ID, exp, signUploadTicket(ticket.ID, exp))
+}
+
+// parseUploadTicket kiểm tra chữ ký và hạn của vé, trả về ID để đánh dấu đã dùng
+func parseUploadTicket(raw string, now time.Time) (uuid.UUID, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, errInvalidUploadTicket
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errInvalidUploadTicket
+	}
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() > exp {
+		return uuid.Nil, errInvalidUploadTicket
+	}
+	if !hmac.Equal([]byte(signUploadTicket(id, exp)), []byte(parts[2])) {
+		return uuid.Nil, errInvalidUploadTicket
+	}
+	return id, nil
+}
+
+// CreateUploadTicket cấp vé upload dùng một lần cho khách (form tư vấn đính kèm file)
+func (h *S3Handler) CreateUploadTicket(c *gin.Context) {
+	now := time.Now()
+	ticket := &model.UploadTicket{
+		IPAddress: c.ClientIP(),
+		ExpiresAt: now.Add(uploadTicketTTL()),
+	}
+	if err := h.uploadRepo.CreateTicket(ticket); err != nil {
+		helpers.ErrorResponse(c, helpers.ErrUploadTicketFailed, err)
+		return
+	}
+	// Dọn vé đã hết hạn từ hôm trước
+	if err := h.uploadRepo.DeleteExpiredTickets(now.Add(-24 * time.Hour)); err != nil {
+		logrus.Warn("Failed to clean up expired upload tickets: ", err)
+	}
+
+	folders := make([]string, 0, len(publicUploadFolders))
+	for _, folder := range mediaFolders {
+		if publicUploadFolders[folder] {
+			folders = append(folders, folder)
+		}
+	}
+	helpers.SuccessResponse(c, "Tạo vé upload thành công", gin.H{
+		"ticket":     encodeUploadTicket(ticket),
+		"expires_at": ticket.ExpiresAt,
+		"max_size":   envInt64("UPLOAD_PUBLIC_MAX_BYTES", defaultPublicUploadMaxBytes),
+		"folders":    folders,
+	})
+}
+
+// uploadQuotaFor lấy quota của người dùng đang đăng nhập (super admin không giới hạn, 0 = không giới hạn)
+func uploadQuotaFor(c *gin.Context) int64 {
+	if c.GetString("user_role") == "super_admin" {
+		return 0
+	}
+	return userUploadQuota()
+}
+
+// reserveUpload ghi sổ upload sau khi xét quota (người dùng: quota riêng; khách: tổng dung lượng trong 24 giờ).
+// Việc xét và ghi sổ là một transaction nên request đồng thời không cùng vượt quota. Đã trả lỗi về client khi trả false
+func (h *S3Handler) reserveUpload(c *gin.Context, upload *model.Upload) bool {
+	limit := envInt64("UPLOAD_PUBLIC_DAILY_BYTES", defaultPublicDailyBytes)
+	if upload.UploadedByID != nil {
+		limit = uploadQuotaFor(c)
+	}
+	used, reserved, err := h.uploadRepo.Reserve(upload, limit, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrUploadQuotaFailed, err)
+		return false
+	}
+	if !reserved {
+		respondQuotaExceeded(c, upload.UploadedByID == nil, used, limit)
+		return false
+	}
+	return true
+}
+
+// respondQuotaExceeded trả lỗi hết quota (khách: hết dung lượng upload công khai trong ngày)
+func respondQuotaExceeded(c *gin.Context, guest bool, used, limit int64) {
+	if guest {
+		logrus.Warnf("Public upload budget exhausted: %d/%d bytes in 24h", used, limit)
+		helpers.ErrorResponse(c, helpers.ErrPublicUploadBudget, nil)
+		return
+	}
+	helpers.ErrorResponseWithData(c, helpers.ErrUploadQuotaExceeded, nil, gin.H{
+		"used_bytes":  used,
+		"quota_bytes": limit,
+	})
+}
+
+// GetUploadQuota lấy dung lượng đã dùng và quota của người dùng hiện tại
+func (h *S3Handler) GetUploadQuota(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	usage, err := h.uploadRepo.GetUsage(userID.(uuid.UUID))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrUploadQuotaFailed, err)
+		return
+	}
+
+	quota := uploadQuotaFor(c)
+	var remaining *int64
+	if quota > 0 {
+		left := quota - usage.Bytes
+		if left < 0 {
+			left = 0
+		}
+		remaining = &left
+	}
+
+	limits := gin.H{}
+	for folder := range uploadFolderLimits {
+		limits[folder] = uploadMaxSize(folder)
+	}
+	helpers.SuccessResponse(c, "Lấy dung lượng đã dùng thành công", gin.H{
+		"files":           usage.Files,
+		"used_bytes":      usage.Bytes,
+		"quota_bytes":     quota, // 0 = không giới hạn
+		"remaining_bytes": remaining,
+		"max_file_size":   limits,
+	})
+}
@@ -0,0 +1,76 @@
+package handle
+
+import (
+	"backend/internal/model"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseUploadTicket(t *testing.T) {
+	t.Setenv("UPLOAD_TICKET_SECRET", "test-secret")
+
+	now := time.Unix(1700000000, 0)
+	ticket := &model.UploadTicket{ID: uuid.New(), ExpiresAt: now.Add(30 * time.Minute)}
+	valid := encodeUploadTicket(ticket)
+	exp := ticket.ExpiresAt.Unix()
+	signature := signUploadTicket(ticket.ID, exp)
+
+	tests := []struct {
+		name    string
+		raw     string
+		now     time.Time
+		wantErr bool
+	}{
+		{"vé hợp lệ", valid, now, false},
+		{"đúng lúc hết hạn", valid, ticket.ExpiresAt, false},
+		{"đã hết hạn", valid, ticket.ExpiresAt.Add(time.Second), true},
+		{"chuỗi rỗng", "", now, true},
+		{"thiếu phần", fmt.Sprintf("%s.%d", ticket.ID, exp), now, true},
+		{"thừa phần", valid + ".x", now, true},
+		{"ID sai định dạng", fmt.Sprintf("not-a-uuid.%d.%s", exp, signature), now, true},
+		{"hạn không phải số", fmt.Sprintf("%s.abc.%s", ticket.ID, signature), now, true},
+		{"kéo dài hạn", fmt.Sprintf("%s.%d.%s", ticket.ID, exp+3600, signature), now, true},
+		{"đổi ID", fmt.Sprintf("%s.%d.%s", uuid.New(), exp, signature), now, true},
+		{"sai chữ ký", fmt.Sprintf("%s.%d.%s", ticket.ID, exp, strings.Repeat("0", len(signature))), now, true},
+		{"chữ ký viết hoa", fmt.Sprintf("%s.%d.%s", ticket.ID, exp, strings.ToUpper(signature)), now, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := parseUploadTicket(tt.raw, tt.now)
+			if tt.wantErr {
+				if err != errInvalidUploadTicket || id != uuid.Nil {
+					t.Errorf("parseUploadTicket() = %v, %v, want lỗi vé không hợp lệ", id, err)
+				}
+				return
+			}
+			if err != nil || id != ticket.ID {
+				t.Errorf("parseUploadTicket() = %v, %v, want %v", id, err, ticket.ID)
+			}
+		})
+	}
+}
+
+func TestSignUploadTicketSecret(t *testing.T) {
+	id := uuid.New()
+	exp := time.Now().Add(time.Hour).Unix()
+
+	t.Setenv("UPLOAD_TICKET_SECRET", "secret-a")
+	signedA := signUploadTicket(id, exp)
+	raw := fmt.Sprintf("%s.%d.%s", id, exp, signedA)
+	if _, err := parseUploadTicket(raw, time.Now()); err != nil {
+		t.Fatalf("parseUploadTicket() với cùng khóa = %v, want nil", err)
+	}
+
+	t.Setenv("UPLOAD_TICKET_SECRET", "secret-b")
+	if signUploadTicket(id, exp) == signedA {
+		t.Error("signUploadTicket() không đổi khi đổi UPLOAD_TICKET_SECRET")
+	}
+	if _, err := parseUploadTicket(raw, time.Now()); err == nil {
+		t.Error("parseUploadTicket() chấp nhận vé ký bằng khóa cũ")
+	}
+}
@@ -0,0 +1,157 @@
+package handle
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// viewRangeMaxDays giới hạn khoảng thời gian truy vấn lượt xem
+const viewRangeMaxDays = 366
+
+// ViewAnalyticsHandler thống kê lượt xem bài viết từ bảng article_view_daily
+type ViewAnalyticsHandler struct {
+	viewRepo    *repo.ArticleViewRepo
+	articleRepo *repo.ArticleRepo
+}
+
+func NewViewAnalyticsHandler() *ViewAnalyticsHandler {
+	return &ViewAnalyticsHandler{
+		viewRepo:    repo.NewArticleViewRepo(),
+		articleRepo: repo.NewArticleRepo(),
+	}
+}
+
+// parseDateRange đọc from/to (YYYY-MM-DD), mặc định 30 ngày gần nhất
+func parseDateRange(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	to := today
+	from := today.AddDate(0, 0, -29)
+
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return from, to, err
+		}
+		to = parsed
+	}
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return from, to, err
+		}
+		from = parsed
+	} else {
+		from = to.AddDate(0, 0, -29)
+	}
+
+	if from.After(to) {
+		return from, to, errors.New("from phải trước hoặc bằng to")
+	}
+	if to.Sub(from) > viewRangeMaxDays*24*time.Hour {
+		return from, to, errors.New("khoảng thời gian tối đa 366 ngày")
+	}
+	return from, to, nil
+}
+
+// fillViewPoints bổ sung các ngày không có lượt xem để biểu đồ liên tục
+func fillViewPoints(from, to time.Time, views map[string]int64) ([]model.ArticleViewPoint, int64) {
+	points := []model.ArticleViewPoint{}
+	var total int64
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		points = append(points, model.ArticleViewPoint{Date: key, Views: views[key]})
+		total += views[key]
+	}
+	return points, total
+}
+
+// GetArticleViews lấy lượt xem theo ngày của một bài viết (admin)
+// Query: from, to (YYYY-MM-DD)
+func (h *ViewAnalyticsHandler) GetArticleViews(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidArticleID, err)
+		return
+	}
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	article, err := h.articleRepo.GetByID(id)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrArticleNotFound, err)
+		return
+	}
+
+	rows, err := h.viewRepo.GetDaily(id, from, to)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	views := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		views[row.ViewDate.Format("2006-01-02")] = row.Views
+	}
+	daily, total := fillViewPoints(from, to, views)
+
+	helpers.SuccessResponse(c, "Lấy lượt xem bài viết thành công", model.ArticleViewStats{
+		ArticleID: article.ID,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Views:     total,
+		ViewCount: article.ViewCount,
+		Daily:     daily,
+	})
+}
+
+// GetViewOverview lấy tổng lượt xem toàn trang theo ngày và các bài được xem nhiều nhất (admin)
+// Query: from, to (YYYY-MM-DD), limit (số bài top, mặc định 10)
+func (h *ViewAnalyticsHandler) GetViewOverview(c *gin.Context) {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrInvalidDateRange, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	points, err := h.viewRepo.GetDailyTotals(from, to)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+	views := make(map[string]int64, len(points))
+	for _, p := range points {
+		views[p.Date] = p.Views
+	}
+	daily, total := fillViewPoints(from, to, views)
+
+	top, err := h.viewRepo.GetTopArticles(from, to, limit)
+	if err != nil {
+		helpers.ErrorResponse(c, helpers.ErrAnalyticsFailed, err)
+		return
+	}
+
+	helpers.SuccessResponse(c, "Lấy thống kê lượt xem thành công", map[string]interface{}{
+		"from":         from.Format("2006-01-02"),
+		"to":           to.Format("2006-01-02"),
+		"views":        total,
+		"daily":        daily,
+		"top_articles": top,
+	})
+}
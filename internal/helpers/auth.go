@@ -9,6 +9,14 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Vai trò được vào khu quản trị /api/admin/manage (khớp utils.AdminMiddleware)
+var staffRoles = map[string]bool{"super_admin": true, consts.ROLE_ADMIN: true}
+
+// IsStaffRole kiểm tra vai trò là quản trị (super admin, admin). Tài khoản tự đăng ký (role user) không phải quản trị
+func IsStaffRole(role string) bool {
+	return staffRoles[role]
+}
+
 // HashPassword hashes a password using bcrypt
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
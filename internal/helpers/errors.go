@@ -0,0 +1,335 @@
+package helpers
+
+import (
+	"backend/internal/consts"
+	"net/http"
+)
+
+// ErrorCode - mã lỗi ổn định để frontend rẽ nhánh (không phụ thuộc vào nội dung thông điệp)
+type ErrorCode string
+
+// APIError - một lỗi trong catalog: mã lỗi, HTTP status và thông điệp theo từng ngôn ngữ
+type APIError struct {
+	Code     ErrorCode
+	Status   int
+	Messages map[string]string // locale -> thông điệp
+}
+
+func newAPIError(code ErrorCode, status int, vi, en string) *APIError {
+	return &APIError{
+		Code:   code,
+		Status: status,
+		Messages: map[string]string{
+			consts.LocaleVI: vi,
+			consts.LocaleEN: en,
+		},
+	}
+}
+
+// Message trả về thông điệp theo ngôn ngữ, fallback về ngôn ngữ mặc định
+func (e *APIError) Message(locale string) string {
+	if msg, ok := e.Messages[locale]; ok {
+		return msg
+	}
+	return e.Messages[consts.DefaultLocale]
+}
+
+func (e *APIError) Error() string {
+	return string(e.Code)
+}
+
+// Lỗi chung
+var (
+	ErrInternal              = newAPIError("INTERNAL_ERROR", http.StatusInternalServerError, "Lỗi hệ thống", "Internal server error")
+	ErrDatabase              = newAPIError("DATABASE_ERROR", http.StatusInternalServerError, "Lỗi cơ sở dữ liệu", "Database error")
+	ErrValidation            = newAPIError("VALIDATION_ERROR", http.StatusBadRequest, "Dữ liệu đầu vào không hợp lệ", "Invalid input data")
+	ErrInvalidJSON           = newAPIError("INVALID_JSON", http.StatusBadRequest, "Dữ liệu JSON không đúng định dạng", "Malformed JSON body")
+	ErrInvalidID             = newAPIError("INVALID_ID", http.StatusBadRequest, "ID không hợp lệ", "Invalid ID")
+	ErrInvalidSlug           = newAPIError("INVALID_SLUG", http.StatusBadRequest, "Slug không hợp lệ", "Invalid slug")
+	ErrSlugExists            = newAPIError("SLUG_EXISTS", http.StatusConflict, "Slug đã tồn tại", "Slug already exists")
+	ErrInvalidDateRange      = newAPIError("INVALID_DATE_RANGE", http.StatusBadRequest, "Khoảng thời gian không hợp lệ", "Invalid date range")
+	ErrInvalidLocale         = newAPIError("INVALID_LOCALE", http.StatusBadRequest, "Ngôn ngữ không hợp lệ", "Unsupported locale")
+	ErrTranslationExists     = newAPIError("TRANSLATION_EXISTS", http.StatusConflict, "Bản dịch cho ngôn ngữ này đã tồn tại", "A translation for this locale already exists")
+	ErrInvalidTranslationOf  = newAPIError("INVALID_TRANSLATION_SOURCE", http.StatusBadRequest, "Bản gốc của bản dịch không hợp lệ", "Invalid translation source")
+	ErrSearchKeywordRequired = newAPIError("SEARCH_KEYWORD_REQUIRED", http.StatusBadRequest, "Từ khóa tìm kiếm là bắt buộc", "Search keyword is required")
+	ErrTooManyRequests       = newAPIError("TOO_MANY_REQUESTS", http.StatusTooManyRequests, "Bạn gửi quá nhiều yêu cầu, vui lòng thử lại sau", "Too many requests, please try again later")
+)
+
+// Xác thực và phân quyền
+var (
+	ErrUnauthorized         = newAPIError("UNAUTHORIZED", http.StatusUnauthorized, "Chưa xác thực", "Authentication required")
+	ErrForbidden            = newAPIError("FORBIDDEN", http.StatusForbidden, "Không đủ quyền", "Permission denied")
+	ErrAdminRequired        = newAPIError("ADMIN_REQUIRED", http.StatusForbidden, "Yêu cầu quyền Admin", "Admin role required")
+	ErrSuperAdminRequired   = newAPIError("SUPER_ADMIN_REQUIRED", http.StatusForbidden, "Yêu cầu quyền Super Admin", "Super Admin role required")
+	ErrInvalidCredentials   = newAPIError("INVALID_CREDENTIALS", http.StatusBadRequest, "Thông tin đăng nhập không chính xác", "Invalid credentials")
+	ErrAccountDisabled      = newAPIError("ACCOUNT_DISABLED", http.StatusUnauthorized, "Tài khoản đã bị vô hiệu hóa", "Account is disabled")
+	ErrPasswordHashFailed   = newAPIError("PASSWORD_HASH_FAILED", http.StatusInternalServerError, "Không thể mã hóa mật khẩu", "Could not hash password")
+	ErrCurrentPasswordEmpty = newAPIError("CURRENT_PASSWORD_REQUIRED", http.StatusBadRequest, "Cần cung cấp mật khẩu hiện tại", "Current password is required")
+	ErrCurrentPasswordWrong = newAPIError("CURRENT_PASSWORD_INCORRECT", http.StatusBadRequest, "Mật khẩu hiện tại không đúng", "Current password is incorrect")
+	ErrPasswordMismatch     = newAPIError("PASSWORD_CONFIRMATION_MISMATCH", http.StatusBadRequest, "Mật khẩu mới và xác nhận không khớp", "New password and confirmation do not match")
+)
+
+// Người dùng
+var (
+	ErrUserNotFound           = newAPIError("USER_NOT_FOUND", http.StatusNotFound, "Không tìm thấy người dùng", "User not found")
+	ErrInvalidUserID          = newAPIError("INVALID_USER_ID", http.StatusBadRequest, "ID người dùng không hợp lệ", "Invalid user ID")
+	ErrEmailExists            = newAPIError("EMAIL_EXISTS", http.StatusConflict, "Email đã tồn tại", "Email already exists")
+	ErrUsernameExists         = newAPIError("USERNAME_EXISTS", http.StatusConflict, "Tên người dùng đã tồn tại", "Username already exists")
+	ErrSuperAdminExists       = newAPIError("SUPER_ADMIN_EXISTS", http.StatusConflict, "Chỉ được phép có một tài khoản Super Admin", "Only one Super Admin account is allowed")
+	ErrSuperAdminOnlyEdit     = newAPIError("USER_EDIT_SUPER_ADMIN_ONLY", http.StatusForbidden, "Chỉ Super Admin mới được sửa thông tin người dùng", "Only Super Admin can edit user information")
+	ErrCannotAssignSuperAdmin = newAPIError("CANNOT_ASSIGN_SUPER_ADMIN", http.StatusForbidden, "Không thể gán vai trò Super Admin", "The Super Admin role cannot be assigned")
+	ErrCannotManageUser       = newAPIError("CANNOT_MANAGE_USER", http.StatusForbidden, "Không thể quản lý người dùng này", "You cannot manage this user")
+	ErrUserListFailed         = newAPIError("USER_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách người dùng", "Could not load users")
+	ErrUserCreateFailed       = newAPIError("USER_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo người dùng", "Could not create user")
+	ErrUserRoleUpdateFailed   = newAPIError("USER_ROLE_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật vai trò người dùng", "Could not update user role")
+	ErrUserReloadFailed       = newAPIError("USER_RELOAD_FAILED", http.StatusInternalServerError, "Không thể lấy thông tin người dùng đã cập nhật", "Could not load the updated user")
+	ErrUserStatsFailed        = newAPIError("USER_STATS_FAILED", http.StatusInternalServerError, "Không thể lấy thống kê người dùng", "Could not load user statistics")
+)
+
+// Bài viết
+var (
+	ErrArticleNotFound          = newAPIError("ARTICLE_NOT_FOUND", http.StatusNotFound, "Không tìm thấy bài viết", "Article not found")
+	ErrInvalidArticleID         = newAPIError("INVALID_ARTICLE_ID", http.StatusBadRequest, "ID bài viết không hợp lệ", "Invalid article ID")
+	ErrInvalidArticleStatus     = newAPIError("INVALID_ARTICLE_STATUS", http.StatusBadRequest, "Status không hợp lệ", "Invalid article status")
+	ErrInvalidTagList           = newAPIError("INVALID_TAG_LIST", http.StatusBadRequest, "Danh sách tag không hợp lệ", "Invalid tag list")
+	ErrArticleFetchFailed       = newAPIError("ARTICLE_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết", "Could not load article")
+	ErrArticleListFailed        = newAPIError("ARTICLE_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách bài viết", "Could not load articles")
+	ErrFeaturedArticlesFailed   = newAPIError("FEATURED_ARTICLES_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết nổi bật", "Could not load featured articles")
+	ErrCategoryArticlesFailed   = newAPIError("CATEGORY_ARTICLES_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết theo danh mục", "Could not load articles for this category")
+	ErrTagArticlesFailed        = newAPIError("TAG_ARTICLES_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết theo tag", "Could not load articles for this tag")
+	ErrArticleSearchFailed      = newAPIError("ARTICLE_SEARCH_FAILED", http.StatusInternalServerError, "Không thể tìm kiếm bài viết", "Could not search articles")
+	ErrArticleCreateFailed      = newAPIError("ARTICLE_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo bài viết", "Could not create article")
+	ErrArticleUpdateFailed      = newAPIError("ARTICLE_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật bài viết", "Could not update article")
+	ErrArticleDeleteFailed      = newAPIError("ARTICLE_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa bài viết", "Could not delete article")
+	ErrArticleReloadFailed      = newAPIError("ARTICLE_RELOAD_FAILED", http.StatusInternalServerError, "Không thể tải lại bài viết", "Could not reload article")
+	ErrRelatedFetchFailed       = newAPIError("RELATED_ARTICLES_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết liên quan", "Could not load related articles")
+	ErrRelatedUpdateFailed      = newAPIError("RELATED_ARTICLES_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật bài viết liên quan", "Could not update related articles")
+	ErrInvalidRelatedArticle    = newAPIError("INVALID_RELATED_ARTICLE", http.StatusBadRequest, "Bài viết liên quan không hợp lệ", "Invalid related article")
+	ErrRelatedSelfReference     = newAPIError("RELATED_SELF_REFERENCE", http.StatusBadRequest, "Không thể ghim chính bài viết này", "An article cannot be pinned as related to itself")
+	ErrInvalidCoAuthor          = newAPIError("INVALID_CO_AUTHOR", http.StatusBadRequest, "Đồng tác giả không hợp lệ", "Invalid co-author")
+	ErrInvalidReviewer          = newAPIError("INVALID_REVIEWER", http.StatusBadRequest, "Người thẩm định không hợp lệ", "Invalid reviewer")
+	ErrInvalidArticleAuthor     = newAPIError("INVALID_ARTICLE_AUTHOR", http.StatusBadRequest, "Tác giả chính không hợp lệ", "Invalid primary author")
+	ErrArticleAttributionFailed = newAPIError("ARTICLE_ATTRIBUTION_FAILED", http.StatusInternalServerError, "Không thể cập nhật tác giả bài viết", "Could not update article attribution")
+)
+
+// Chuỗi bài viết
+var (
+	ErrSeriesNotFound             = newAPIError("SERIES_NOT_FOUND", http.StatusNotFound, "Không tìm thấy chuỗi bài viết", "Series not found")
+	ErrInvalidSeriesID            = newAPIError("INVALID_SERIES_ID", http.StatusBadRequest, "ID chuỗi bài viết không hợp lệ", "Invalid series ID")
+	ErrSeriesFetchFailed          = newAPIError("SERIES_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy chuỗi bài viết", "Could not load series")
+	ErrSeriesListFailed           = newAPIError("SERIES_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách chuỗi bài viết", "Could not load series list")
+	ErrSeriesCreateFailed         = newAPIError("SERIES_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo chuỗi bài viết", "Could not create series")
+	ErrSeriesUpdateFailed         = newAPIError("SERIES_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật chuỗi bài viết", "Could not update series")
+	ErrSeriesDeleteFailed         = newAPIError("SERIES_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa chuỗi bài viết", "Could not delete series")
+	ErrSeriesReloadFailed         = newAPIError("SERIES_RELOAD_FAILED", http.StatusInternalServerError, "Không thể tải lại chuỗi bài viết", "Could not reload series")
+	ErrSeriesArticlesUpdateFailed = newAPIError("SERIES_ARTICLES_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật bài viết của chuỗi", "Could not update series articles")
+	ErrSeriesDuplicateArticle     = newAPIError("SERIES_DUPLICATE_ARTICLE", http.StatusBadRequest, "Danh sách bài viết bị trùng lặp", "Article list contains duplicates")
+	ErrSeriesInvalidArticle       = newAPIError("SERIES_INVALID_ARTICLE", http.StatusBadRequest, "Bài viết không hợp lệ", "Invalid article in series")
+	ErrSeriesArticleConflict      = newAPIError("SERIES_ARTICLE_CONFLICT", http.StatusConflict, "Bài viết đã thuộc một chuỗi khác", "Article already belongs to another series")
+)
+
+// Danh mục
+var (
+	ErrCategoryNotFound      = newAPIError("CATEGORY_NOT_FOUND", http.StatusNotFound, "Không tìm thấy danh mục", "Category not found")
+	ErrInvalidCategoryID     = newAPIError("INVALID_CATEGORY_ID", http.StatusBadRequest, "ID danh mục không hợp lệ", "Invalid category ID")
+	ErrInvalidCategory       = newAPIError("INVALID_CATEGORY", http.StatusBadRequest, "Danh mục không hợp lệ", "Invalid category")
+	ErrInvalidParentCategory = newAPIError("INVALID_PARENT_CATEGORY", http.StatusBadRequest, "Danh mục cha không hợp lệ", "Invalid parent category")
+	ErrCategoryFetchFailed   = newAPIError("CATEGORY_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy danh mục", "Could not load categories")
+	ErrCategoryListFailed    = newAPIError("CATEGORY_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách danh mục", "Could not load category list")
+	ErrHomeCategoriesFailed  = newAPIError("HOME_CATEGORIES_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách danh mục cho trang chủ", "Could not load homepage categories")
+	ErrCategoryTreeFailed    = newAPIError("CATEGORY_TREE_FAILED", http.StatusInternalServerError, "Không thể lấy cây danh mục", "Could not load category tree")
+	ErrCategoryCreateFailed  = newAPIError("CATEGORY_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo danh mục", "Could not create category")
+	ErrCategoryUpdateFailed  = newAPIError("CATEGORY_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật danh mục", "Could not update category")
+	ErrCategoryDeleteFailed  = newAPIError("CATEGORY_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa danh mục", "Could not delete category")
+)
+
+// Tag
+var (
+	ErrTagNotFound       = newAPIError("TAG_NOT_FOUND", http.StatusNotFound, "Không tìm thấy tag", "Tag not found")
+	ErrInvalidTagID      = newAPIError("INVALID_TAG_ID", http.StatusBadRequest, "ID tag không hợp lệ", "Invalid tag ID")
+	ErrTagFetchFailed    = newAPIError("TAG_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy tag", "Could not load tag")
+	ErrTagListFailed     = newAPIError("TAG_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách tags", "Could not load tags")
+	ErrPopularTagsFailed = newAPIError("POPULAR_TAGS_FAILED", http.StatusInternalServerError, "Không thể lấy tags phổ biến", "Could not load popular tags")
+	ErrTagSearchFailed   = newAPIError("TAG_SEARCH_FAILED", http.StatusInternalServerError, "Không thể tìm kiếm tags", "Could not search tags")
+	ErrTagCreateFailed   = newAPIError("TAG_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo tag", "Could not create tag")
+	ErrTagUpdateFailed   = newAPIError("TAG_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật tag", "Could not update tag")
+	ErrTagDeleteFailed   = newAPIError("TAG_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa tag", "Could not delete tag")
+)
+
+// Homepage sections
+var (
+	ErrSectionNotFound     = newAPIError("SECTION_NOT_FOUND", http.StatusNotFound, "Không tìm thấy section", "Section not found")
+	ErrSectionHidden       = newAPIError("SECTION_HIDDEN", http.StatusNotFound, "Section không được hiển thị", "Section is hidden")
+	ErrTypeKeyExists       = newAPIError("TYPE_KEY_EXISTS", http.StatusConflict, "TypeKey đã tồn tại", "Type key already exists")
+	ErrUnknownSectionType  = newAPIError("UNKNOWN_SECTION_TYPE", http.StatusBadRequest, "Loại section không tồn tại", "Unknown section type")
+	ErrSectionListFailed   = newAPIError("SECTION_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách sections", "Could not load sections")
+	ErrSectionCreateFailed = newAPIError("SECTION_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo section", "Could not create section")
+	ErrSectionUpdateFailed = newAPIError("SECTION_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật section", "Could not update section")
+	ErrSectionDeleteFailed = newAPIError("SECTION_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa section", "Could not delete section")
+)
+
+// Hồ sơ tác giả
+var (
+	ErrAuthorNotFound       = newAPIError("AUTHOR_NOT_FOUND", http.StatusNotFound, "Không tìm thấy tác giả", "Author not found")
+	ErrInvalidAuthorID      = newAPIError("INVALID_AUTHOR_ID", http.StatusBadRequest, "ID tác giả không hợp lệ", "Invalid author ID")
+	ErrAuthorUserLinked     = newAPIError("AUTHOR_USER_LINKED", http.StatusConflict, "Người dùng đã được gắn với hồ sơ tác giả khác", "User is already linked to another author profile")
+	ErrInvalidPracticeArea  = newAPIError("INVALID_PRACTICE_AREA", http.StatusBadRequest, "Lĩnh vực hành nghề không hợp lệ", "Invalid practice area")
+	ErrAuthorFetchFailed    = newAPIError("AUTHOR_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy thông tin tác giả", "Could not load author")
+	ErrAuthorListFailed     = newAPIError("AUTHOR_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách tác giả", "Could not load authors")
+	ErrAuthorArticlesFailed = newAPIError("AUTHOR_ARTICLES_FAILED", http.StatusInternalServerError, "Không thể lấy bài viết của tác giả", "Could not load articles for this author")
+	ErrAuthorCreateFailed   = newAPIError("AUTHOR_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo hồ sơ tác giả", "Could not create author profile")
+	ErrAuthorUpdateFailed   = newAPIError("AUTHOR_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật hồ sơ tác giả", "Could not update author profile")
+	ErrAuthorDeleteFailed   = newAPIError("AUTHOR_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa hồ sơ tác giả", "Could not delete author profile")
+)
+
+// Bình luận
+var (
+	ErrCommentNotFound      = newAPIError("COMMENT_NOT_FOUND", http.StatusNotFound, "Không tìm thấy bình luận", "Comment not found")
+	ErrInvalidCommentID     = newAPIError("INVALID_COMMENT_ID", http.StatusBadRequest, "ID bình luận không hợp lệ", "Invalid comment ID")
+	ErrInvalidCommentParent = newAPIError("INVALID_COMMENT_PARENT", http.StatusBadRequest, "Bình luận được trả lời không hợp lệ", "Invalid parent comment")
+	ErrInvalidCommentStatus = newAPIError("INVALID_COMMENT_STATUS", http.StatusBadRequest, "Trạng thái bình luận không hợp lệ", "Invalid comment status")
+	ErrCommentCreateFailed  = newAPIError("COMMENT_CREATE_FAILED", http.StatusInternalServerError, "Không thể gửi bình luận", "Could not submit comment")
+	ErrCommentListFailed    = newAPIError("COMMENT_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách bình luận", "Could not load comments")
+	ErrCommentUpdateFailed  = newAPIError("COMMENT_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật bình luận", "Could not update comment")
+	ErrCommentDeleteFailed  = newAPIError("COMMENT_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa bình luận", "Could not delete comment")
+)
+
+// Rà soát pháp lý
+var (
+	ErrInvalidReviewDate     = newAPIError("INVALID_REVIEW_DATE", http.StatusBadRequest, "Hạn rà soát tiếp theo phải ở tương lai", "Next review date must be in the future")
+	ErrLegalReviewFailed     = newAPIError("LEGAL_REVIEW_FAILED", http.StatusInternalServerError, "Không thể cập nhật rà soát pháp lý", "Could not update legal review")
+	ErrLegalReviewListFailed = newAPIError("LEGAL_REVIEW_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách bài viết cần rà soát", "Could not load review queue")
+)
+
+// Văn bản pháp luật
+var (
+	ErrLegalDocumentNotFound     = newAPIError("LEGAL_DOCUMENT_NOT_FOUND", http.StatusNotFound, "Không tìm thấy văn bản", "Legal document not found")
+	ErrInvalidLegalDocumentID    = newAPIError("INVALID_LEGAL_DOCUMENT_ID", http.StatusBadRequest, "ID văn bản không hợp lệ", "Invalid legal document ID")
+	ErrInvalidLegalDocument      = newAPIError("INVALID_LEGAL_DOCUMENT", http.StatusBadRequest, "Văn bản được trích dẫn không hợp lệ", "Invalid cited legal document")
+	ErrInvalidLegalDocumentDates = newAPIError("INVALID_LEGAL_DOCUMENT_DATES", http.StatusBadRequest, "Ngày hiệu lực của văn bản không hợp lệ", "Invalid legal document dates")
+	ErrInvalidLegalDocumentQuery = newAPIError("INVALID_LEGAL_DOCUMENT_QUERY", http.StatusBadRequest, "Bộ lọc văn bản không hợp lệ", "Invalid legal document filter")
+	ErrLegalDocumentFetchFailed  = newAPIError("LEGAL_DOCUMENT_FETCH_FAILED", http.StatusInternalServerError, "Không thể lấy thông tin văn bản", "Could not load legal document")
+	ErrLegalDocumentListFailed   = newAPIError("LEGAL_DOCUMENT_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách văn bản", "Could not load legal documents")
+	ErrLegalDocumentCreateFailed = newAPIError("LEGAL_DOCUMENT_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo văn bản", "Could not create legal document")
+	ErrLegalDocumentUpdateFailed = newAPIError("LEGAL_DOCUMENT_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật văn bản", "Could not update legal document")
+	ErrLegalDocumentDeleteFailed = newAPIError("LEGAL_DOCUMENT_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa văn bản", "Could not delete legal document")
+)
+
+// Câu hỏi thường gặp (FAQ)
+var (
+	ErrFAQNotFound     = newAPIError("FAQ_NOT_FOUND", http.StatusNotFound, "Không tìm thấy câu hỏi", "FAQ not found")
+	ErrInvalidFAQID    = newAPIError("INVALID_FAQ_ID", http.StatusBadRequest, "ID câu hỏi không hợp lệ", "Invalid FAQ ID")
+	ErrInvalidFAQ      = newAPIError("INVALID_FAQ", http.StatusBadRequest, "Câu hỏi không hợp lệ", "Invalid FAQ")
+	ErrInvalidFAQQuery = newAPIError("INVALID_FAQ_QUERY", http.StatusBadRequest, "Bộ lọc câu hỏi không hợp lệ", "Invalid FAQ filter")
+	ErrFAQListFailed   = newAPIError("FAQ_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách câu hỏi", "Could not load FAQs")
+	ErrFAQCreateFailed = newAPIError("FAQ_CREATE_FAILED", http.StatusInternalServerError, "Không thể tạo câu hỏi", "Could not create FAQ")
+	ErrFAQUpdateFailed = newAPIError("FAQ_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật câu hỏi", "Could not update FAQ")
+	ErrFAQDeleteFailed = newAPIError("FAQ_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa câu hỏi", "Could not delete FAQ")
+)
+
+// Bản tin (newsletter)
+var (
+	ErrInvalidNewsletterToken    = newAPIError("INVALID_NEWSLETTER_TOKEN", http.StatusBadRequest, "Link không hợp lệ hoặc đã hết hạn", "Invalid or expired link")
+	ErrSubscriberNotFound        = newAPIError("SUBSCRIBER_NOT_FOUND", http.StatusNotFound, "Không tìm thấy người đăng ký", "Subscriber not found")
+	ErrInvalidSubscriberID       = newAPIError("INVALID_SUBSCRIBER_ID", http.StatusBadRequest, "ID người đăng ký không hợp lệ", "Invalid subscriber ID")
+	ErrInvalidSubscriberQuery    = newAPIError("INVALID_SUBSCRIBER_QUERY", http.StatusBadRequest, "Bộ lọc người đăng ký không hợp lệ", "Invalid subscriber filter")
+	ErrInvalidNewsletterTopic    = newAPIError("INVALID_NEWSLETTER_TOPIC", http.StatusBadRequest, "Chủ đề đăng ký không hợp lệ", "Invalid newsletter topic")
+	ErrNewsletterSubscribeFailed = newAPIError("NEWSLETTER_SUBSCRIBE_FAILED", http.StatusInternalServerError, "Không thể đăng ký nhận bản tin", "Could not subscribe to newsletter")
+	ErrNewsletterUpdateFailed    = newAPIError("NEWSLETTER_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật đăng ký", "Could not update subscription")
+	ErrSubscriberListFailed      = newAPIError("SUBSCRIBER_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách người đăng ký", "Could not load subscribers")
+	ErrNewsletterDigestFailed    = newAPIError("NEWSLETTER_DIGEST_FAILED", http.StatusInternalServerError, "Không thể soạn hoặc gửi bản tin", "Could not compose or send newsletter")
+	ErrNewsletterDigestRunning   = newAPIError("NEWSLETTER_DIGEST_RUNNING", http.StatusConflict, "Bản tin đang được gửi", "Newsletter is already being sent")
+)
+
+// Thư viện media
+var (
+	ErrMediaNotFound         = newAPIError("MEDIA_NOT_FOUND", http.StatusNotFound, "Không tìm thấy file", "Media file not found")
+	ErrInvalidMediaID        = newAPIError("INVALID_MEDIA_ID", http.StatusBadRequest, "ID file không hợp lệ", "Invalid media ID")
+	ErrInvalidMediaKey       = newAPIError("INVALID_MEDIA_KEY", http.StatusBadRequest, "Key file không hợp lệ", "Invalid media key")
+	ErrMediaObjectMissing    = newAPIError("MEDIA_OBJECT_MISSING", http.StatusBadRequest, "File chưa được upload lên storage", "File has not been uploaded to storage")
+	ErrInvalidMediaQuery     = newAPIError("INVALID_MEDIA_QUERY", http.StatusBadRequest, "Bộ lọc thư viện media không hợp lệ", "Invalid media filter")
+	ErrMediaListFailed       = newAPIError("MEDIA_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách file", "Could not load media files")
+	ErrMediaSaveFailed       = newAPIError("MEDIA_SAVE_FAILED", http.StatusInternalServerError, "Không thể lưu thông tin file", "Could not save media file")
+	ErrMediaInUse            = newAPIError("MEDIA_IN_USE", http.StatusConflict, "File đang được sử dụng, gỡ khỏi nội dung trước hoặc xóa với force", "File is still referenced; remove the references first or delete with force")
+	ErrMediaUsageFailed      = newAPIError("MEDIA_USAGE_FAILED", http.StatusInternalServerError, "Không thể lấy thông tin sử dụng file", "Could not load media usage")
+	ErrMediaGCRunning        = newAPIError("MEDIA_GC_RUNNING", http.StatusConflict, "Đang dọn file không dùng, vui lòng thử lại sau", "Media cleanup is already running, please try again later")
+	ErrMediaNotProcessable   = newAPIError("MEDIA_NOT_PROCESSABLE", http.StatusBadRequest, "Chỉ tạo được ảnh thu nhỏ cho ảnh JPEG hoặc PNG", "Variants can only be generated for JPEG or PNG images")
+	ErrMediaProcessFailed    = newAPIError("MEDIA_PROCESS_FAILED", http.StatusInternalServerError, "Không thể xử lý ảnh", "Could not process image")
+	ErrMediaRejected         = newAPIError("MEDIA_REJECTED", http.StatusUnprocessableEntity, "File không an toàn hoặc không đúng loại khai báo, đã bị cách ly", "File is unsafe or does not match its declared type and has been quarantined")
+	ErrMediaNotClean         = newAPIError("MEDIA_NOT_CLEAN", http.StatusConflict, "File chưa được kiểm tra xong hoặc đã bị từ chối", "File has not passed scanning yet or was rejected")
+	ErrMediaScanFailed       = newAPIError("MEDIA_SCAN_FAILED", http.StatusBadGateway, "Không thể quét file, vui lòng thử lại sau", "Could not scan the file, please try again later")
+	ErrMediaGCFailed         = newAPIError("MEDIA_GC_FAILED", http.StatusInternalServerError, "Không thể dọn file không dùng", "Media cleanup failed")
+	ErrMediaAccessDenied     = newAPIError("MEDIA_ACCESS_DENIED", http.StatusForbidden, "Bạn không có quyền tải file này", "You do not have permission to download this file")
+	ErrMediaVisibilityFailed = newAPIError("MEDIA_VISIBILITY_FAILED", http.StatusInternalServerError, "Không thể đổi phạm vi truy cập file", "Could not change file visibility")
+	ErrMediaDownloadFailed   = newAPIError("MEDIA_DOWNLOAD_FAILED", http.StatusInternalServerError, "Không thể tạo link tải file", "Could not create download link")
+	ErrMediaNotPublic        = newAPIError("MEDIA_NOT_PUBLIC", http.StatusConflict, "File đang ở chế độ riêng tư", "File is private")
+	ErrAttachmentNotFound    = newAPIError("ATTACHMENT_NOT_FOUND", http.StatusNotFound, "Không tìm thấy tệp đính kèm", "Attachment not found")
+	ErrSubscribersOnly       = newAPIError("SUBSCRIBERS_ONLY", http.StatusForbidden, "Tệp chỉ dành cho người đăng ký bản tin, vui lòng yêu cầu link tải qua email", "This file is for newsletter subscribers only; request a download link by email")
+	ErrInvalidDownloadToken  = newAPIError("INVALID_DOWNLOAD_TOKEN", http.StatusForbidden, "Link tải không hợp lệ hoặc đã hết hạn", "Download link is invalid or has expired")
+)
+
+// Đánh giá bài viết
+var (
+	ErrFeedbackSaveFailed  = newAPIError("FEEDBACK_SAVE_FAILED", http.StatusInternalServerError, "Không thể ghi nhận đánh giá", "Could not save feedback")
+	ErrFeedbackStatsFailed = newAPIError("FEEDBACK_STATS_FAILED", http.StatusInternalServerError, "Không thể lấy thống kê đánh giá", "Could not load feedback statistics")
+)
+
+// Yêu cầu tư vấn
+var (
+	ErrConsultationNotFound     = newAPIError("CONSULTATION_NOT_FOUND", http.StatusNotFound, "Không tìm thấy yêu cầu tư vấn", "Consultation request not found")
+	ErrInvalidConsultationID    = newAPIError("INVALID_CONSULTATION_ID", http.StatusBadRequest, "ID yêu cầu tư vấn không hợp lệ", "Invalid consultation request ID")
+	ErrInvalidAttachment        = newAPIError("INVALID_ATTACHMENT", http.StatusBadRequest, "File đính kèm không hợp lệ", "Invalid attachment")
+	ErrInvalidAssignee          = newAPIError("INVALID_ASSIGNEE", http.StatusBadRequest, "Người được giao không hợp lệ", "Invalid assignee")
+	ErrConsultationCreateFailed = newAPIError("CONSULTATION_CREATE_FAILED", http.StatusInternalServerError, "Không thể gửi yêu cầu tư vấn", "Could not submit consultation request")
+	ErrConsultationListFailed   = newAPIError("CONSULTATION_LIST_FAILED", http.StatusInternalServerError, "Không thể lấy danh sách yêu cầu tư vấn", "Could not load consultation requests")
+	ErrConsultationUpdateFailed = newAPIError("CONSULTATION_UPDATE_FAILED", http.StatusInternalServerError, "Không thể cập nhật yêu cầu tư vấn", "Could not update consultation request")
+	ErrConsultationDeleteFailed = newAPIError("CONSULTATION_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa yêu cầu tư vấn", "Could not delete consultation request")
+	ErrConsultationNoteFailed   = newAPIError("CONSULTATION_NOTE_FAILED", http.StatusInternalServerError, "Không thể thêm ghi chú", "Could not add note")
+)
+
+// Dashboard
+var (
+	ErrDashboardFailed = newAPIError("DASHBOARD_FAILED", http.StatusInternalServerError, "Không thể lấy dữ liệu dashboard", "Could not load dashboard data")
+	ErrAnalyticsFailed = newAPIError("ANALYTICS_FAILED", http.StatusInternalServerError, "Không thể lấy dữ liệu phân tích", "Could not load analytics")
+	ErrAlertsFailed    = newAPIError("ALERTS_FAILED", http.StatusInternalServerError, "Không thể lấy cảnh báo", "Could not load alerts")
+)
+
+// Chính sách upload: loại file, dung lượng, quota và vé upload công khai
+var (
+	ErrUploadTypeNotAllowed   = newAPIError("UPLOAD_TYPE_NOT_ALLOWED", http.StatusBadRequest, "Loại file không được phép upload", "This file type is not allowed")
+	ErrUploadFolderNotAllowed = newAPIError("UPLOAD_FOLDER_NOT_ALLOWED", http.StatusForbidden, "Vé upload công khai chỉ dùng cho ảnh và tài liệu", "Public upload tickets only allow images and documents")
+	ErrUploadQuotaExceeded    = newAPIError("UPLOAD_QUOTA_EXCEEDED", http.StatusForbidden, "Bạn đã dùng hết dung lượng lưu trữ được cấp", "Your storage quota has been exceeded")
+	ErrUploadTicketRequired   = newAPIError("UPLOAD_TICKET_REQUIRED", http.StatusUnauthorized, "Cần đăng nhập hoặc có vé upload để tải file lên", "Login or an upload ticket is required to upload files")
+	ErrInvalidUploadTicket    = newAPIError("INVALID_UPLOAD_TICKET", http.StatusForbidden, "Vé upload không hợp lệ, đã hết hạn hoặc đã được dùng", "Upload ticket is invalid, expired or already used")
+	ErrUploadTicketFailed     = newAPIError("UPLOAD_TICKET_FAILED", http.StatusInternalServerError, "Không thể tạo vé upload", "Could not create upload ticket")
+	ErrUploadQuotaFailed      = newAPIError("UPLOAD_QUOTA_FAILED", http.StatusInternalServerError, "Không thể lấy dung lượng đã dùng", "Could not load storage usage")
+	ErrPublicUploadBudget     = newAPIError("PUBLIC_UPLOAD_BUDGET_EXCEEDED", http.StatusTooManyRequests, "Dung lượng upload công khai trong ngày đã hết, vui lòng thử lại sau", "The daily public upload budget has been used up, please try again later")
+)
+
+// Upload nhiều phần (video, âm thanh dung lượng lớn)
+var (
+	ErrMultipartUnsupported    = newAPIError("MULTIPART_UNSUPPORTED", http.StatusNotImplemented, "Storage hiện tại không hỗ trợ upload nhiều phần, hãy dùng /upload/s3", "The current storage does not support multipart uploads; use /upload/s3 instead")
+	ErrMultipartTypeNotAllowed = newAPIError("MULTIPART_TYPE_NOT_ALLOWED", http.StatusBadRequest, "Chỉ file video và âm thanh được upload nhiều phần", "Only video and audio files can be uploaded in parts")
+	ErrInvalidMultipartID      = newAPIError("INVALID_MULTIPART_ID", http.StatusBadRequest, "ID phiên upload không hợp lệ", "Invalid multipart upload ID")
+	ErrMultipartNotFound       = newAPIError("MULTIPART_NOT_FOUND", http.StatusNotFound, "Không tìm thấy phiên upload", "Multipart upload not found")
+	ErrMultipartNotActive      = newAPIError("MULTIPART_NOT_ACTIVE", http.StatusConflict, "Phiên upload đã hoàn tất, bị hủy hoặc hết hạn", "Multipart upload is already completed, aborted or expired")
+	ErrInvalidPartNumber       = newAPIError("INVALID_PART_NUMBER", http.StatusBadRequest, "Số thứ tự phần upload không hợp lệ", "Invalid part number")
+	ErrMultipartIncomplete     = newAPIError("MULTIPART_INCOMPLETE", http.StatusBadRequest, "Chưa upload đủ hoặc đúng kích thước các phần của file", "Some parts are missing or have the wrong size")
+	ErrMultipartFailed         = newAPIError("MULTIPART_FAILED", http.StatusBadGateway, "Không thể xử lý upload nhiều phần trên storage", "Storage could not process the multipart upload")
+)
+
+// Lưu trữ file và sitemap
+var (
+	ErrStorageUnavailable      = newAPIError("STORAGE_UNAVAILABLE", http.StatusInternalServerError, "Không thể kết nối tới storage service", "Could not connect to storage service")
+	ErrStorageError            = newAPIError("STORAGE_ERROR", http.StatusInternalServerError, "Lỗi storage service", "Storage service error")
+	ErrStorageInfoFailed       = newAPIError("STORAGE_INFO_FAILED", http.StatusInternalServerError, "Lỗi khi lấy thông tin storage", "Could not load storage information")
+	ErrUploadURLFailed         = newAPIError("UPLOAD_URL_FAILED", http.StatusInternalServerError, "Không thể tạo URL upload", "Could not create upload URL")
+	ErrViewURLFailed           = newAPIError("VIEW_URL_FAILED", http.StatusInternalServerError, "Không thể tạo URL xem file", "Could not create file view URL")
+	ErrFileDeleteFailed        = newAPIError("FILE_DELETE_FAILED", http.StatusInternalServerError, "Không thể xóa file", "Could not delete file")
+	ErrFilePathRequired        = newAPIError("FILE_PATH_REQUIRED", http.StatusBadRequest, "Đường dẫn file là bắt buộc", "File path is required")
+	ErrInvalidFilePath         = newAPIError("INVALID_FILE_PATH", http.StatusBadRequest, "Đường dẫn file không hợp lệ", "Invalid file path")
+	ErrInvalidStorageSignature = newAPIError("INVALID_STORAGE_SIGNATURE", http.StatusForbidden, "Link file không hợp lệ hoặc đã hết hạn", "File link is invalid or has expired")
+	ErrUploadTooLarge          = newAPIError("UPLOAD_TOO_LARGE", http.StatusRequestEntityTooLarge, "File vượt quá dung lượng cho phép", "File exceeds the allowed size")
+	ErrInvalidUploadForm       = newAPIError("INVALID_UPLOAD_FORM", http.StatusBadRequest, "Form upload không hợp lệ hoặc thiếu file", "Upload form is invalid or missing the file")
+	ErrSitemapFailed           = newAPIError("SITEMAP_FAILED", http.StatusInternalServerError, "Không thể tạo sitemap", "Could not build sitemap")
+)
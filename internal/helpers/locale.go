@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"backend/internal/consts"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveLocale xác định ngôn ngữ của request: ưu tiên query param `lang`,
+// sau đó header Accept-Language, cuối cùng là ngôn ngữ mặc định
+func ResolveLocale(c *gin.Context) string {
+	if lang := NormalizeLocale(c.Query("lang")); lang != "" {
+		return lang
+	}
+	if lang := ParseAcceptLanguage(c.GetHeader("Accept-Language")); lang != "" {
+		return lang
+	}
+	return consts.DefaultLocale
+}
+
+// NormalizeLocale chuẩn hóa mã ngôn ngữ (vd: "en-US" -> "en"), trả về rỗng nếu không được hỗ trợ
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	if consts.IsSupportedLocale(locale) {
+		return locale
+	}
+	return ""
+}
+
+// ParseAcceptLanguage chọn ngôn ngữ được hỗ trợ có trọng số q cao nhất trong header Accept-Language
+func ParseAcceptLanguage(header string) string {
+	best := ""
+	bestQ := -1.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := NormalizeLocale(fields[0])
+		if locale == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > bestQ {
+			best = locale
+			bestQ = q
+		}
+	}
+	return best
+}
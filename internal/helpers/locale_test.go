@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"vi", "vi"},
+		{"EN", "en"},
+		{" en-US ", "en"},
+		{"vi_VN", "vi"},
+		{"fr", ""},
+		{"", ""},
+		{"-en", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := NormalizeLocale(tt.locale); got != tt.want {
+				t.Errorf("NormalizeLocale(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"en", "en"},
+		{"en-US,en;q=0.9", "en"},
+		{"fr-FR,fr;q=0.9,en;q=0.8,vi;q=0.7", "en"},
+		{"vi;q=0.5, en;q=0.8", "en"},
+		{"en;q=0.5, vi", "vi"},
+		{"en, vi", "en"},
+		{"fr, de;q=0.9", ""},
+		{"*", ""},
+		{"en;q=abc, vi;q=0.9", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+	}{
+		{"mặc định", "", "", "vi"},
+		{"theo Accept-Language", "", "en-US,en;q=0.9", "en"},
+		{"query lang ưu tiên hơn header", "?lang=vi", "en", "vi"},
+		{"query lang không hỗ trợ thì dùng header", "?lang=fr", "en", "en"},
+		{"không có ngôn ngữ hỗ trợ", "?lang=fr", "de", "vi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/articles"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				c.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if got := ResolveLocale(c); got != tt.want {
+				t.Errorf("ResolveLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,55 +7,77 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// CodeOK là mã trả về cho mọi response thành công
+const CodeOK = "OK"
+
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// MarshalJSON đảm bảo response luôn có code (response thành công tạo trực tiếp không cần gán)
+func (r Response) MarshalJSON() ([]byte, error) {
+	type alias Response
+	if r.Code == "" {
+		if r.Success {
+			r.Code = CodeOK
+		} else {
+			r.Code = string(ErrInternal.Code)
+		}
+	}
+	return json.Marshal(alias(r))
 }
 
 // Success response helper
 func SuccessResponse(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, Response{
 		Success: true,
+		Code:    CodeOK,
 		Message: message,
 		Data:    data,
 	})
 }
 
-// Generic error response helper
-func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
+// ErrorResponse trả về lỗi trong catalog: HTTP status và mã lỗi lấy từ apiErr,
+// thông điệp được dịch theo ngôn ngữ của request. err (nếu có) là chi tiết kỹ thuật
+func ErrorResponse(c *gin.Context, apiErr *APIError, err error) {
 	response := Response{
 		Success: false,
-		Message: message,
+		Code:    string(apiErr.Code),
+		Message: apiErr.Message(ResolveLocale(c)),
 	}
 
 	if err != nil {
 		response.Error = err.Error()
 	}
 
-	c.JSON(statusCode, response)
-}
-
-// Quick helpers for common error types
-func BadRequestResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusBadRequest, message, nil)
+	c.JSON(apiErr.Status, response)
 }
 
-func UnauthorizedResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusUnauthorized, message, nil)
-}
+// ErrorResponseWithData trả về lỗi trong catalog kèm dữ liệu giúp client xử lý (vd: danh sách đang tham chiếu)
+func ErrorResponseWithData(c *gin.Context, apiErr *APIError, err error, data interface{}) {
+	response := Response{
+		Success: false,
+		Code:    string(apiErr.Code),
+		Message: apiErr.Message(ResolveLocale(c)),
+		Data:    data,
+	}
 
-func ForbiddenResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusForbidden, message, nil)
-}
+	if err != nil {
+		response.Error = err.Error()
+	}
 
-func NotFoundResponse(c *gin.Context, message string) {
-	ErrorResponse(c, http.StatusNotFound, message, nil)
+	c.JSON(apiErr.Status, response)
 }
 
-func InternalErrorResponse(c *gin.Context, message string, err error) {
-	ErrorResponse(c, http.StatusInternalServerError, message, err)
+// AbortWithError trả về lỗi và dừng chuỗi middleware
+func AbortWithError(c *gin.Context, apiErr *APIError, err error) {
+	ErrorResponse(c, apiErr, err)
+	c.Abort()
 }
 
 // StructToMap chuyển struct sang map[string]interface{}
@@ -1,9 +1,15 @@
 package helpers
 
 import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"sort"
 	"strings"
 )
 
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
 // RemoveVietnameseDiacritics removes Vietnamese diacritics from text for fuzzy search
 func RemoveVietnameseDiacritics(text string) string {
 	// Chuyển về lowercase trước
@@ -74,3 +80,47 @@ func IsSearchMatch(searchTerm, targetText string) bool {
 
 	return strings.Contains(normalizedTarget, normalizedSearch)
 }
+
+// RichTextToPlain lấy phần chữ từ nội dung rich content (JSON của editor hoặc chuỗi HTML):
+// gom mọi giá trị "text" theo thứ tự xuất hiện, bỏ thẻ HTML và khoảng trắng thừa
+func RichTextToPlain(raw []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+
+	var parts []string
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch node := v.(type) {
+		case string:
+			parts = append(parts, node)
+		case []interface{}:
+			for _, child := range node {
+				walk(child)
+			}
+		case map[string]interface{}:
+			if text, ok := node["text"].(string); ok {
+				parts = append(parts, text)
+			}
+			keys := make([]string, 0, len(node))
+			for key := range node {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys) // Thứ tự duyệt ổn định
+			for _, key := range keys {
+				if key == "text" {
+					continue
+				}
+				if _, ok := node[key].(string); ok {
+					continue // Bỏ qua thuộc tính dạng chuỗi (type, href, ...)
+				}
+				walk(node[key])
+			}
+		}
+	}
+	walk(value)
+
+	text := html.UnescapeString(htmlTagPattern.ReplaceAllString(strings.Join(parts, " "), " "))
+	return strings.Join(strings.Fields(text), " ")
+}
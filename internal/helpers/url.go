@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	"backend/internal/consts"
+	"os"
+	"strings"
+)
+
+// PublicBaseURL trả về domain trang công khai (env PUBLIC_WEB_DOMAIN), luôn có scheme và không có "/" cuối
+func PublicBaseURL() string {
+	v := os.Getenv("PUBLIC_WEB_DOMAIN")
+	if v == "" {
+		return "https://quantriduanxaydung.vn"
+	}
+	if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") {
+		return strings.TrimRight(v, "/")
+	}
+	return "https://" + strings.TrimRight(v, "/")
+}
+
+// LocalizedPath thêm tiền tố ngôn ngữ cho đường dẫn (ngôn ngữ mặc định không có tiền tố)
+func LocalizedPath(locale, path string) string {
+	if locale == "" || locale == consts.DefaultLocale {
+		return path
+	}
+	return "/" + locale + path
+}
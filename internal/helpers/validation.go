@@ -0,0 +1,149 @@
+package helpers
+
+import (
+	"backend/internal/consts"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError - lỗi validate của một trường trong request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"` // Tên rule bị vi phạm: required, min, max, email, type...
+	Message string `json:"message"`
+}
+
+// Thông điệp theo rule validate; %s là tham số của rule (nếu có)
+var fieldMessages = map[string]map[string]string{
+	"required": {consts.LocaleVI: "Trường này là bắt buộc", consts.LocaleEN: "This field is required"},
+	"email":    {consts.LocaleVI: "Email không hợp lệ", consts.LocaleEN: "Must be a valid email address"},
+	"url":      {consts.LocaleVI: "URL không hợp lệ", consts.LocaleEN: "Must be a valid URL"},
+	"uuid":     {consts.LocaleVI: "Phải là UUID hợp lệ", consts.LocaleEN: "Must be a valid UUID"},
+	"oneof":    {consts.LocaleVI: "Giá trị phải là một trong: %s", consts.LocaleEN: "Must be one of: %s"},
+	"len":      {consts.LocaleVI: "Độ dài phải bằng %s", consts.LocaleEN: "Length must be %s"},
+	"min":      {consts.LocaleVI: "Tối thiểu %s ký tự", consts.LocaleEN: "Must be at least %s characters"},
+	"max":      {consts.LocaleVI: "Tối đa %s ký tự", consts.LocaleEN: "Must be at most %s characters"},
+	"min_num":  {consts.LocaleVI: "Giá trị tối thiểu là %s", consts.LocaleEN: "Must be at least %s"},
+	"max_num":  {consts.LocaleVI: "Giá trị tối đa là %s", consts.LocaleEN: "Must be at most %s"},
+	"min_list": {consts.LocaleVI: "Cần ít nhất %s phần tử", consts.LocaleEN: "Must contain at least %s items"},
+	"max_list": {consts.LocaleVI: "Tối đa %s phần tử", consts.LocaleEN: "Must contain at most %s items"},
+	"gte":      {consts.LocaleVI: "Giá trị phải lớn hơn hoặc bằng %s", consts.LocaleEN: "Must be greater than or equal to %s"},
+	"lte":      {consts.LocaleVI: "Giá trị phải nhỏ hơn hoặc bằng %s", consts.LocaleEN: "Must be less than or equal to %s"},
+	"eqfield":  {consts.LocaleVI: "Phải trùng với trường %s", consts.LocaleEN: "Must match field %s"},
+	"type":     {consts.LocaleVI: "Kiểu dữ liệu không hợp lệ, cần %s", consts.LocaleEN: "Invalid type, expected %s"},
+	"pattern":  {consts.LocaleVI: "Sai định dạng, cần khớp %s", consts.LocaleEN: "Invalid format, must match %s"},
+	"unknown":  {consts.LocaleVI: "Trường không được hỗ trợ", consts.LocaleEN: "Unknown field"},
+	"invalid":  {consts.LocaleVI: "Giá trị không hợp lệ", consts.LocaleEN: "Invalid value"},
+}
+
+// RegisterValidatorTagNames dùng tên trong tag json làm tên trường khi validate,
+// để FieldError.Field khớp với key mà client gửi lên
+func RegisterValidatorTagNames() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+			name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return fld.Name
+			}
+			return name
+		})
+	}
+}
+
+// fieldMessage dịch thông điệp của một rule, fallback về "invalid"
+func fieldMessage(rule, param, locale string) string {
+	messages, ok := fieldMessages[rule]
+	if !ok {
+		messages = fieldMessages["invalid"]
+	}
+	msg, ok := messages[locale]
+	if !ok {
+		msg = messages[consts.DefaultLocale]
+	}
+	if strings.Contains(msg, "%s") {
+		return fmt.Sprintf(msg, param)
+	}
+	return msg
+}
+
+// ruleKey phân biệt min/max cho chuỗi, số và danh sách
+func ruleKey(fe validator.FieldError) string {
+	tag := fe.Tag()
+	if tag != "min" && tag != "max" {
+		return tag
+	}
+	switch fe.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return tag + "_list"
+	case reflect.String:
+		return tag
+	default:
+		return tag + "_num"
+	}
+}
+
+// ValidationErrorResponse chuyển lỗi từ ShouldBindJSON/ShouldBindQuery thành
+// danh sách lỗi theo từng trường (mã VALIDATION_ERROR) hoặc INVALID_JSON khi body sai định dạng
+func ValidationErrorResponse(c *gin.Context, err error) {
+	locale := ResolveLocale(c)
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	var fields []FieldError
+	switch {
+	case errors.As(err, &validationErrs):
+		fields = make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, FieldError{
+				Field:   fe.Field(),
+				Code:    fe.Tag(),
+				Message: fieldMessage(ruleKey(fe), fe.Param(), locale),
+			})
+		}
+	case errors.As(err, &typeErr):
+		fields = []FieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fieldMessage("type", typeErr.Type.String(), locale),
+		}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		ErrorResponse(c, ErrInvalidJSON, err)
+		return
+	}
+
+	FieldErrorsResponse(c, err, fields)
+}
+
+// NewFieldError tạo lỗi của một trường theo tên rule (cùng bộ rule với validator), thông điệp theo ngôn ngữ request.
+// Dùng cho dữ liệu không validate được bằng tag binding (vd metadata theo schema)
+func NewFieldError(c *gin.Context, field, rule, param string) FieldError {
+	return FieldError{
+		Field:   field,
+		Code:    strings.TrimSuffix(strings.TrimSuffix(rule, "_list"), "_num"), // min_list, max_num... có mã min/max như validator
+		Message: fieldMessage(rule, param, ResolveLocale(c)),
+	}
+}
+
+// FieldErrorsResponse trả lỗi VALIDATION_ERROR với danh sách lỗi theo từng trường
+func FieldErrorsResponse(c *gin.Context, err error, fields []FieldError) {
+	c.JSON(ErrValidation.Status, Response{
+		Success: false,
+		Code:    string(ErrValidation.Code),
+		Message: ErrValidation.Message(ResolveLocale(c)),
+		Error:   err.Error(),
+		Errors:  fields,
+	})
+}
@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"backend/internal/consts"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VisitorCookieName - cookie định danh khách truy cập ẩn danh
+const VisitorCookieName = "visitor_id"
+
+const visitorCookieMaxAge = 365 * 24 * 60 * 60
+
+var visitorIDPattern = regexp.MustCompile(`^[a-f0-9]{32}$`)
+
+func hashHex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// signVisitorID ký visitor_id bằng HMAC (VISITOR_COOKIE_SECRET, mặc định dùng khóa JWT)
+// để client không tự đặt được mã định danh tùy ý
+func signVisitorID(visitorID string) string {
+	secret := os.Getenv("VISITOR_COOKIE_SECRET")
+	if secret == "" {
+		secret = consts.JWT_SECRET_KEY
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(visitorID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseVisitorCookie tách và kiểm tra cookie dạng <visitor_id>.<chữ ký>; trả về false khi sai định dạng hoặc sai chữ ký
+func parseVisitorCookie(value string) (string, bool) {
+	visitorID, signature, ok := strings.Cut(value, ".")
+	if !ok || !visitorIDPattern.MatchString(visitorID) {
+		return "", false
+	}
+	if !hmac.Equal([]byte(signVisitorID(visitorID)), []byte(signature)) {
+		return "", false
+	}
+	return visitorID, true
+}
+
+// VisitorHash trả về mã băm định danh khách truy cập để chống trùng lặp (phản hồi, lượt xem...).
+// Ưu tiên cookie visitor_id có chữ ký hợp lệ; nếu chưa có hoặc chữ ký sai thì dùng dấu vân tay
+// IP + User-Agent + Accept-Language và ghi lại cookie đã ký để các lần sau cho cùng kết quả. Không lưu IP thô.
+// Có thể đặt salt qua env VISITOR_HASH_SALT.
+func VisitorHash(c *gin.Context) string {
+	cookie, err := c.Cookie(VisitorCookieName)
+	visitorID, ok := parseVisitorCookie(cookie)
+	if err != nil || !ok {
+		visitorID = hashHex(c.ClientIP() + "|" + c.Request.UserAgent() + "|" + c.GetHeader("Accept-Language"))[:32]
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(VisitorCookieName, visitorID+"."+signVisitorID(visitorID), visitorCookieMaxAge, "/", "", c.Request.TLS != nil, true)
+	}
+	return hashHex(os.Getenv("VISITOR_HASH_SALT") + visitorID)
+}
@@ -0,0 +1,76 @@
+package imaging
+
+import (
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sync"
+)
+
+// Định dạng đầu ra hỗ trợ
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+// Encoder mã hóa ảnh sang một định dạng. Mã hóa lại không giữ EXIF/metadata của file gốc
+type Encoder interface {
+	Format() string
+	ContentType() string
+	Extension() string
+	SupportsAlpha() bool
+	Encode(w io.Writer, img image.Image) error
+}
+
+// JPEGEncoder - Encoder JPEG của thư viện chuẩn
+type JPEGEncoder struct {
+	Quality int
+}
+
+func (JPEGEncoder) Format() string      { return FormatJPEG }
+func (JPEGEncoder) ContentType() string { return "image/jpeg" }
+func (JPEGEncoder) Extension() string   { return ".jpg" }
+func (JPEGEncoder) SupportsAlpha() bool { return false }
+
+func (e JPEGEncoder) Encode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: e.Quality})
+}
+
+// PNGEncoder - Encoder PNG của thư viện chuẩn (dùng cho ảnh trong suốt khi không có WebP)
+type PNGEncoder struct{}
+
+func (PNGEncoder) Format() string      { return FormatPNG }
+func (PNGEncoder) ContentType() string { return "image/png" }
+func (PNGEncoder) Extension() string   { return ".png" }
+func (PNGEncoder) SupportsAlpha() bool { return true }
+
+func (PNGEncoder) Encode(w io.Writer, img image.Image) error {
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	return encoder.Encode(w, img)
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		FormatJPEG: JPEGEncoder{Quality: 82},
+		FormatPNG:  PNGEncoder{},
+	}
+)
+
+// RegisterEncoder đăng ký hoặc thay encoder cho một định dạng.
+// Thư viện chuẩn không có encoder WebP: bản build cần WebP đăng ký encoder riêng (vd bọc libwebp) khi khởi động
+func RegisterEncoder(encoder Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[encoder.Format()] = encoder
+}
+
+// EncoderFor lấy encoder đã đăng ký theo định dạng
+func EncoderFor(format string) (Encoder, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	encoder, ok := encoders[format]
+	return encoder, ok
+}
@@ -0,0 +1,84 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// StripJPEGMetadata bỏ các segment metadata (APP1 EXIF/XMP, APP13 IPTC, COM) của file JPEG mà không mã hóa lại.
+// Giữ APP0 (JFIF), APP2 (ICC profile) và APP14 (Adobe) vì ảnh hưởng cách hiển thị màu.
+// Trả về changed = false khi không có gì để bỏ hoặc file không đúng cấu trúc (giữ nguyên file)
+func StripJPEGMetadata(data []byte) ([]byte, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data, false
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	changed := false
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return data, false
+		}
+		marker := data[pos+1]
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// Phần còn lại là dữ liệu ảnh
+			return append(out, data[pos:]...), changed
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return data, false
+		}
+		if marker == 0xE1 || marker == 0xED || marker == 0xFE {
+			changed = true
+		} else {
+			out = append(out, data[pos:pos+2+length]...)
+		}
+		pos += 2 + length
+	}
+	return data, false
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks - Chunk PNG chứa metadata (EXIF, văn bản tự do, thời điểm sửa)
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// StripPNGMetadata bỏ các chunk metadata của file PNG (CRC tính theo từng chunk nên các chunk còn lại giữ nguyên).
+// Trả về changed = false khi không có gì để bỏ hoặc file không đúng cấu trúc
+func StripPNGMetadata(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return data, false
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	changed := false
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) || end < pos {
+			return data, false
+		}
+		chunkType := string(data[pos+4 : pos+8])
+		if pngMetadataChunks[chunkType] {
+			changed = true
+		} else {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+		if chunkType == "IEND" {
+			return out, changed
+		}
+	}
+	return data, false
+}
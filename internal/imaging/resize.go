@@ -0,0 +1,160 @@
+// Package imaging xử lý ảnh thuần Go (không cgo): đổi kích thước, cắt, xoay theo EXIF và mã hóa lại
+package imaging
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// ToRGBA chuyển ảnh đã decode sang RGBA (premultiplied) để xử lý nhanh theo mảng byte
+func ToRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
+
+// FitSize tính kích thước vừa khung maxW x maxH, giữ tỉ lệ và không phóng to. 0 = không giới hạn chiều đó
+func FitSize(width, height, maxW, maxH int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+	scale := 1.0
+	if maxW > 0 && width > maxW {
+		scale = math.Min(scale, float64(maxW)/float64(width))
+	}
+	if maxH > 0 && height > maxH {
+		scale = math.Min(scale, float64(maxH)/float64(height))
+	}
+	w := int(math.Round(float64(width) * scale))
+	h := int(math.Round(float64(height) * scale))
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
+
+// Fit thu nhỏ ảnh vừa khung maxW x maxH, giữ tỉ lệ (ảnh nhỏ hơn khung giữ nguyên kích thước)
+func Fit(src *image.RGBA, maxW, maxH int) *image.RGBA {
+	w, h := FitSize(src.Rect.Dx(), src.Rect.Dy(), maxW, maxH)
+	if w == src.Rect.Dx() && h == src.Rect.Dy() {
+		return src
+	}
+	return Resize(src, w, h)
+}
+
+// Fill cắt giữa ảnh theo tỉ lệ width:height rồi đổi về đúng width x height (vd ảnh OG 1200x630)
+func Fill(src *image.RGBA, width, height int) *image.RGBA {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	cropW, cropH := srcW, int(math.Round(float64(srcW)*float64(height)/float64(width)))
+	if cropH > srcH {
+		cropW, cropH = int(math.Round(float64(srcH)*float64(width)/float64(height))), srcH
+	}
+	x0 := (srcW - cropW) / 2
+	y0 := (srcH - cropH) / 2
+	cropped := src.SubImage(image.Rect(x0, y0, x0+cropW, y0+cropH)).(*image.RGBA)
+	return Resize(cropped, width, height)
+}
+
+// Resize đổi kích thước bằng bộ lọc tam giác (bilinear khi phóng to, trung bình vùng khi thu nhỏ),
+// tách hai lượt ngang/dọc
+func Resize(src *image.RGBA, width, height int) *image.RGBA {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	tmp := image.NewRGBA(image.Rect(0, 0, width, srcH))
+	resample(tmp.Pix, 4, tmp.Stride, src.Pix[src.PixOffset(src.Rect.Min.X, src.Rect.Min.Y):], 4, src.Stride, srcW, width, srcH)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	resample(dst.Pix, dst.Stride, 4, tmp.Pix, tmp.Stride, 4, srcH, height, width)
+	return dst
+}
+
+type contribution struct {
+	start   int
+	weights []float64
+}
+
+// weightsFor tính trọng số lấy mẫu của từng pixel đích trên một chiều
+func weightsFor(srcLen, dstLen int) []contribution {
+	scale := float64(srcLen) / float64(dstLen)
+	radius := math.Max(scale, 1)
+	out := make([]contribution, dstLen)
+	for i := range out {
+		center := (float64(i) + 0.5) * scale
+		left := int(math.Floor(center - radius))
+		right := int(math.Ceil(center + radius))
+		if left < 0 {
+			left = 0
+		}
+		if right > srcLen {
+			right = srcLen
+		}
+		weights := make([]float64, 0, right-left)
+		sum := 0.0
+		for j := left; j < right; j++ {
+			w := 1 - math.Abs((float64(j)+0.5-center)/radius)
+			if w < 0 {
+				w = 0
+			}
+			weights = append(weights, w)
+			sum += w
+		}
+		if sum == 0 {
+			// Không có pixel nào trong vùng lọc (chỉ xảy ra ở biên): lấy pixel gần nhất
+			nearest := int(center)
+			if nearest >= srcLen {
+				nearest = srcLen - 1
+			}
+			out[i] = contribution{start: nearest, weights: []float64{1}}
+			continue
+		}
+		for k := range weights {
+			weights[k] /= sum
+		}
+		out[i] = contribution{start: left, weights: weights}
+	}
+	return out
+}
+
+// resample lọc một chiều: srcStep/dstStep là khoảng cách byte giữa hai pixel liền kề trên chiều đang lọc,
+// srcLine/dstLine là khoảng cách giữa hai dòng của chiều còn lại
+func resample(dst []byte, dstStep, dstLine int, src []byte, srcStep, srcLine int, srcLen, dstLen, lines int) {
+	contribs := weightsFor(srcLen, dstLen)
+	for line := 0; line < lines; line++ {
+		srcBase := line * srcLine
+		dstBase := line * dstLine
+		for i, c := range contribs {
+			var r, g, b, a float64
+			offset := srcBase + c.start*srcStep
+			for _, w := range c.weights {
+				r += float64(src[offset]) * w
+				g += float64(src[offset+1]) * w
+				b += float64(src[offset+2]) * w
+				a += float64(src[offset+3]) * w
+				offset += srcStep
+			}
+			d := dstBase + i*dstStep
+			dst[d] = clampByte(r)
+			dst[d+1] = clampByte(g)
+			dst[d+2] = clampByte(b)
+			dst[d+3] = clampByte(a)
+		}
+	}
+}
+
+func clampByte(v float64) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
@@ -0,0 +1,116 @@
+package legalreview
+
+import (
+	"backend/internal/model"
+	"backend/internal/notify"
+	"backend/internal/repo"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Result - Kết quả một lần quét
+type Result struct {
+	Checked int `json:"checked"` // Số bài được kiểm tra
+	Flagged int `json:"flagged"` // Số bài mới bị đánh dấu cần rà soát
+	Digests int `json:"digests"` // Số bản tổng hợp đã gửi cho tác giả
+}
+
+// Run quét một lần: đánh dấu bài quá hạn rà soát hoặc trích dẫn văn bản đã hết hiệu lực,
+// rồi gửi mỗi tác giả một bản tổng hợp các bài mới bị đánh dấu qua notify.
+// Đánh dấu lỗi giữa chừng thì vẫn gửi tổng hợp cho các bài đã đánh dấu (lần quét sau không chọn lại chúng) rồi trả lỗi
+func Run(now time.Time) (Result, error) {
+	var result Result
+	reviewRepo := repo.NewLegalReviewRepo()
+
+	candidates, err := reviewRepo.GetCandidates(now)
+	if err != nil {
+		return result, err
+	}
+	result.Checked = len(candidates)
+
+	byAuthor := map[uuid.UUID][]model.Article{}
+	var authorOrder []uuid.UUID
+	var flagErr error
+	for i := range candidates {
+		article := &candidates[i]
+		reasons := article.DueReviewReasons(now)
+		if len(reasons) == 0 {
+			continue
+		}
+		if flagErr = reviewRepo.Flag(article, reasons, now); flagErr != nil {
+			break
+		}
+		result.Flagged++
+		if _, ok := byAuthor[article.AuthorID]; !ok {
+			authorOrder = append(authorOrder, article.AuthorID)
+		}
+		byAuthor[article.AuthorID] = append(byAuthor[article.AuthorID], *article)
+	}
+
+	for _, authorID := range authorOrder {
+		sendDigest(byAuthor[authorID])
+		result.Digests++
+	}
+
+	return result, flagErr
+}
+
+// sendDigest gửi cho tác giả danh sách bài cần rà soát
+func sendDigest(articles []model.Article) {
+	author := articles[0].Author
+
+	var lines []string
+	ids := make([]uuid.UUID, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+		lines = append(lines, fmt.Sprintf("- %s: %s", article.Title, strings.Join(article.GetReviewReasons(), "; ")))
+	}
+
+	n := notify.Notification{
+		Event:   notify.EventLegalReviewDue,
+		Title:   fmt.Sprintf("%d bài viết cần rà soát pháp lý", len(articles)),
+		Message: strings.Join(lines, "\n"),
+		URL:     "/admin/articles/needs-review",
+		Data: map[string]interface{}{
+			"article_ids": ids,
+		},
+	}
+	if author != nil {
+		n.Data["author_id"] = author.ID
+		if author.Email != "" {
+			n.Recipients = []string{author.Email}
+		}
+	}
+	notify.Send(n)
+}
+
+// Start chạy job quét định kỳ mỗi LEGAL_REVIEW_INTERVAL_HOURS giờ (mặc định 24, đặt 0 để tắt)
+func Start() {
+	hours := 24
+	if v, err := strconv.Atoi(os.Getenv("LEGAL_REVIEW_INTERVAL_HOURS")); err == nil && v >= 0 {
+		hours = v
+	}
+	if hours == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(hours) * time.Hour)
+		defer ticker.Stop()
+		for {
+			result, err := Run(time.Now())
+			if err != nil {
+				log.Printf("⚠️  Warning: Legal review check failed: %v", err)
+			} else if result.Flagged > 0 {
+				log.Printf("⚖️  Legal review: %d/%d articles flagged, %d digests sent", result.Flagged, result.Checked, result.Digests)
+			}
+			<-ticker.C
+		}
+	}()
+}
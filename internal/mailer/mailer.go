@@ -0,0 +1,167 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Message - Một email cần gửi (HTML kèm bản text thuần)
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	Headers map[string]string // Header bổ sung (List-Unsubscribe, ...)
+}
+
+// Mailer - Kênh gửi email (file, SMTP, dịch vụ bên ngoài...). Có thể thay thế bằng SetMailer
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// DefaultFrom trả về địa chỉ gửi mặc định (env MAILER_FROM)
+func DefaultFrom() string {
+	if v := os.Getenv("MAILER_FROM"); v != "" {
+		return v
+	}
+	return "no-reply@localhost"
+}
+
+// Build tạo nội dung email định dạng MIME (multipart/alternative: text + HTML)
+func Build(msg Message, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	}
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	from := msg.From
+	if from == "" {
+		from = DefaultFrom()
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: %s\r\n", from)
+	fmt.Fprintf(&out, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&out, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&out, "MIME-Version: 1.0\r\n")
+
+	keys := make([]string, 0, len(msg.Headers))
+	for key := range msg.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&out, "%s: %s\r\n", key, msg.Headers[key])
+	}
+
+	fmt.Fprintf(&out, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+// FileMailer ghi mỗi email thành một file .eml trong thư mục (dùng khi phát triển local)
+type FileMailer struct {
+	Dir string
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]+`)
+
+func (m *FileMailer) Send(msg Message) error {
+	now := time.Now()
+	content, err := Build(msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	return os.WriteFile(filepath.Join(m.Dir, name), content, 0o644)
+}
+
+// LogMailer chỉ ghi log người nhận và tiêu đề (không gửi thật)
+type LogMailer struct{}
+
+func (LogMailer) Send(msg Message) error {
+	log.Printf("✉️  -> %s: %s", msg.To, msg.Subject)
+	return nil
+}
+
+var (
+	current   Mailer
+	currentMu sync.RWMutex
+)
+
+// defaultMailer chọn kênh gửi theo env MAILER_DRIVER: file (mặc định, ghi vào MAILER_FILE_DIR) hoặc log
+func defaultMailer() Mailer {
+	switch os.Getenv("MAILER_DRIVER") {
+	case "log":
+		return LogMailer{}
+	default:
+		dir := os.Getenv("MAILER_FILE_DIR")
+		if dir == "" {
+			dir = "tmp/mail"
+		}
+		return &FileMailer{Dir: dir}
+	}
+}
+
+// SetMailer thay thế kênh gửi email mặc định (vd: SMTP, SES)
+func SetMailer(m Mailer) {
+	currentMu.Lock()
+	current = m
+	currentMu.Unlock()
+}
+
+// Get trả về kênh gửi email đang dùng
+func Get() Mailer {
+	currentMu.RLock()
+	m := current
+	currentMu.RUnlock()
+	if m != nil {
+		return m
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	if current == nil {
+		current = defaultMailer()
+	}
+	return current
+}
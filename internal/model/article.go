@@ -1,6 +1,7 @@
 package model
 
 import (
+	"backend/internal/consts"
 	"encoding/json"
 	"time"
 
@@ -11,27 +12,40 @@ import (
 
 // Article - Bài viết
 type Article struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
-	Title       string         `json:"title" gorm:"not null;size:500;index"`
-	Description string         `json:"description" gorm:"type:text"`
-	Slug        string         `json:"slug" gorm:"unique;not null;size:500;index"`
-	CategoryID  *uuid.UUID     `json:"category_id" gorm:"type:char(36);index"`
-	TagIDs      datatypes.JSON `json:"tag_ids" gorm:"type:json;column:tag_id"` // Mảng UUID của tags
-	IsActive    bool           `json:"is_active" gorm:"default:true;index"`
-	IsHot       bool           `json:"is_hot" gorm:"default:false;index"`
-	Status      string         `json:"status" gorm:"type:varchar(20);default:'draft';index"` // draft, post
-	PublishedAt *time.Time     `json:"published_at"`
-	Metadata    datatypes.JSON `json:"metadata" gorm:"type:json"`
-	Content     datatypes.JSON `json:"content" gorm:"type:json"`
-	AuthorID    uuid.UUID      `json:"author_id" gorm:"type:char(36);not null;index"` // Admin tạo bài
-	ViewCount   int            `json:"view_count" gorm:"default:0;index"`
-	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Title              string         `json:"title" gorm:"not null;size:500;index"`
+	Description        string         `json:"description" gorm:"type:text"`
+	Slug               string         `json:"slug" gorm:"unique;not null;size:500;index"`
+	CategoryID         *uuid.UUID     `json:"category_id" gorm:"type:char(36);index"`
+	TagIDs             datatypes.JSON `json:"tag_ids" gorm:"type:json;column:tag_id"` // Mảng UUID của tags
+	IsActive           bool           `json:"is_active" gorm:"default:true;index"`
+	IsHot              bool           `json:"is_hot" gorm:"default:false;index"`
+	Status             string         `json:"status" gorm:"type:varchar(20);default:'draft';index"` // draft, post
+	PublishedAt        *time.Time     `json:"published_at"`
+	Metadata           datatypes.JSON `json:"metadata" gorm:"type:json"`
+	Content            datatypes.JSON `json:"content" gorm:"type:json"`
+	AuthorID           uuid.UUID      `json:"author_id" gorm:"type:char(36);not null;index"` // Admin tạo bài
+	ViewCount          int            `json:"view_count" gorm:"default:0;index"`
+	CommentCount       int            `json:"comment_count" gorm:"default:0"`            // Số bình luận đã duyệt
+	ReviewedByID       *uuid.UUID     `json:"reviewed_by_id" gorm:"type:char(36);index"` // Hồ sơ tác giả thẩm định nội dung pháp lý
+	ReviewedAt         *time.Time     `json:"reviewed_at"`                               // Lần rà soát pháp lý gần nhất
+	ReviewBy           *time.Time     `json:"review_by" gorm:"index"`                    // Hạn rà soát pháp lý tiếp theo
+	LegalBasis         datatypes.JSON `json:"legal_basis" gorm:"type:json"`              // Mảng LegalReference
+	NeedsReview        bool           `json:"needs_review" gorm:"default:false;index"`   // Bị job nhắc rà soát đánh dấu
+	ReviewReasons      datatypes.JSON `json:"review_reasons" gorm:"type:json"`           // Mảng lý do cần rà soát
+	ReviewFlaggedAt    *time.Time     `json:"review_flagged_at"`
+	Attachments        datatypes.JSON `json:"attachments" gorm:"type:json"` // Mảng ArticleAttachment (file trong thư viện media)
+	Locale             string         `json:"locale" gorm:"type:varchar(10);default:'vi';index"`
+	TranslationGroupID *uuid.UUID     `json:"translation_group_id" gorm:"type:char(36);index"` // Các bản dịch của cùng nội dung có chung group
+	CreatedAt          time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Quan hệ (constraints handled manually in database.go)
-	Author   *User     `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
-	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	Author     *User             `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Category   *Category         `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	CoAuthors  []ArticleCoAuthor `json:"co_authors,omitempty" gorm:"foreignKey:ArticleID"`
+	ReviewedBy *AuthorProfile    `json:"reviewed_by,omitempty" gorm:"foreignKey:ReviewedByID"`
 }
 
 func (Article) TableName() string {
@@ -42,27 +56,38 @@ func (a *Article) BeforeCreate(tx *gorm.DB) (err error) {
 	if a.ID == uuid.Nil {
 		a.ID = uuid.New()
 	}
+	ensureTranslationGroup(a.ID, &a.TranslationGroupID)
 	return
 }
 
 type ArticleInput struct {
-	Title       string          `json:"title" binding:"required,min=1,max=500"`
-	Description string          `json:"description"`
-	Slug        string          `json:"slug" binding:"required,min=1,max=500"`
-	CategoryID  *uuid.UUID      `json:"category_id"`
-	TagIDs      []uuid.UUID     `json:"tag_ids"` // Đổi tên từ tag_id thành tag_ids cho nhất quán
-	IsActive    *bool           `json:"is_active"`
-	IsHot       *bool           `json:"is_hot"`
-	Status      *string         `json:"status"`
-	PublishedAt *time.Time      `json:"published_at"`
-	Metadata    json.RawMessage `json:"metadata"`
-	Content     json.RawMessage `json:"content"`
+	Title       string              `json:"title" binding:"required,min=1,max=500"`
+	Description string              `json:"description"`
+	Slug        string              `json:"slug" binding:"required,min=1,max=500"`
+	CategoryID  *uuid.UUID          `json:"category_id"`
+	TagIDs      []uuid.UUID         `json:"tag_ids"` // Đổi tên từ tag_id thành tag_ids cho nhất quán
+	IsActive    *bool               `json:"is_active"`
+	IsHot       *bool               `json:"is_hot"`
+	Status      *string             `json:"status"`
+	PublishedAt *time.Time          `json:"published_at"`
+	Metadata    json.RawMessage     `json:"metadata"`
+	Content     json.RawMessage     `json:"content"`
+	Locale      string              `json:"locale"`
+	ReviewBy    *time.Time          `json:"review_by"`
+	LegalBasis  []LegalReference    `json:"legal_basis" binding:"omitempty,dive"`
+	Attachments []ArticleAttachment `json:"attachments" binding:"omitempty,max=20,dive"`
+	// TranslationOf: ID của một bản ghi bất kỳ trong nhóm bản dịch cần liên kết (chỉ dùng khi tạo)
+	TranslationOf *uuid.UUID `json:"translation_of"`
 }
 
+// AuthorResponse - Thông tin công khai của tác giả nhúng trong bài viết (không lộ email)
 type AuthorResponse struct {
-	ID       uuid.UUID `json:"id"`
-	FullName string    `json:"full_name"`
-	Email    string    `json:"email"`
+	ID        *uuid.UUID `json:"id,omitempty"`         // ID của User (không có với hồ sơ đứng riêng)
+	ProfileID *uuid.UUID `json:"profile_id,omitempty"` // ID hồ sơ công khai (nếu có)
+	FullName  string     `json:"full_name"`
+	Slug      string     `json:"slug,omitempty"` // Dùng cho link /tac-gia/:slug
+	Title     string     `json:"title,omitempty"`
+	Photo     *Avatar    `json:"photo,omitempty"`
 }
 
 type CategorySimpleResponse struct {
@@ -72,25 +97,48 @@ type CategorySimpleResponse struct {
 }
 
 type ArticleResponse struct {
-	ID          uuid.UUID               `json:"id"`
-	Title       string                  `json:"title"`
-	Description string                  `json:"description"`
-	Slug        string                  `json:"slug"`
-	CategoryID  *uuid.UUID              `json:"category_id"`
-	TagIDs      []uuid.UUID             `json:"tag_ids"` // Đổi tên từ tag_id thành tag_ids
-	TagNames    []string                `json:"tag_names,omitempty"`
-	IsActive    bool                    `json:"is_active"`
-	IsHot       bool                    `json:"is_hot"`
-	Status      string                  `json:"status"`
-	PublishedAt *time.Time              `json:"published_at"`
-	Metadata    json.RawMessage         `json:"metadata,omitempty"`
-	Content     json.RawMessage         `json:"content,omitempty"`
-	AuthorID    uuid.UUID               `json:"author_id"`
-	ViewCount   int                     `json:"view_count"`
-	Author      *AuthorResponse         `json:"author,omitempty"`
-	Category    *CategorySimpleResponse `json:"category,omitempty"`
-	CreatedAt   time.Time               `json:"created_at"`
-	UpdatedAt   time.Time               `json:"updated_at"`
+	ID                 uuid.UUID                     `json:"id"`
+	Title              string                        `json:"title"`
+	Description        string                        `json:"description"`
+	Slug               string                        `json:"slug"`
+	CategoryID         *uuid.UUID                    `json:"category_id"`
+	TagIDs             []uuid.UUID                   `json:"tag_ids"` // Đổi tên từ tag_id thành tag_ids
+	TagNames           []string                      `json:"tag_names,omitempty"`
+	IsActive           bool                          `json:"is_active"`
+	IsHot              bool                          `json:"is_hot"`
+	Status             string                        `json:"status"`
+	PublishedAt        *time.Time                    `json:"published_at"`
+	Metadata           json.RawMessage               `json:"metadata,omitempty"`
+	Content            json.RawMessage               `json:"content,omitempty"`
+	AuthorID           uuid.UUID                     `json:"author_id"`
+	ViewCount          int                           `json:"view_count"`
+	CommentCount       int                           `json:"comment_count"`
+	Author             *AuthorResponse               `json:"author,omitempty"`
+	CoAuthors          []AuthorResponse              `json:"co_authors,omitempty"`
+	ReviewedBy         *AuthorResponse               `json:"reviewed_by,omitempty"`
+	ReviewedAt         *time.Time                    `json:"reviewed_at,omitempty"`
+	LastReviewedAt     *time.Time                    `json:"last_reviewed_at,omitempty"` // Lần rà soát pháp lý gần nhất
+	LegalBasis         []LegalReference              `json:"legal_basis,omitempty"`
+	LegalDocuments     []LegalDocumentSimpleResponse `json:"legal_documents,omitempty"` // Văn bản trong thư viện được trích dẫn (trang chi tiết công khai)
+	LegalReview        *ArticleLegalReviewResponse   `json:"legal_review,omitempty"`    // Trạng thái rà soát (chỉ có ở API admin)
+	FAQs               []FAQResponse                 `json:"faqs,omitempty"`            // Câu hỏi thường gặp nhúng trong bài (trang chi tiết công khai)
+	Attachments        []ArticleAttachmentResponse   `json:"attachments,omitempty"`
+	Category           *CategorySimpleResponse       `json:"category,omitempty"`
+	Series             *ArticleSeriesPosition        `json:"series,omitempty"`
+	Locale             string                        `json:"locale"`
+	TranslationGroupID *uuid.UUID                    `json:"translation_group_id"`
+	Translations       []TranslationLink             `json:"translations,omitempty"`
+	JSONLD             map[string]interface{}        `json:"json_ld,omitempty"` // Structured data schema.org (chỉ có ở trang chi tiết công khai)
+	CreatedAt          time.Time                     `json:"created_at"`
+	UpdatedAt          time.Time                     `json:"updated_at"`
+}
+
+// IsPublished kiểm tra bài viết đã xuất bản, đang hoạt động và đã tới giờ xuất bản (không phải bài đặt lịch)
+func (a *Article) IsPublished() bool {
+	if a.PublishedAt != nil && a.PublishedAt.After(time.Now()) {
+		return false
+	}
+	return consts.IsPublishedArticleStatus(a.Status) && a.IsActive
 }
 
 // GetTagIDs trả về danh sách UUID của tags từ JSON
@@ -125,20 +173,23 @@ func (a *Article) SetTagIDs(tagIDs []uuid.UUID) error {
 
 func (a *Article) ToResponse() ArticleResponse {
 	response := ArticleResponse{
-		ID:          a.ID,
-		Title:       a.Title,
-		Description: a.Description,
-		Slug:        a.Slug,
-		CategoryID:  a.CategoryID,
-		TagIDs:      a.GetTagIDs(), // Sử dụng method mới
-		IsActive:    a.IsActive,
-		IsHot:       a.IsHot,
-		Status:      a.Status,
-		PublishedAt: a.PublishedAt,
-		AuthorID:    a.AuthorID,
-		ViewCount:   a.ViewCount,
-		CreatedAt:   a.CreatedAt,
-		UpdatedAt:   a.UpdatedAt,
+		ID:                 a.ID,
+		Title:              a.Title,
+		Description:        a.Description,
+		Slug:               a.Slug,
+		CategoryID:         a.CategoryID,
+		TagIDs:             a.GetTagIDs(), // Sử dụng method mới
+		IsActive:           a.IsActive,
+		IsHot:              a.IsHot,
+		Status:             a.Status,
+		PublishedAt:        a.PublishedAt,
+		AuthorID:           a.AuthorID,
+		ViewCount:          a.ViewCount,
+		CommentCount:       a.CommentCount,
+		Locale:             a.Locale,
+		TranslationGroupID: a.TranslationGroupID,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
 	}
 
 	// Preserve metadata/content as-is
@@ -150,14 +201,26 @@ func (a *Article) ToResponse() ArticleResponse {
 		response.Content = json.RawMessage(a.Content)
 	}
 
-	// Include author info
+	// Include author info (ưu tiên hồ sơ công khai nếu đang hoạt động)
 	if a.Author != nil {
-		response.Author = &AuthorResponse{
-			ID:       a.Author.ID,
-			FullName: a.Author.FullName,
-			Email:    a.Author.Email,
+		response.Author = a.Author.ToAuthorResponse()
+	}
+
+	// Đồng tác giả (bỏ qua hồ sơ đã ẩn) và người thẩm định
+	for _, coAuthor := range a.CoAuthors {
+		if coAuthor.AuthorProfile != nil && coAuthor.AuthorProfile.IsActive {
+			response.CoAuthors = append(response.CoAuthors, *coAuthor.AuthorProfile.ToAuthorResponse())
 		}
 	}
+	if a.ReviewedBy != nil && a.ReviewedBy.IsActive {
+		response.ReviewedBy = a.ReviewedBy.ToAuthorResponse()
+		response.ReviewedAt = a.ReviewedAt
+	}
+	response.LastReviewedAt = a.ReviewedAt
+	if legalBasis := a.GetLegalBasis(); len(legalBasis) > 0 {
+		response.LegalBasis = legalBasis
+	}
+	response.Attachments = a.attachmentResponses()
 
 	// Include category info
 	if a.Category != nil {
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ArticleAttachment - Tệp đính kèm bài viết (mẫu hợp đồng, biểu mẫu...) chọn từ thư viện media.
+// Key, FileName, ContentType, Size do server điền từ thư viện khi lưu; Key giúp chỉ mục sử dụng file nhận ra tệp
+type ArticleAttachment struct {
+	MediaID         uuid.UUID `json:"media_id" binding:"required"`
+	Title           string    `json:"title" binding:"max=255"`
+	SubscribersOnly bool      `json:"subscribers_only"` // Chỉ người đăng ký bản tin (hoặc quản trị) tải được; file phải ở chế độ riêng tư
+	Key             string    `json:"key,omitempty"`
+	FileName        string    `json:"file_name,omitempty"`
+	ContentType     string    `json:"content_type,omitempty"`
+	Size            int64     `json:"size,omitempty"`
+}
+
+// ArticleAttachmentResponse - Tệp đính kèm trả về cho client, chỉ tải qua endpoint của backend
+type ArticleAttachmentResponse struct {
+	MediaID         uuid.UUID `json:"media_id"`
+	Title           string    `json:"title"`
+	FileName        string    `json:"file_name"`
+	ContentType     string    `json:"content_type"`
+	Size            int64     `json:"size"`
+	SubscribersOnly bool      `json:"subscribers_only"`
+	DownloadURL     string    `json:"download_url"`
+}
+
+// GetAttachments giải mã danh sách tệp đính kèm
+func (a *Article) GetAttachments() []ArticleAttachment {
+	attachments := []ArticleAttachment{}
+	if len(a.Attachments) > 0 {
+		_ = json.Unmarshal(a.Attachments, &attachments)
+	}
+	return attachments
+}
+
+// SetAttachments lưu danh sách tệp đính kèm
+func (a *Article) SetAttachments(attachments []ArticleAttachment) {
+	if attachments == nil {
+		attachments = []ArticleAttachment{}
+	}
+	bytes, _ := json.Marshal(attachments)
+	a.Attachments = datatypes.JSON(bytes)
+}
+
+// FindAttachment tìm tệp đính kèm theo ID file
+func (a *Article) FindAttachment(mediaID uuid.UUID) (ArticleAttachment, bool) {
+	for _, attachment := range a.GetAttachments() {
+		if attachment.MediaID == mediaID {
+			return attachment, true
+		}
+	}
+	return ArticleAttachment{}, false
+}
+
+// AttachmentDownloadPath - Endpoint tải tệp đính kèm (kiểm tra quyền theo bài viết)
+func (a *Article) AttachmentDownloadPath(mediaID uuid.UUID) string {
+	return "/api/articles/" + a.Slug + "/attachments/" + mediaID.String()
+}
+
+func (a *Article) attachmentResponses() []ArticleAttachmentResponse {
+	attachments := a.GetAttachments()
+	if len(attachments) == 0 {
+		return nil
+	}
+	responses := make([]ArticleAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		responses = append(responses, ArticleAttachmentResponse{
+			MediaID:         attachment.MediaID,
+			Title:           attachment.Title,
+			FileName:        attachment.FileName,
+			ContentType:     attachment.ContentType,
+			Size:            attachment.Size,
+			SubscribersOnly: attachment.SubscribersOnly,
+			DownloadURL:     a.AttachmentDownloadPath(attachment.MediaID),
+		})
+	}
+	return responses
+}
+
+// AttachmentLinkInput - Người đăng ký bản tin xin link tải tệp qua email
+type AttachmentLinkInput struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleCoAuthor - Đồng tác giả của bài viết (hồ sơ tác giả), theo thứ tự hiển thị
+type ArticleCoAuthor struct {
+	ID              uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID       uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_co_author"`
+	AuthorProfileID uuid.UUID `json:"author_profile_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_co_author;index"`
+	Position        int       `json:"position" gorm:"default:0"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	AuthorProfile *AuthorProfile `json:"author_profile,omitempty" gorm:"foreignKey:AuthorProfileID"`
+}
+
+func (ArticleCoAuthor) TableName() string {
+	return "article_co_authors"
+}
+
+func (a *ArticleCoAuthor) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// ArticleAttributionInput - Đồng tác giả (theo thứ tự) và người thẩm định pháp lý của bài viết
+type ArticleAttributionInput struct {
+	CoAuthorIDs  []uuid.UUID `json:"co_author_ids"`  // ID hồ sơ tác giả
+	ReviewedByID *uuid.UUID  `json:"reviewed_by_id"` // ID hồ sơ tác giả, null để bỏ thẩm định
+	ReviewedAt   *time.Time  `json:"reviewed_at"`    // Mặc định là thời điểm hiện tại khi có người thẩm định
+}
+
+// ArticleAuthorInput - Chuyển tác giả chính của bài viết sang User khác
+type ArticleAuthorInput struct {
+	AuthorID uuid.UUID `json:"author_id" binding:"required"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleRelation - Bài viết liên quan được admin ghim thủ công cho một bài viết
+type ArticleRelation struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_related"`
+	RelatedID uuid.UUID `json:"related_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_related;index"`
+	Position  int       `json:"position" gorm:"default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Related *Article `json:"related,omitempty" gorm:"foreignKey:RelatedID"`
+}
+
+func (ArticleRelation) TableName() string {
+	return "article_relations"
+}
+
+func (r *ArticleRelation) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// PinnedRelatedInput - Danh sách bài viết liên quan được ghim (theo thứ tự hiển thị)
+type PinnedRelatedInput struct {
+	RelatedIDs []uuid.UUID `json:"related_ids"`
+}
+
+// RelatedArticleResponse - Bài viết liên quan kèm điểm số gợi ý
+type RelatedArticleResponse struct {
+	ArticleResponse
+	Score  float64 `json:"score"`
+	Pinned bool    `json:"pinned"`
+}
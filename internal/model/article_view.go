@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleViewDaily - Số lượt xem thô của bài viết theo ngày; Article.ViewCount là tổng dẫn xuất từ bảng này
+type ArticleViewDaily struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_view_daily_date"`
+	ViewDate  time.Time `json:"view_date" gorm:"type:date;not null;uniqueIndex:idx_article_view_daily_date;index"`
+	Views     int64     `json:"views" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (ArticleViewDaily) TableName() string {
+	return "article_view_daily"
+}
+
+func (v *ArticleViewDaily) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
+
+// ArticleViewPoint - Một điểm dữ liệu lượt xem theo ngày
+type ArticleViewPoint struct {
+	Date  string `json:"date"` // YYYY-MM-DD
+	Views int64  `json:"views"`
+}
+
+// ArticleViewStats - Lượt xem của một bài viết trong khoảng thời gian
+type ArticleViewStats struct {
+	ArticleID uuid.UUID          `json:"article_id"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Views     int64              `json:"views"`      // Tổng trong khoảng thời gian
+	ViewCount int                `json:"view_count"` // Tổng mọi thời điểm
+	Daily     []ArticleViewPoint `json:"daily"`
+}
+
+// TopViewedArticle - Bài viết có nhiều lượt xem nhất trong khoảng thời gian
+type TopViewedArticle struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Views     int64     `json:"views"`
+	ViewCount int       `json:"view_count"`
+}
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AuthorProfile - Hồ sơ công khai của luật sư/thành viên, có thể gắn với User hoặc đứng riêng
+type AuthorProfile struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	UserID          *uuid.UUID     `json:"user_id" gorm:"type:char(36);uniqueIndex"` // Mỗi User có tối đa một hồ sơ
+	FullName        string         `json:"full_name" gorm:"not null;size:255"`
+	Slug            string         `json:"slug" gorm:"unique;not null;size:255;index"`
+	Title           string         `json:"title" gorm:"size:255"`      // Chức danh: Luật sư thành viên, Cộng sự...
+	BarNumber       string         `json:"bar_number" gorm:"size:100"` // Số thẻ luật sư
+	Photo           datatypes.JSON `json:"photo" gorm:"type:json"`     // Avatar {url, alt}
+	Bio             string         `json:"bio" gorm:"type:text"`
+	PracticeAreaIDs datatypes.JSON `json:"practice_area_ids" gorm:"type:json"` // Mảng UUID danh mục (lĩnh vực hành nghề)
+	SocialLinks     datatypes.JSON `json:"social_links" gorm:"type:json"`      // Mảng SocialLink
+	IsActive        bool           `json:"is_active" gorm:"default:true;index"`
+	DisplayOrder    int            `json:"display_order" gorm:"default:0;index"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (AuthorProfile) TableName() string {
+	return "author_profiles"
+}
+
+func (p *AuthorProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// SocialLink - Liên kết mạng xã hội của tác giả
+type SocialLink struct {
+	Platform string `json:"platform" binding:"required,max=50"` // facebook, linkedin, zalo...
+	URL      string `json:"url" binding:"required,url,max=500"`
+}
+
+type AuthorProfileInput struct {
+	UserID          *uuid.UUID   `json:"user_id"`
+	FullName        string       `json:"full_name" binding:"required,min=1,max=255"`
+	Slug            string       `json:"slug" binding:"required,min=1,max=255"`
+	Title           string       `json:"title" binding:"max=255"`
+	BarNumber       string       `json:"bar_number" binding:"max=100"`
+	Photo           *Avatar      `json:"photo"`
+	Bio             string       `json:"bio"`
+	PracticeAreaIDs []uuid.UUID  `json:"practice_area_ids"`
+	SocialLinks     []SocialLink `json:"social_links" binding:"dive"`
+	IsActive        *bool        `json:"is_active"`
+	DisplayOrder    int          `json:"display_order"`
+}
+
+// AuthorProfileResponse - Hồ sơ tác giả (không bao gồm email/số điện thoại của User)
+type AuthorProfileResponse struct {
+	ID              uuid.UUID                `json:"id"`
+	UserID          *uuid.UUID               `json:"user_id,omitempty"`
+	FullName        string                   `json:"full_name"`
+	Slug            string                   `json:"slug"`
+	Title           string                   `json:"title"`
+	BarNumber       string                   `json:"bar_number"`
+	Photo           *Avatar                  `json:"photo"`
+	Bio             string                   `json:"bio"`
+	PracticeAreaIDs []uuid.UUID              `json:"practice_area_ids"`
+	PracticeAreas   []CategorySimpleResponse `json:"practice_areas,omitempty"`
+	SocialLinks     []SocialLink             `json:"social_links"`
+	IsActive        bool                     `json:"is_active"`
+	DisplayOrder    int                      `json:"display_order"`
+	ArticleCount    int64                    `json:"article_count"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+}
+
+// GetPhoto giải mã ảnh đại diện
+func (p *AuthorProfile) GetPhoto() *Avatar {
+	if len(p.Photo) == 0 {
+		return nil
+	}
+	var photo Avatar
+	if err := json.Unmarshal(p.Photo, &photo); err != nil || photo.URL == "" {
+		return nil
+	}
+	return &photo
+}
+
+// GetPracticeAreaIDs trả về danh sách UUID lĩnh vực hành nghề
+func (p *AuthorProfile) GetPracticeAreaIDs() []uuid.UUID {
+	ids := []uuid.UUID{}
+	if len(p.PracticeAreaIDs) > 0 {
+		_ = json.Unmarshal(p.PracticeAreaIDs, &ids)
+	}
+	return ids
+}
+
+// GetSocialLinks giải mã danh sách liên kết mạng xã hội
+func (p *AuthorProfile) GetSocialLinks() []SocialLink {
+	links := []SocialLink{}
+	if len(p.SocialLinks) > 0 {
+		_ = json.Unmarshal(p.SocialLinks, &links)
+	}
+	return links
+}
+
+// ApplyInput gán dữ liệu từ input vào hồ sơ
+func (p *AuthorProfile) ApplyInput(input AuthorProfileInput) {
+	p.UserID = input.UserID
+	p.FullName = input.FullName
+	p.Slug = input.Slug
+	p.Title = input.Title
+	p.BarNumber = input.BarNumber
+	p.Bio = input.Bio
+	p.DisplayOrder = input.DisplayOrder
+	if input.IsActive != nil {
+		p.IsActive = *input.IsActive
+	}
+
+	p.Photo = nil
+	if input.Photo != nil && input.Photo.URL != "" {
+		photo, _ := json.Marshal(input.Photo)
+		p.Photo = datatypes.JSON(photo)
+	}
+
+	if input.PracticeAreaIDs == nil {
+		input.PracticeAreaIDs = []uuid.UUID{}
+	}
+	areas, _ := json.Marshal(input.PracticeAreaIDs)
+	p.PracticeAreaIDs = datatypes.JSON(areas)
+
+	if input.SocialLinks == nil {
+		input.SocialLinks = []SocialLink{}
+	}
+	links, _ := json.Marshal(input.SocialLinks)
+	p.SocialLinks = datatypes.JSON(links)
+}
+
+func (p *AuthorProfile) ToResponse() AuthorProfileResponse {
+	return AuthorProfileResponse{
+		ID:              p.ID,
+		UserID:          p.UserID,
+		FullName:        p.FullName,
+		Slug:            p.Slug,
+		Title:           p.Title,
+		BarNumber:       p.BarNumber,
+		Photo:           p.GetPhoto(),
+		Bio:             p.Bio,
+		PracticeAreaIDs: p.GetPracticeAreaIDs(),
+		SocialLinks:     p.GetSocialLinks(),
+		IsActive:        p.IsActive,
+		DisplayOrder:    p.DisplayOrder,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// ToAuthorResponse - Thông tin tác giả gọn để nhúng vào bài viết
+func (p *AuthorProfile) ToAuthorResponse() *AuthorResponse {
+	profileID := p.ID
+	return &AuthorResponse{
+		ID:        p.UserID,
+		ProfileID: &profileID,
+		FullName:  p.FullName,
+		Slug:      p.Slug,
+		Title:     p.Title,
+		Photo:     p.GetPhoto(),
+	}
+}
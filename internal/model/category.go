@@ -10,22 +10,24 @@ import (
 )
 
 type Category struct {
-	ID             uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
-	Name           string         `json:"name" gorm:"not null;size:255;index"`
-	Description    string         `json:"description" gorm:"type:text"`
-	Slug           string         `json:"slug" gorm:"unique;not null;size:255;index"`
-	IsActive       bool           `json:"is_active" gorm:"default:true;index"`
-	DisplayOrder   int            `json:"display_order" gorm:"default:0;index"`
-	ShowOnMenu     bool           `json:"show_menu" gorm:"default:false"`
-	ShowOnHome     bool           `json:"show_home" gorm:"default:false"`
-	ShowOnFooter   bool           `json:"show_footer" gorm:"default:false"`
-	PositionMenu   int            `json:"position_menu" gorm:"default:0;index"`
-	PositionFooter int            `json:"position_footer" gorm:"default:0;index"`
-	PositionHome   int            `json:"position_home" gorm:"default:0;index"`
-	Metadata       datatypes.JSON `json:"metadata" gorm:"type:json"`
-	CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Name               string         `json:"name" gorm:"not null;size:255;index"`
+	Description        string         `json:"description" gorm:"type:text"`
+	Slug               string         `json:"slug" gorm:"unique;not null;size:255;index"`
+	IsActive           bool           `json:"is_active" gorm:"default:true;index"`
+	DisplayOrder       int            `json:"display_order" gorm:"default:0;index"`
+	ShowOnMenu         bool           `json:"show_menu" gorm:"default:false"`
+	ShowOnHome         bool           `json:"show_home" gorm:"default:false"`
+	ShowOnFooter       bool           `json:"show_footer" gorm:"default:false"`
+	PositionMenu       int            `json:"position_menu" gorm:"default:0;index"`
+	PositionFooter     int            `json:"position_footer" gorm:"default:0;index"`
+	PositionHome       int            `json:"position_home" gorm:"default:0;index"`
+	Metadata           datatypes.JSON `json:"metadata" gorm:"type:json"`
+	Locale             string         `json:"locale" gorm:"type:varchar(10);default:'vi';index"`
+	TranslationGroupID *uuid.UUID     `json:"translation_group_id" gorm:"type:char(36);index"` // Các bản dịch của cùng nội dung có chung group
+	CreatedAt          time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 
 	ParentID *uuid.UUID `json:"parent_id,omitempty" gorm:"type:char(36);index"`
 	Parent   *Category  `json:"parent_category,omitempty" gorm:"foreignKey:ParentID"`
@@ -56,6 +58,7 @@ func (c *Category) BeforeCreate(tx *gorm.DB) (err error) {
 	if c.ID == uuid.Nil {
 		c.ID = uuid.New()
 	}
+	ensureTranslationGroup(c.ID, &c.TranslationGroupID)
 	return
 }
 
@@ -73,26 +76,31 @@ type CategoryInput struct {
 	PositionHome   *int              `json:"position_home,omitempty"`
 	Metadata       *CategoryMetadata `json:"metadata,omitempty"`
 	ParentCategory *string           `json:"parent_category,omitempty"`
+	Locale         string            `json:"locale,omitempty"`
+	TranslationOf  *uuid.UUID        `json:"translation_of,omitempty"`
 }
 
 type CategoryResponse struct {
-	ID             uuid.UUID         `json:"id"`
-	Name           string            `json:"name"`
-	Description    string            `json:"description"`
-	Slug           string            `json:"slug"`
-	DisplayOrder   int               `json:"display_order"`
-	IsActive       bool              `json:"is_active"`
-	ShowOnMenu     bool              `json:"show_menu"`
-	ShowOnHome     bool              `json:"show_home"`
-	ShowOnFooter   bool              `json:"show_footer"`
-	PositionMenu   int               `json:"position_menu"`
-	PositionFooter int               `json:"position_footer"`
-	PositionHome   int               `json:"position_home"`
-	Metadata       *CategoryMetadata `json:"metadata"` // Đổi từ datatypes.JSON sang *CategoryMetadata
-	Articles       []ArticleSummary  `json:"articles,omitempty"`
-	CreatedAt      time.Time         `json:"created_at"`
-	UpdatedAt      time.Time         `json:"updated_at"`
-	ParentCategory *struct {
+	ID                 uuid.UUID         `json:"id"`
+	Name               string            `json:"name"`
+	Description        string            `json:"description"`
+	Slug               string            `json:"slug"`
+	DisplayOrder       int               `json:"display_order"`
+	IsActive           bool              `json:"is_active"`
+	ShowOnMenu         bool              `json:"show_menu"`
+	ShowOnHome         bool              `json:"show_home"`
+	ShowOnFooter       bool              `json:"show_footer"`
+	PositionMenu       int               `json:"position_menu"`
+	PositionFooter     int               `json:"position_footer"`
+	PositionHome       int               `json:"position_home"`
+	Metadata           *CategoryMetadata `json:"metadata"` // Đổi từ datatypes.JSON sang *CategoryMetadata
+	Locale             string            `json:"locale"`
+	TranslationGroupID *uuid.UUID        `json:"translation_group_id"`
+	Translations       []TranslationLink `json:"translations,omitempty"`
+	Articles           []ArticleSummary  `json:"articles,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	ParentCategory     *struct {
 		ID   uuid.UUID `json:"id"`
 		Name string    `json:"name"`
 	} `json:"parent_category,omitempty"`
@@ -116,20 +124,22 @@ type ArticleSummary struct {
 // ToResponse chuyển Category thành CategoryResponse
 func (c *Category) ToResponse() CategoryResponse {
 	response := CategoryResponse{
-		ID:             c.ID,
-		Name:           c.Name,
-		Description:    c.Description,
-		Slug:           c.Slug,
-		DisplayOrder:   c.DisplayOrder,
-		IsActive:       c.IsActive,
-		ShowOnMenu:     c.ShowOnMenu,
-		ShowOnHome:     c.ShowOnHome,
-		ShowOnFooter:   c.ShowOnFooter,
-		PositionMenu:   c.PositionMenu,
-		PositionFooter: c.PositionFooter,
-		PositionHome:   c.PositionHome,
-		CreatedAt:      c.CreatedAt,
-		UpdatedAt:      c.UpdatedAt,
+		ID:                 c.ID,
+		Name:               c.Name,
+		Description:        c.Description,
+		Slug:               c.Slug,
+		DisplayOrder:       c.DisplayOrder,
+		IsActive:           c.IsActive,
+		ShowOnMenu:         c.ShowOnMenu,
+		ShowOnHome:         c.ShowOnHome,
+		ShowOnFooter:       c.ShowOnFooter,
+		PositionMenu:       c.PositionMenu,
+		PositionFooter:     c.PositionFooter,
+		PositionHome:       c.PositionHome,
+		Locale:             c.Locale,
+		TranslationGroupID: c.TranslationGroupID,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
 	}
 
 	// Parse metadata từ JSON sang struct
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleComment - Bình luận của độc giả trên bài viết (trả lời tối đa một cấp)
+type ArticleComment struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID     uuid.UUID      `json:"article_id" gorm:"type:char(36);not null;index"`
+	ParentID      *uuid.UUID     `json:"parent_id" gorm:"type:char(36);index"` // Chỉ trỏ tới bình luận gốc
+	AuthorName    string         `json:"author_name" gorm:"not null;size:100"`
+	AuthorEmail   string         `json:"author_email" gorm:"not null;size:255;index"`
+	Content       string         `json:"content" gorm:"type:text;not null"`
+	Status        string         `json:"status" gorm:"size:20;default:'pending';index"` // pending, approved, rejected, spam
+	LinkCount     int            `json:"link_count" gorm:"default:0"`
+	IPAddress     string         `json:"ip_address" gorm:"size:64"`
+	UserAgent     string         `json:"user_agent" gorm:"size:500"`
+	ModeratedByID *uuid.UUID     `json:"moderated_by_id" gorm:"type:char(36)"`
+	ModeratedAt   *time.Time     `json:"moderated_at"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Article *Article         `json:"article,omitempty" gorm:"foreignKey:ArticleID"`
+	Replies []ArticleComment `json:"replies,omitempty" gorm:"foreignKey:ParentID"`
+}
+
+func (ArticleComment) TableName() string {
+	return "article_comments"
+}
+
+func (c *ArticleComment) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// CommentInput - Form bình luận công khai
+type CommentInput struct {
+	AuthorName  string     `json:"author_name" binding:"required,min=2,max=100"`
+	AuthorEmail string     `json:"author_email" binding:"required,email,max=255"`
+	Content     string     `json:"content" binding:"required,min=2,max=3000"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	Website     string     `json:"website"` // Honeypot: trường ẩn, người thật luôn để trống
+}
+
+// CommentStatusInput - Duyệt, từ chối hoặc đánh dấu spam
+type CommentStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=pending approved rejected spam"`
+}
+
+// PublicCommentResponse - Bình luận hiển thị công khai (không có email, IP)
+type PublicCommentResponse struct {
+	ID         uuid.UUID               `json:"id"`
+	ParentID   *uuid.UUID              `json:"parent_id,omitempty"`
+	AuthorName string                  `json:"author_name"`
+	Content    string                  `json:"content"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Replies    []PublicCommentResponse `json:"replies,omitempty"`
+}
+
+// CommentResponse - Bình luận trong hàng đợi kiểm duyệt (admin)
+type CommentResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ArticleID    uuid.UUID  `json:"article_id"`
+	ArticleTitle string     `json:"article_title,omitempty"`
+	ArticleSlug  string     `json:"article_slug,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id"`
+	AuthorName   string     `json:"author_name"`
+	AuthorEmail  string     `json:"author_email"`
+	Content      string     `json:"content"`
+	Status       string     `json:"status"`
+	LinkCount    int        `json:"link_count"`
+	IPAddress    string     `json:"ip_address"`
+	ModeratedAt  *time.Time `json:"moderated_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (c *ArticleComment) ToPublicResponse() PublicCommentResponse {
+	response := PublicCommentResponse{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		AuthorName: c.AuthorName,
+		Content:    c.Content,
+		CreatedAt:  c.CreatedAt,
+	}
+	for i := range c.Replies {
+		response.Replies = append(response.Replies, c.Replies[i].ToPublicResponse())
+	}
+	return response
+}
+
+func (c *ArticleComment) ToResponse() CommentResponse {
+	response := CommentResponse{
+		ID:          c.ID,
+		ArticleID:   c.ArticleID,
+		ParentID:    c.ParentID,
+		AuthorName:  c.AuthorName,
+		AuthorEmail: c.AuthorEmail,
+		Content:     c.Content,
+		Status:      c.Status,
+		LinkCount:   c.LinkCount,
+		IPAddress:   c.IPAddress,
+		ModeratedAt: c.ModeratedAt,
+		CreatedAt:   c.CreatedAt,
+	}
+	if c.Article != nil {
+		response.ArticleTitle = c.Article.Title
+		response.ArticleSlug = c.Article.Slug
+	}
+	return response
+}
@@ -0,0 +1,187 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ConsultationRequest - Yêu cầu tư vấn pháp lý gửi từ website
+type ConsultationRequest struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	FullName     string         `json:"full_name" gorm:"not null;size:255"`
+	Phone        string         `json:"phone" gorm:"not null;size:20;index"`
+	Email        string         `json:"email" gorm:"size:255;index"`
+	CategoryID   *uuid.UUID     `json:"category_id" gorm:"type:char(36);index"` // Lĩnh vực cần tư vấn
+	Message      string         `json:"message" gorm:"type:text;not null"`
+	Attachments  datatypes.JSON `json:"attachments" gorm:"type:json"` // Mảng ConsultationAttachment (key S3)
+	Status       string         `json:"status" gorm:"size:20;default:'new';index"`
+	AssignedToID *uuid.UUID     `json:"assigned_to_id" gorm:"type:char(36);index"`
+	Locale       string         `json:"locale" gorm:"type:varchar(10);default:'vi'"`
+	IPAddress    string         `json:"ip_address" gorm:"size:64"`
+	UserAgent    string         `json:"user_agent" gorm:"size:500"`
+	ContactedAt  *time.Time     `json:"contacted_at"`
+	ClosedAt     *time.Time     `json:"closed_at"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Category   *Category          `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	AssignedTo *User              `json:"assigned_to,omitempty" gorm:"foreignKey:AssignedToID"`
+	Notes      []ConsultationNote `json:"notes,omitempty" gorm:"foreignKey:ConsultationID"`
+}
+
+func (ConsultationRequest) TableName() string {
+	return "consultation_requests"
+}
+
+func (r *ConsultationRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// ConsultationNote - Ghi chú nội bộ của nhân viên trên một yêu cầu tư vấn
+type ConsultationNote struct {
+	ID             uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ConsultationID uuid.UUID `json:"consultation_id" gorm:"type:char(36);not null;index"`
+	AuthorID       uuid.UUID `json:"author_id" gorm:"type:char(36);not null;index"`
+	Content        string    `json:"content" gorm:"type:text;not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Author *User `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+func (ConsultationNote) TableName() string {
+	return "consultation_notes"
+}
+
+func (n *ConsultationNote) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
+
+// ConsultationAttachment - File đính kèm đã upload qua /api/upload/s3
+type ConsultationAttachment struct {
+	Key         string `json:"key" binding:"required,max=500"`
+	Name        string `json:"name" binding:"max=255"`
+	ContentType string `json:"content_type" binding:"max=100"`
+	Size        int64  `json:"size" binding:"gte=0"`
+}
+
+// ConsultationInput - Form yêu cầu tư vấn công khai
+type ConsultationInput struct {
+	FullName    string                   `json:"full_name" binding:"required,min=2,max=255"`
+	Phone       string                   `json:"phone" binding:"required,min=8,max=20"`
+	Email       string                   `json:"email" binding:"omitempty,email,max=255"`
+	CategoryID  *uuid.UUID               `json:"category_id"`
+	Message     string                   `json:"message" binding:"required,min=10,max=5000"`
+	Attachments []ConsultationAttachment `json:"attachments" binding:"max=5,dive"`
+	Website     string                   `json:"website"` // Honeypot: trường ẩn, người thật luôn để trống
+}
+
+// ConsultationStatusInput - Cập nhật trạng thái yêu cầu tư vấn
+type ConsultationStatusInput struct {
+	Status string `json:"status" binding:"required,oneof=new assigned contacted closed"`
+}
+
+// ConsultationAssignInput - Giao yêu cầu cho nhân viên (null để bỏ giao)
+type ConsultationAssignInput struct {
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+}
+
+// ConsultationNoteInput - Thêm ghi chú nội bộ
+type ConsultationNoteInput struct {
+	Content string `json:"content" binding:"required,min=1,max=5000"`
+}
+
+// ConsultationUserSummary - Thông tin gọn của nhân viên
+type ConsultationUserSummary struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+}
+
+type ConsultationNoteResponse struct {
+	ID        uuid.UUID                `json:"id"`
+	Content   string                   `json:"content"`
+	Author    *ConsultationUserSummary `json:"author,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+type ConsultationResponse struct {
+	ID           uuid.UUID                  `json:"id"`
+	FullName     string                     `json:"full_name"`
+	Phone        string                     `json:"phone"`
+	Email        string                     `json:"email"`
+	CategoryID   *uuid.UUID                 `json:"category_id"`
+	CategoryName string                     `json:"category_name,omitempty"`
+	Message      string                     `json:"message"`
+	Attachments  []ConsultationAttachment   `json:"attachments"`
+	Status       string                     `json:"status"`
+	AssignedTo   *ConsultationUserSummary   `json:"assigned_to,omitempty"`
+	Locale       string                     `json:"locale"`
+	IPAddress    string                     `json:"ip_address"`
+	ContactedAt  *time.Time                 `json:"contacted_at"`
+	ClosedAt     *time.Time                 `json:"closed_at"`
+	Notes        []ConsultationNoteResponse `json:"notes,omitempty"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
+}
+
+func userSummary(u *User) *ConsultationUserSummary {
+	if u == nil {
+		return nil
+	}
+	return &ConsultationUserSummary{ID: u.ID, FullName: u.FullName, Email: u.Email}
+}
+
+// GetAttachments giải mã danh sách file đính kèm
+func (r *ConsultationRequest) GetAttachments() []ConsultationAttachment {
+	attachments := []ConsultationAttachment{}
+	if len(r.Attachments) > 0 {
+		_ = json.Unmarshal(r.Attachments, &attachments)
+	}
+	return attachments
+}
+
+func (r *ConsultationRequest) ToResponse() ConsultationResponse {
+	response := ConsultationResponse{
+		ID:          r.ID,
+		FullName:    r.FullName,
+		Phone:       r.Phone,
+		Email:       r.Email,
+		CategoryID:  r.CategoryID,
+		Message:     r.Message,
+		Attachments: r.GetAttachments(),
+		Status:      r.Status,
+		AssignedTo:  userSummary(r.AssignedTo),
+		Locale:      r.Locale,
+		IPAddress:   r.IPAddress,
+		ContactedAt: r.ContactedAt,
+		ClosedAt:    r.ClosedAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+
+	if r.Category != nil {
+		response.CategoryName = r.Category.Name
+	}
+
+	for _, note := range r.Notes {
+		response.Notes = append(response.Notes, ConsultationNoteResponse{
+			ID:        note.ID,
+			Content:   note.Content,
+			Author:    userSummary(note.Author),
+			CreatedAt: note.CreatedAt,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DashboardSummary - Số liệu tổng quan nội dung trong kỳ, kèm % tăng trưởng so với kỳ liền trước
+type DashboardSummary struct {
+	PublishedInPeriod   int64   `json:"published_in_period"`
+	PublishedGrowth     float64 `json:"published_growth"`
+	ViewsInPeriod       int64   `json:"views_in_period"`
+	ViewsGrowth         float64 `json:"views_growth"`
+	TotalPublished      int64   `json:"total_published"`
+	TotalDrafts         int64   `json:"total_drafts"`
+	ScheduledCount      int64   `json:"scheduled_count"`
+	StaleCount          int64   `json:"stale_count"`
+	PendingComments     int64   `json:"pending_comments"`
+	NewConsultations    int64   `json:"new_consultations"`
+	AverageViewsPerPost float64 `json:"average_views_per_post"` // Lượt xem trong kỳ / số bài xuất bản trong kỳ
+}
+
+// PublishingByTime - Số bài xuất bản theo kỳ (ngày/tuần/tháng/năm)
+type PublishingByTime struct {
+	Period    string `json:"period"`
+	Published int64  `json:"published"`
+}
+
+// ViewsByTime - Lượt xem theo kỳ
+type ViewsByTime struct {
+	Period string `json:"period"`
+	Views  int64  `json:"views"`
+}
+
+// DashboardArticle - Bài viết trong các bảng xếp hạng/danh sách của dashboard
+type DashboardArticle struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Status      string     `json:"status"`
+	AuthorName  string     `json:"author_name"`
+	Views       int64      `json:"views"`      // Lượt xem trong kỳ
+	ViewCount   int        `json:"view_count"` // Tổng mọi thời điểm
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	AgeDays     int        `json:"age_days,omitempty"` // Số ngày kể từ lần cập nhật cuối (bản nháp, bài cũ)
+}
+
+// CategoryContentStats - Thống kê nội dung theo danh mục
+type CategoryContentStats struct {
+	CategoryID   uuid.UUID `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	Published    int64     `json:"published"` // Số bài xuất bản trong kỳ
+	Views        int64     `json:"views"`     // Lượt xem trong kỳ
+}
+
+// TagContentStats - Thống kê nội dung theo tag
+type TagContentStats struct {
+	TagID     uuid.UUID `json:"tag_id"`
+	TagName   string    `json:"tag_name"`
+	Published int64     `json:"published"`
+	Views     int64     `json:"views"`
+}
+
+// AuthorProductivity - Năng suất tác giả trong kỳ
+type AuthorProductivity struct {
+	AuthorID      uuid.UUID  `json:"author_id"`
+	FullName      string     `json:"full_name"`
+	Published     int64      `json:"published"`      // Bài xuất bản trong kỳ
+	Drafts        int64      `json:"drafts"`         // Bản nháp hiện có
+	Updated       int64      `json:"updated"`        // Bài được cập nhật trong kỳ
+	Views         int64      `json:"views"`          // Lượt xem trong kỳ trên các bài của tác giả
+	LastPublished *time.Time `json:"last_published"` // Lần xuất bản gần nhất
+}
+
+// DashboardFullOverview - Tổng quan dashboard
+type DashboardFullOverview struct {
+	Summary        DashboardSummary       `json:"summary"`
+	PublishedChart []PublishingByTime     `json:"published_chart"`
+	ViewsChart     []ViewsByTime          `json:"views_chart"`
+	TopArticles    []DashboardArticle     `json:"top_articles"`
+	TopCategories  []CategoryContentStats `json:"top_categories"`
+	RecentArticles []DashboardArticle     `json:"recent_articles"`
+}
+
+// DashboardAnalytics - Phân tích chi tiết
+type DashboardAnalytics struct {
+	CategoryStats      []CategoryContentStats `json:"category_stats"`
+	TagStats           []TagContentStats      `json:"tag_stats"`
+	TopArticles        []DashboardArticle     `json:"top_articles"`
+	AuthorProductivity []AuthorProductivity   `json:"author_productivity"`
+	PublishedChart     []PublishingByTime     `json:"published_chart"`
+	ViewsChart         []ViewsByTime          `json:"views_chart"`
+}
+
+// DashboardAlerts - Việc cần xử lý: bản nháp tồn đọng, hàng đợi lên lịch, bài lâu chưa cập nhật
+type DashboardAlerts struct {
+	DraftBacklog   []DashboardArticle `json:"draft_backlog"`
+	ScheduledQueue []DashboardArticle `json:"scheduled_queue"`
+	StaleArticles  []DashboardArticle `json:"stale_articles"`
+	StaleMonths    int                `json:"stale_months"`
+	AlertCounts    map[string]int64   `json:"alert_counts"`
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// FAQ - Câu hỏi thường gặp, có thể gắn với danh mục và nhúng vào bài viết
+type FAQ struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Question     string         `json:"question" gorm:"not null;size:500"`
+	Answer       datatypes.JSON `json:"answer" gorm:"type:json"`      // Nội dung trả lời (rich content giống content bài viết)
+	AnswerText   string         `json:"answer_text" gorm:"type:text"` // Bản text thuần của câu trả lời (dùng cho JSON-LD và tìm kiếm)
+	CategoryID   *uuid.UUID     `json:"category_id" gorm:"type:char(36);index"`
+	DisplayOrder int            `json:"display_order" gorm:"default:0;index"`
+	IsActive     bool           `json:"is_active" gorm:"default:true;index"`
+	Locale       string         `json:"locale" gorm:"type:varchar(10);default:'vi';index"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+}
+
+func (FAQ) TableName() string {
+	return "faqs"
+}
+
+func (f *FAQ) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// ArticleFAQ - Câu hỏi thường gặp được nhúng vào bài viết (theo thứ tự)
+type ArticleFAQ struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_faq"`
+	FAQID     uuid.UUID `json:"faq_id" gorm:"column:faq_id;type:char(36);not null;uniqueIndex:idx_article_faq;index"`
+	Position  int       `json:"position" gorm:"default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ArticleFAQ) TableName() string {
+	return "article_faqs"
+}
+
+func (l *ArticleFAQ) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
+
+type FAQInput struct {
+	Question     string          `json:"question" binding:"required,min=1,max=500"`
+	Answer       json.RawMessage `json:"answer" binding:"required"`
+	AnswerText   string          `json:"answer_text"` // Bỏ trống để tự tạo từ answer
+	CategoryID   *uuid.UUID      `json:"category_id"`
+	DisplayOrder *int            `json:"display_order"`
+	IsActive     *bool           `json:"is_active"`
+	Locale       string          `json:"locale"`
+}
+
+// FAQOrderItem - Thứ tự mới của một câu hỏi
+type FAQOrderItem struct {
+	ID           uuid.UUID `json:"id" binding:"required"`
+	DisplayOrder int       `json:"display_order" binding:"gte=0"`
+}
+
+// FAQReorderInput - Sắp xếp lại nhiều câu hỏi cùng lúc
+type FAQReorderInput struct {
+	Items []FAQOrderItem `json:"items" binding:"required,min=1,max=500,dive"`
+}
+
+// ArticleFAQsInput - Danh sách câu hỏi nhúng vào bài viết (theo thứ tự)
+type ArticleFAQsInput struct {
+	FAQIDs []uuid.UUID `json:"faq_ids"`
+}
+
+type FAQResponse struct {
+	ID           uuid.UUID               `json:"id"`
+	Question     string                  `json:"question"`
+	Answer       json.RawMessage         `json:"answer"`
+	AnswerText   string                  `json:"answer_text"`
+	CategoryID   *uuid.UUID              `json:"category_id"`
+	Category     *CategorySimpleResponse `json:"category,omitempty"`
+	DisplayOrder int                     `json:"display_order"`
+	IsActive     bool                    `json:"is_active"`
+	Locale       string                  `json:"locale"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+func (f *FAQ) ToResponse() FAQResponse {
+	resp := FAQResponse{
+		ID:           f.ID,
+		Question:     f.Question,
+		AnswerText:   f.AnswerText,
+		CategoryID:   f.CategoryID,
+		DisplayOrder: f.DisplayOrder,
+		IsActive:     f.IsActive,
+		Locale:       f.Locale,
+		CreatedAt:    f.CreatedAt,
+		UpdatedAt:    f.UpdatedAt,
+	}
+	if len(f.Answer) > 0 {
+		resp.Answer = json.RawMessage(f.Answer)
+	}
+	if f.Category != nil {
+		resp.Category = &CategorySimpleResponse{
+			ID:   f.Category.ID,
+			Name: f.Category.Name,
+			Slug: f.Category.Slug,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArticleFeedback - Đánh giá "bài viết có hữu ích không?" của độc giả, mỗi khách một phiếu cho mỗi bài
+type ArticleFeedback struct {
+	ID          uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID   uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_feedback_visitor"`
+	VisitorHash string    `json:"-" gorm:"type:char(64);not null;uniqueIndex:idx_article_feedback_visitor"`
+	Helpful     bool      `json:"helpful" gorm:"not null;index"`
+	Reason      string    `json:"reason" gorm:"size:500"`
+	Locale      string    `json:"locale" gorm:"type:varchar(10);default:'vi'"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (ArticleFeedback) TableName() string {
+	return "article_feedback"
+}
+
+func (f *ArticleFeedback) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// FeedbackInput - Phiếu đánh giá bài viết
+type FeedbackInput struct {
+	Helpful *bool  `json:"helpful" binding:"required"`
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// FeedbackSummary - Tổng hợp đánh giá
+type FeedbackSummary struct {
+	Helpful      int64   `json:"helpful"`
+	NotHelpful   int64   `json:"not_helpful"`
+	Total        int64   `json:"total"`
+	HelpfulRatio float64 `json:"helpful_ratio"` // 0..1, 0 khi chưa có phiếu
+}
+
+// NewFeedbackSummary tính tổng và tỉ lệ hữu ích
+func NewFeedbackSummary(helpful, notHelpful int64) FeedbackSummary {
+	summary := FeedbackSummary{
+		Helpful:    helpful,
+		NotHelpful: notHelpful,
+		Total:      helpful + notHelpful,
+	}
+	if summary.Total > 0 {
+		summary.HelpfulRatio = float64(helpful) / float64(summary.Total)
+	}
+	return summary
+}
+
+// ArticleFeedbackStats - Tổng hợp đánh giá theo bài viết
+type ArticleFeedbackStats struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	ViewCount int       `json:"view_count"`
+	FeedbackSummary
+}
+
+// CategoryFeedbackStats - Tổng hợp đánh giá theo danh mục
+type CategoryFeedbackStats struct {
+	CategoryID   *uuid.UUID `json:"category_id"`
+	CategoryName string     `json:"category_name"`
+	ArticleCount int64      `json:"article_count"`
+	FeedbackSummary
+}
+
+// FeedbackReasonResponse - Lý do độc giả để lại (admin)
+type FeedbackReasonResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Helpful   bool      `json:"helpful"`
+	Reason    string    `json:"reason"`
+	Locale    string    `json:"locale"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -14,10 +14,12 @@ type HomepageSection struct {
 	ID          uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
 	Title       string         `json:"title" gorm:"not null;size:255;index"`
 	Description string         `json:"description" gorm:"type:text"`
-	TypeKey     string         `json:"type_key" gorm:"type:varchar(50);not null;uniqueIndex"` // TYPE01, TYPE02, etc.
-	Metadata    datatypes.JSON `json:"metadata" gorm:"type:json"`                             // JSON array lưu dữ liệu items
-	Position    int            `json:"position" gorm:"default:0;index"`                       // Vị trí hiển thị
-	ShowHome    bool           `json:"show_home" gorm:"default:true;index"`                   // Hiển thị ở trang chủ
+	TypeKey     string         `json:"type_key" gorm:"type:varchar(50);not null;uniqueIndex:idx_homepage_type_locale"`   // Định danh section: TYPE01, TYPE02, LAW_NEWS...
+	Locale      string         `json:"locale" gorm:"type:varchar(10);default:'vi';uniqueIndex:idx_homepage_type_locale"` // Mỗi type_key có một bản cho mỗi ngôn ngữ
+	SectionType string         `json:"section_type" gorm:"type:varchar(50);not null;default:'';index"`                   // Loại section đã đăng ký (sectiontype), nhiều section có thể cùng loại
+	Metadata    datatypes.JSON `json:"metadata" gorm:"type:json"`                                                        // JSON array lưu dữ liệu items
+	Position    int            `json:"position" gorm:"default:0;index"`                                                  // Vị trí hiển thị
+	ShowHome    bool           `json:"show_home" gorm:"default:true;index"`                                              // Hiển thị ở trang chủ
 	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
@@ -31,17 +33,30 @@ func (h *HomepageSection) BeforeCreate(tx *gorm.DB) (err error) {
 	if h.ID == uuid.Nil {
 		h.ID = uuid.New()
 	}
+	if h.SectionType == "" {
+		h.SectionType = h.TypeKey
+	}
 	return
 }
 
+// Type lấy loại section (dữ liệu cũ chưa có section_type thì type_key chính là loại)
+func (h *HomepageSection) Type() string {
+	if h.SectionType != "" {
+		return h.SectionType
+	}
+	return h.TypeKey
+}
+
 // HomepageSectionInput - Input cho tạo/cập nhật section
 type HomepageSectionInput struct {
 	Title       string          `json:"title" binding:"required,min=1,max=255"`
 	Description string          `json:"description"`
 	TypeKey     string          `json:"type_key" binding:"required,min=1,max=50"`
+	SectionType string          `json:"section_type" binding:"max=50"` // Bỏ trống: loại trùng type_key (khi tạo) hoặc giữ nguyên (khi sửa)
 	Metadata    json.RawMessage `json:"metadata"`
 	Position    *int            `json:"position"`
 	ShowHome    *bool           `json:"show_home"`
+	Locale      string          `json:"locale"`
 }
 
 // HomepageSectionResponse - Response khi trả về section
@@ -50,9 +65,11 @@ type HomepageSectionResponse struct {
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
 	TypeKey     string          `json:"type_key"`
+	SectionType string          `json:"section_type"`
 	Metadata    json.RawMessage `json:"metadata"`
 	Position    int             `json:"position"`
 	ShowHome    bool            `json:"show_home"`
+	Locale      string          `json:"locale"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
@@ -62,7 +79,10 @@ type HomepageSectionPublicResponse struct {
 	Title       string          `json:"title"`
 	Description string          `json:"description"`
 	TypeKey     string          `json:"type_key"`
+	SectionType string          `json:"section_type"`
+	Locale      string          `json:"locale"`
 	Metadata    json.RawMessage `json:"metadata"`
+	Items       interface{}     `json:"items,omitempty"` // Nội dung lấy khi đọc của section động (bài viết, tags)
 }
 
 // ToResponse chuyển đổi từ model sang response
@@ -72,9 +92,11 @@ func (h *HomepageSection) ToResponse() HomepageSectionResponse {
 		Title:       h.Title,
 		Description: h.Description,
 		TypeKey:     h.TypeKey,
+		SectionType: h.Type(),
 		Metadata:    json.RawMessage(h.Metadata),
 		Position:    h.Position,
 		ShowHome:    h.ShowHome,
+		Locale:      h.Locale,
 		CreatedAt:   h.CreatedAt,
 		UpdatedAt:   h.UpdatedAt,
 	}
@@ -86,6 +108,8 @@ func (h *HomepageSection) ToPublicResponse() HomepageSectionPublicResponse {
 		Title:       h.Title,
 		Description: h.Description,
 		TypeKey:     h.TypeKey,
+		SectionType: h.Type(),
+		Locale:      h.Locale,
 		Metadata:    json.RawMessage(h.Metadata),
 	}
 }
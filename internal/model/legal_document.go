@@ -0,0 +1,233 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// LegalDocument - Văn bản pháp luật/biểu mẫu có thể tải về (luật, nghị định, thông tư, mẫu hợp đồng)
+type LegalDocument struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Title            string         `json:"title" gorm:"not null;size:500;index"`
+	Slug             string         `json:"slug" gorm:"unique;not null;size:500;index"`
+	DocumentNumber   string         `json:"document_number" gorm:"size:100;index"`   // Số hiệu: 45/2019/QH14
+	DocType          string         `json:"doc_type" gorm:"type:varchar(30);index"`  // law, decree, circular, contract_template
+	IssuingAuthority string         `json:"issuing_authority" gorm:"size:255;index"` // Cơ quan ban hành
+	IssueDate        *time.Time     `json:"issue_date" gorm:"type:date;index"`
+	EffectiveDate    *time.Time     `json:"effective_date" gorm:"type:date"`
+	ExpiryDate       *time.Time     `json:"expiry_date" gorm:"type:date"`
+	Status           string         `json:"status" gorm:"type:varchar(20);default:'in_force';index"` // in_force, expired, amended
+	Summary          string         `json:"summary" gorm:"type:text"`
+	Content          datatypes.JSON `json:"content" gorm:"type:json"`
+	Attachments      datatypes.JSON `json:"attachments" gorm:"type:json"` // Mảng DocumentAttachment (key S3)
+	CategoryID       *uuid.UUID     `json:"category_id" gorm:"type:char(36);index"`
+	IsActive         bool           `json:"is_active" gorm:"default:true;index"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+}
+
+func (LegalDocument) TableName() string {
+	return "legal_documents"
+}
+
+func (d *LegalDocument) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// ArticleLegalDocument - Văn bản pháp luật được bài viết trích dẫn (theo thứ tự)
+type ArticleLegalDocument struct {
+	ID              uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ArticleID       uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_legal_document"`
+	LegalDocumentID uuid.UUID `json:"legal_document_id" gorm:"type:char(36);not null;uniqueIndex:idx_article_legal_document;index"`
+	Position        int       `json:"position" gorm:"default:0"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ArticleLegalDocument) TableName() string {
+	return "article_legal_documents"
+}
+
+func (l *ArticleLegalDocument) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
+
+// DocumentAttachment - File văn bản đã upload qua /api/upload/s3
+type DocumentAttachment struct {
+	Key         string `json:"key" binding:"required,max=500"`
+	Name        string `json:"name" binding:"max=255"`
+	ContentType string `json:"content_type" binding:"max=100"`
+	Size        int64  `json:"size" binding:"gte=0"`
+	URL         string `json:"url,omitempty"` // Link tải, được tạo khi trả về
+}
+
+type LegalDocumentInput struct {
+	Title            string               `json:"title" binding:"required,min=1,max=500"`
+	Slug             string               `json:"slug" binding:"required,min=1,max=500"`
+	DocumentNumber   string               `json:"document_number" binding:"max=100"`
+	DocType          string               `json:"doc_type" binding:"required,oneof=law decree circular contract_template"`
+	IssuingAuthority string               `json:"issuing_authority" binding:"max=255"`
+	IssueDate        *time.Time           `json:"issue_date"`
+	EffectiveDate    *time.Time           `json:"effective_date"`
+	ExpiryDate       *time.Time           `json:"expiry_date"`
+	Status           string               `json:"status" binding:"omitempty,oneof=in_force expired amended"`
+	Summary          string               `json:"summary"`
+	Content          json.RawMessage      `json:"content"`
+	Attachments      []DocumentAttachment `json:"attachments" binding:"max=20,dive"`
+	CategoryID       *uuid.UUID           `json:"category_id"`
+	IsActive         *bool                `json:"is_active"`
+}
+
+// ArticleLegalDocumentsInput - Danh sách văn bản bài viết trích dẫn (theo thứ tự)
+type ArticleLegalDocumentsInput struct {
+	DocumentIDs []uuid.UUID `json:"document_ids"`
+}
+
+// LegalDocumentSimpleResponse - Văn bản gọn để nhúng vào bài viết
+type LegalDocumentSimpleResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Title          string     `json:"title"`
+	Slug           string     `json:"slug"`
+	DocumentNumber string     `json:"document_number"`
+	DocType        string     `json:"doc_type"`
+	Status         string     `json:"status"`
+	EffectiveDate  *time.Time `json:"effective_date"`
+}
+
+type LegalDocumentResponse struct {
+	ID               uuid.UUID               `json:"id"`
+	Title            string                  `json:"title"`
+	Slug             string                  `json:"slug"`
+	DocumentNumber   string                  `json:"document_number"`
+	DocType          string                  `json:"doc_type"`
+	DocTypeLabel     string                  `json:"doc_type_label"`
+	IssuingAuthority string                  `json:"issuing_authority"`
+	IssueDate        *time.Time              `json:"issue_date"`
+	EffectiveDate    *time.Time              `json:"effective_date"`
+	ExpiryDate       *time.Time              `json:"expiry_date"`
+	Status           string                  `json:"status"`
+	Summary          string                  `json:"summary"`
+	Content          json.RawMessage         `json:"content,omitempty"`
+	Attachments      []DocumentAttachment    `json:"attachments"`
+	CategoryID       *uuid.UUID              `json:"category_id"`
+	Category         *CategorySimpleResponse `json:"category,omitempty"`
+	IsActive         bool                    `json:"is_active"`
+	CitingArticles   []ArticleSummary        `json:"citing_articles,omitempty"` // Bài viết trích dẫn văn bản (chỉ ở trang chi tiết)
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+// LegalDocumentFacet - Một giá trị bộ lọc kèm số văn bản
+type LegalDocumentFacet struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// LegalDocumentFacets - Các bộ lọc của thư viện văn bản
+type LegalDocumentFacets struct {
+	DocTypes    []LegalDocumentFacet `json:"doc_types"`
+	Statuses    []LegalDocumentFacet `json:"statuses"`
+	Authorities []LegalDocumentFacet `json:"issuing_authorities"`
+	Years       []LegalDocumentFacet `json:"years"`
+}
+
+// GetAttachments giải mã danh sách file đính kèm
+func (d *LegalDocument) GetAttachments() []DocumentAttachment {
+	attachments := []DocumentAttachment{}
+	if len(d.Attachments) > 0 {
+		_ = json.Unmarshal(d.Attachments, &attachments)
+	}
+	return attachments
+}
+
+// ApplyInput gán dữ liệu từ input vào văn bản
+func (d *LegalDocument) ApplyInput(input LegalDocumentInput) {
+	d.Title = input.Title
+	d.Slug = input.Slug
+	d.DocumentNumber = input.DocumentNumber
+	d.DocType = input.DocType
+	d.IssuingAuthority = input.IssuingAuthority
+	d.IssueDate = input.IssueDate
+	d.EffectiveDate = input.EffectiveDate
+	d.ExpiryDate = input.ExpiryDate
+	d.Summary = input.Summary
+	d.CategoryID = input.CategoryID
+	if input.Status != "" {
+		d.Status = input.Status
+	}
+	if input.IsActive != nil {
+		d.IsActive = *input.IsActive
+	}
+
+	d.Content = nil
+	if len(input.Content) > 0 {
+		d.Content = datatypes.JSON(input.Content)
+	}
+
+	if input.Attachments == nil {
+		input.Attachments = []DocumentAttachment{}
+	}
+	for i := range input.Attachments {
+		input.Attachments[i].URL = "" // Link tải luôn được tạo lại từ key
+	}
+	attachments, _ := json.Marshal(input.Attachments)
+	d.Attachments = datatypes.JSON(attachments)
+}
+
+func (d *LegalDocument) ToResponse() LegalDocumentResponse {
+	resp := LegalDocumentResponse{
+		ID:               d.ID,
+		Title:            d.Title,
+		Slug:             d.Slug,
+		DocumentNumber:   d.DocumentNumber,
+		DocType:          d.DocType,
+		IssuingAuthority: d.IssuingAuthority,
+		IssueDate:        d.IssueDate,
+		EffectiveDate:    d.EffectiveDate,
+		ExpiryDate:       d.ExpiryDate,
+		Status:           d.Status,
+		Summary:          d.Summary,
+		Attachments:      d.GetAttachments(),
+		CategoryID:       d.CategoryID,
+		IsActive:         d.IsActive,
+		CreatedAt:        d.CreatedAt,
+		UpdatedAt:        d.UpdatedAt,
+	}
+	if len(d.Content) > 0 {
+		resp.Content = json.RawMessage(d.Content)
+	}
+	if d.Category != nil {
+		resp.Category = &CategorySimpleResponse{
+			ID:   d.Category.ID,
+			Name: d.Category.Name,
+			Slug: d.Category.Slug,
+		}
+	}
+	return resp
+}
+
+// ToSimpleResponse - Văn bản gọn để nhúng vào bài viết
+func (d *LegalDocument) ToSimpleResponse() LegalDocumentSimpleResponse {
+	return LegalDocumentSimpleResponse{
+		ID:             d.ID,
+		Title:          d.Title,
+		Slug:           d.Slug,
+		DocumentNumber: d.DocumentNumber,
+		DocType:        d.DocType,
+		Status:         d.Status,
+		EffectiveDate:  d.EffectiveDate,
+	}
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// LegalReference - Căn cứ pháp lý được bài viết trích dẫn (luật, nghị định, thông tư...)
+type LegalReference struct {
+	Title         string     `json:"title" binding:"required,max=500"`    // Tên văn bản
+	Number        string     `json:"number" binding:"max=100"`            // Số hiệu: 45/2019/QH14
+	URL           string     `json:"url" binding:"omitempty,url,max=500"` // Link văn bản gốc
+	EffectiveDate *time.Time `json:"effective_date"`                      // Ngày có hiệu lực
+	ExpiryDate    *time.Time `json:"expiry_date"`                         // Ngày hết hiệu lực (nếu đã biết)
+}
+
+// LegalReviewInput - Đánh dấu bài viết đã được rà soát pháp lý
+type LegalReviewInput struct {
+	ReviewedByID *uuid.UUID `json:"reviewed_by_id"` // Hồ sơ luật sư rà soát (tuỳ chọn)
+	NextReviewBy *time.Time `json:"next_review_by"` // Hạn rà soát tiếp theo (tuỳ chọn)
+}
+
+// ArticleLegalReviewResponse - Trạng thái rà soát pháp lý của bài viết (chỉ trả về cho admin)
+type ArticleLegalReviewResponse struct {
+	ReviewBy       *time.Time       `json:"review_by"`
+	LegalBasis     []LegalReference `json:"legal_basis"`
+	NeedsReview    bool             `json:"needs_review"`
+	ReviewReasons  []string         `json:"review_reasons"`
+	FlaggedAt      *time.Time       `json:"flagged_at"`
+	LastReviewedAt *time.Time       `json:"last_reviewed_at"`
+}
+
+// NeedsReviewArticleResponse - Một dòng trong hàng đợi cần rà soát
+type NeedsReviewArticleResponse struct {
+	ID          uuid.UUID                  `json:"id"`
+	Title       string                     `json:"title"`
+	Slug        string                     `json:"slug"`
+	AuthorID    uuid.UUID                  `json:"author_id"`
+	Author      *AuthorResponse            `json:"author,omitempty"`
+	LegalReview ArticleLegalReviewResponse `json:"legal_review"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+// GetLegalBasis giải mã danh sách căn cứ pháp lý
+func (a *Article) GetLegalBasis() []LegalReference {
+	refs := []LegalReference{}
+	if len(a.LegalBasis) > 0 {
+		_ = json.Unmarshal(a.LegalBasis, &refs)
+	}
+	return refs
+}
+
+// SetLegalBasis lưu danh sách căn cứ pháp lý
+func (a *Article) SetLegalBasis(refs []LegalReference) {
+	if refs == nil {
+		refs = []LegalReference{}
+	}
+	bytes, _ := json.Marshal(refs)
+	a.LegalBasis = datatypes.JSON(bytes)
+}
+
+// GetReviewReasons giải mã lý do cần rà soát
+func (a *Article) GetReviewReasons() []string {
+	reasons := []string{}
+	if len(a.ReviewReasons) > 0 {
+		_ = json.Unmarshal(a.ReviewReasons, &reasons)
+	}
+	return reasons
+}
+
+// SetReviewReasons lưu lý do cần rà soát
+func (a *Article) SetReviewReasons(reasons []string) {
+	if reasons == nil {
+		reasons = []string{}
+	}
+	bytes, _ := json.Marshal(reasons)
+	a.ReviewReasons = datatypes.JSON(bytes)
+}
+
+// DueReviewReasons trả về các lý do bài viết cần rà soát tại thời điểm now:
+// đã quá hạn review_by, hoặc có văn bản được trích dẫn đã hết hiệu lực sau lần rà soát gần nhất
+func (a *Article) DueReviewReasons(now time.Time) []string {
+	var reasons []string
+
+	if a.ReviewBy != nil && !a.ReviewBy.After(now) &&
+		(a.ReviewedAt == nil || a.ReviewedAt.Before(*a.ReviewBy)) {
+		reasons = append(reasons, fmt.Sprintf("Quá hạn rà soát (%s)", a.ReviewBy.Format("02/01/2006")))
+	}
+
+	for _, ref := range a.GetLegalBasis() {
+		if ref.ExpiryDate == nil || ref.ExpiryDate.After(now) {
+			continue
+		}
+		// Đã rà soát sau khi văn bản hết hiệu lực thì không nhắc lại
+		if a.ReviewedAt != nil && !a.ReviewedAt.Before(*ref.ExpiryDate) {
+			continue
+		}
+		name := ref.Title
+		if ref.Number != "" {
+			name = fmt.Sprintf("%s (%s)", ref.Title, ref.Number)
+		}
+		reasons = append(reasons, fmt.Sprintf("Văn bản hết hiệu lực từ %s: %s", ref.ExpiryDate.Format("02/01/2006"), name))
+	}
+
+	return reasons
+}
+
+// ToLegalReviewResponse trả về trạng thái rà soát pháp lý
+func (a *Article) ToLegalReviewResponse() ArticleLegalReviewResponse {
+	return ArticleLegalReviewResponse{
+		ReviewBy:       a.ReviewBy,
+		LegalBasis:     a.GetLegalBasis(),
+		NeedsReview:    a.NeedsReview,
+		ReviewReasons:  a.GetReviewReasons(),
+		FlaggedAt:      a.ReviewFlaggedAt,
+		LastReviewedAt: a.ReviewedAt,
+	}
+}
+
+// ToNeedsReviewResponse - Dòng trong hàng đợi cần rà soát
+func (a *Article) ToNeedsReviewResponse() NeedsReviewArticleResponse {
+	resp := NeedsReviewArticleResponse{
+		ID:          a.ID,
+		Title:       a.Title,
+		Slug:        a.Slug,
+		AuthorID:    a.AuthorID,
+		LegalReview: a.ToLegalReviewResponse(),
+		UpdatedAt:   a.UpdatedAt,
+	}
+	if a.Author != nil {
+		resp.Author = a.Author.ToAuthorResponse()
+	}
+	return resp
+}
@@ -0,0 +1,293 @@
+package model
+
+import (
+	"backend/internal/storage"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// MediaAsset - File đã upload lên storage và được xác nhận (thư viện media)
+type MediaAsset struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Key           string         `json:"key" gorm:"not null;size:500;uniqueIndex"` // Object key trên storage
+	Folder        string         `json:"folder" gorm:"type:varchar(20);index"`     // images, documents, media, other
+	FileName      string         `json:"file_name" gorm:"size:255;index"`          // Tên file gốc người dùng chọn
+	ContentType   string         `json:"content_type" gorm:"size:100;index"`
+	Size          int64          `json:"size"`
+	Width         *int           `json:"width"`  // Chỉ có với ảnh raster
+	Height        *int           `json:"height"` // Chỉ có với ảnh raster
+	ETag          string         `json:"etag" gorm:"size:100"`
+	Variants      datatypes.JSON `json:"variants" gorm:"type:json"`                                       // Mảng MediaVariant (ảnh đã resize, bỏ EXIF)
+	VariantsETag  string         `json:"-" gorm:"size:100"`                                               // ETag của file gốc khi tạo variants
+	Status        string         `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"` // pending, clean, rejected
+	DetectedType  string         `json:"detected_type" gorm:"size:100"`                                   // Loại file nhận diện từ nội dung
+	ScanResult    string         `json:"scan_result" gorm:"size:500"`                                     // Lý do từ chối, mẫu mã độc hoặc lỗi quét gần nhất
+	ScanAttempts  int            `json:"scan_attempts" gorm:"not null;default:0"`
+	ScannedAt     *time.Time     `json:"scanned_at"`
+	Visibility    string         `json:"visibility" gorm:"type:varchar(20);not null;default:'public';index"` // public, private
+	PrivateKey    string         `json:"-" gorm:"size:500"`                                                  // Key ngẫu nhiên dưới private/ khi file riêng tư
+	DownloadCount int64          `json:"download_count" gorm:"not null;default:0"`                           // Số lượt tải qua endpoint của backend
+	Title         string         `json:"title" gorm:"size:255"`
+	AltText       string         `json:"alt_text" gorm:"size:500"`
+	Caption       string         `json:"caption" gorm:"type:text"`
+	UploadedByID  *uuid.UUID     `json:"uploaded_by_id" gorm:"type:char(36);index"`
+	CreatedAt     time.Time      `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt     time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	UploadedBy *User `json:"uploaded_by,omitempty" gorm:"foreignKey:UploadedByID"`
+}
+
+func (MediaAsset) TableName() string {
+	return "media_assets"
+}
+
+func (m *MediaAsset) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
+
+// Trạng thái kiểm tra file: file mới ở trạng thái chờ (cách ly) cho tới khi quét xong
+const (
+	MediaStatusPending  = "pending"  // Chờ kiểm tra, chưa tạo variant, không hiện trong hộp thoại chọn file
+	MediaStatusClean    = "clean"    // Đúng loại khai báo, không có mã độc
+	MediaStatusRejected = "rejected" // Sai loại, file lai, SVG có script hoặc có mã độc; file gốc đã chuyển vào khu cách ly
+)
+
+// Phạm vi truy cập file
+const (
+	MediaVisibilityPublic  = "public"  // Link trực tiếp, ai cũng xem được
+	MediaVisibilityPrivate = "private" // File gốc nằm trong private/, chỉ tải qua backend bằng link ký ngắn hạn
+)
+
+// StorageKey - Vị trí thật của file gốc trên storage (file riêng tư nằm ở PrivateKey dưới storage.PrivatePrefix).
+// Key vẫn là định danh của file trong nội dung và chỉ mục sử dụng
+func (m *MediaAsset) StorageKey() string {
+	if m.Visibility == MediaVisibilityPrivate {
+		if m.PrivateKey != "" {
+			return m.PrivateKey
+		}
+		return storage.PrivatePrefix + m.Key // Bản ghi cũ chưa có PrivateKey
+	}
+	return m.Key
+}
+
+// NewPrivateKey tạo key ngẫu nhiên dưới storage.PrivatePrefix, không suy ra được từ Key công khai
+// (link công khai cũ đã bị lộ không giúp đoán được vị trí file riêng tư)
+func (m *MediaAsset) NewPrivateKey() string {
+	return storage.PrivatePrefix + uuid.NewString() + strings.ToLower(path.Ext(m.Key))
+}
+
+// IsPrivate kiểm tra file chỉ tải được qua backend
+func (m *MediaAsset) IsPrivate() bool {
+	return m.Visibility == MediaVisibilityPrivate
+}
+
+// DownloadPath - Endpoint tải file qua backend (kiểm tra quyền, đếm lượt tải)
+func (m *MediaAsset) DownloadPath() string {
+	return "/api/files/" + m.ID.String()
+}
+
+// Kiểu cắt ảnh của variant
+const (
+	MediaVariantFit  = "fit"  // Giữ tỉ lệ, vừa khung (dùng cho srcset)
+	MediaVariantFill = "fill" // Cắt giữa đúng kích thước (vd ảnh OG)
+)
+
+// MediaVariant - Bản resize của ảnh, lưu cạnh file gốc: {key không đuôi}_{name}.{ext}
+type MediaVariant struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	Mode        string `json:"mode"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// GetVariants giải mã danh sách variant
+func (m *MediaAsset) GetVariants() []MediaVariant {
+	variants := []MediaVariant{}
+	if len(m.Variants) > 0 {
+		_ = json.Unmarshal(m.Variants, &variants)
+	}
+	return variants
+}
+
+// SetVariants lưu danh sách variant
+func (m *MediaAsset) SetVariants(variants []MediaVariant) {
+	if variants == nil {
+		variants = []MediaVariant{}
+	}
+	data, _ := json.Marshal(variants)
+	m.Variants = datatypes.JSON(data)
+}
+
+// ObjectKeys lấy key file gốc và key các variant
+func (m *MediaAsset) ObjectKeys() []string {
+	keys := []string{m.Key}
+	for _, variant := range m.GetVariants() {
+		keys = append(keys, variant.Key)
+	}
+	return keys
+}
+
+// MediaConfirmInput - Xác nhận file đã upload xong qua presigned URL
+type MediaConfirmInput struct {
+	Key      string `json:"key" binding:"required,max=500"`
+	FileName string `json:"file_name" binding:"max=255"`
+	Title    string `json:"title" binding:"max=255"`
+	AltText  string `json:"alt_text" binding:"max=500"`
+	Caption  string `json:"caption"`
+	// Visibility: public (mặc định với file mới) hoặc private; bỏ trống khi xác nhận lại thì giữ nguyên
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public private"`
+}
+
+// MediaUpdateInput - Cập nhật thông tin mô tả của file
+type MediaUpdateInput struct {
+	Title   *string `json:"title" binding:"omitempty,max=255"`
+	AltText *string `json:"alt_text" binding:"omitempty,max=500"`
+	Caption *string `json:"caption"`
+}
+
+// MediaVisibilityInput - Đổi phạm vi truy cập file
+type MediaVisibilityInput struct {
+	Visibility string `json:"visibility" binding:"required,oneof=public private"`
+}
+
+// MediaUploader - Người upload file
+type MediaUploader struct {
+	ID       uuid.UUID `json:"id"`
+	FullName string    `json:"full_name"`
+}
+
+type MediaAssetResponse struct {
+	ID            uuid.UUID              `json:"id"`
+	Key           string                 `json:"key"`
+	URL           string                 `json:"url"` // Link truy cập, được tạo khi trả về
+	Folder        string                 `json:"folder"`
+	FileName      string                 `json:"file_name"`
+	ContentType   string                 `json:"content_type"`
+	Size          int64                  `json:"size"`
+	Width         *int                   `json:"width"`
+	Height        *int                   `json:"height"`
+	Title         string                 `json:"title"`
+	AltText       string                 `json:"alt_text"`
+	Caption       string                 `json:"caption"`
+	Variants      []MediaVariantResponse `json:"variants"`
+	SrcSet        string                 `json:"srcset,omitempty"` // Các variant kiểu fit, dùng trực tiếp cho <img srcset>
+	Status        string                 `json:"status"`
+	DetectedType  string                 `json:"detected_type,omitempty"`
+	ScanResult    string                 `json:"scan_result,omitempty"`
+	ScannedAt     *time.Time             `json:"scanned_at,omitempty"`
+	Visibility    string                 `json:"visibility"`
+	DownloadURL   string                 `json:"download_url"` // Tải qua backend: kiểm tra quyền, đếm lượt tải
+	DownloadCount int64                  `json:"download_count"`
+	UploadedBy    *MediaUploader         `json:"uploaded_by,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+type MediaVariantResponse struct {
+	MediaVariant
+	URL string `json:"url"`
+}
+
+// BuildMediaVariants gắn link cho các variant (urlFor tạo link từ object key) và dựng chuỗi srcset
+func BuildMediaVariants(variants []MediaVariant, urlFor func(key string) string) ([]MediaVariantResponse, string) {
+	responses := make([]MediaVariantResponse, 0, len(variants))
+	var fits []MediaVariantResponse
+	for _, variant := range variants {
+		resp := MediaVariantResponse{MediaVariant: variant, URL: urlFor(variant.Key)}
+		responses = append(responses, resp)
+		if variant.Mode == MediaVariantFit {
+			fits = append(fits, resp)
+		}
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].Width < fits[j].Width })
+	parts := make([]string, 0, len(fits))
+	lastWidth := 0
+	for _, fit := range fits {
+		// Ảnh gốc nhỏ có thể cho nhiều variant trùng chiều rộng
+		if fit.Width == lastWidth {
+			continue
+		}
+		lastWidth = fit.Width
+		parts = append(parts, fmt.Sprintf("%s %dw", fit.URL, fit.Width))
+	}
+	return responses, strings.Join(parts, ", ")
+}
+
+func (m *MediaAsset) ToResponse() MediaAssetResponse {
+	resp := MediaAssetResponse{
+		ID:            m.ID,
+		Key:           m.Key,
+		Folder:        m.Folder,
+		FileName:      m.FileName,
+		ContentType:   m.ContentType,
+		Size:          m.Size,
+		Width:         m.Width,
+		Height:        m.Height,
+		Title:         m.Title,
+		AltText:       m.AltText,
+		Caption:       m.Caption,
+		Status:        m.Status,
+		DetectedType:  m.DetectedType,
+		ScanResult:    m.ScanResult,
+		ScannedAt:     m.ScannedAt,
+		Visibility:    m.Visibility,
+		DownloadURL:   m.DownloadPath(),
+		DownloadCount: m.DownloadCount,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+	if m.UploadedBy != nil {
+		resp.UploadedBy = &MediaUploader{ID: m.UploadedBy.ID, FullName: m.UploadedBy.FullName}
+	}
+	return resp
+}
+
+// Cách người tải được cấp quyền
+const (
+	MediaAccessPublic     = "public"     // File công khai hoặc tệp đính kèm không giới hạn
+	MediaAccessStaff      = "staff"      // Người dùng quản trị đã đăng nhập
+	MediaAccessSubscriber = "subscriber" // Người đăng ký bản tin qua link gửi email
+)
+
+// MediaDownload - Nhật ký tải file qua backend
+type MediaDownload struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	MediaID      uuid.UUID  `json:"media_id" gorm:"type:char(36);not null;index"`
+	ArticleID    *uuid.UUID `json:"article_id,omitempty" gorm:"type:char(36);index"` // Tải dưới dạng tệp đính kèm của bài viết
+	UserID       *uuid.UUID `json:"user_id,omitempty" gorm:"type:char(36);index"`
+	SubscriberID *uuid.UUID `json:"subscriber_id,omitempty" gorm:"type:char(36);index"`
+	Access       string     `json:"access" gorm:"type:varchar(20);not null"` // public, staff, subscriber
+	IPAddress    string     `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent    string     `json:"user_agent" gorm:"size:500"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+
+	User       *User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Subscriber *Subscriber `json:"subscriber,omitempty" gorm:"foreignKey:SubscriberID"`
+}
+
+func (MediaDownload) TableName() string {
+	return "media_downloads"
+}
+
+func (d *MediaDownload) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Loại entity tham chiếu tới file trên storage
+const (
+	MediaEntityArticle         = "article"
+	MediaEntityCategory        = "category"
+	MediaEntityTag             = "tag"
+	MediaEntityUser            = "user"
+	MediaEntityAuthorProfile   = "author_profile"
+	MediaEntityHomepageSection = "homepage_section"
+	MediaEntityLegalDocument   = "legal_document"
+	MediaEntityConsultation    = "consultation"
+	MediaEntityFAQ             = "faq"
+	MediaEntitySeries          = "series"
+)
+
+// MediaKeyPattern - Định dạng object key do /upload/s3 tạo: {uuid}/{folder}/{yyyymm}/{uuid}.{ext},
+// kể cả variant ảnh {uuid}_{name}.{ext}. Khớp được cả key trần lẫn key nằm trong direct URL, presigned URL hay HTML
+var MediaKeyPattern = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}/(?:images|documents|media|other)/[0-9]{6}/[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}(?:_[a-z0-9]+)?\.[A-Za-z0-9]+`)
+
+// MediaUsage - Chỉ mục entity nào đang dùng object key nào (cập nhật mỗi lần lưu)
+type MediaUsage struct {
+	ID         uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	ObjectKey  string    `json:"object_key" gorm:"not null;size:500;uniqueIndex:idx_media_usage"`
+	EntityType string    `json:"entity_type" gorm:"type:varchar(30);not null;uniqueIndex:idx_media_usage;index:idx_media_usage_entity"`
+	EntityID   uuid.UUID `json:"entity_id" gorm:"type:char(36);not null;uniqueIndex:idx_media_usage;index:idx_media_usage_entity"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (MediaUsage) TableName() string {
+	return "media_usages"
+}
+
+func (u *MediaUsage) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return
+}
+
+// IsMediaKey kiểm tra key có đúng định dạng do /upload/s3 tạo (toàn bộ chuỗi)
+func IsMediaKey(key string) bool {
+	return MediaKeyPattern.FindString(key) == key && key != ""
+}
+
+// ExtractMediaKeys tìm các object key xuất hiện trong dữ liệu (JSON, HTML, URL...), không trùng lặp, đã sắp xếp
+func ExtractMediaKeys(payloads ...[]byte) []string {
+	seen := map[string]struct{}{}
+	for _, payload := range payloads {
+		for _, match := range MediaKeyPattern.FindAll(payload, -1) {
+			seen[string(match)] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
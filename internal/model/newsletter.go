@@ -0,0 +1,139 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Subscriber - Người đăng ký nhận bản tin qua email (double opt-in)
+type Subscriber struct {
+	ID                    uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Email                 string         `json:"email" gorm:"not null;size:255;uniqueIndex"`
+	Name                  string         `json:"name" gorm:"size:255"`
+	Locale                string         `json:"locale" gorm:"type:varchar(10);default:'vi';index"`
+	Status                string         `json:"status" gorm:"type:varchar(20);default:'pending';index"` // pending, confirmed, unsubscribed
+	CategoryIDs           datatypes.JSON `json:"category_ids" gorm:"type:json"`                          // Danh mục quan tâm; trống = mọi bài viết
+	TagIDs                datatypes.JSON `json:"tag_ids" gorm:"type:json"`                               // Tag quan tâm
+	ConfirmTokenHash      string         `json:"-" gorm:"type:varchar(64);index"`                        // SHA-256 của token xác nhận
+	ConfirmTokenExpiresAt *time.Time     `json:"-"`
+	UnsubscribeToken      string         `json:"-" gorm:"type:varchar(64);uniqueIndex"` // Dùng cho link hủy đăng ký một chạm
+	ConfirmedAt           *time.Time     `json:"confirmed_at"`
+	UnsubscribedAt        *time.Time     `json:"unsubscribed_at"`
+	LastDigestAt          *time.Time     `json:"last_digest_at"`
+	CreatedAt             time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt             time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Subscriber) TableName() string {
+	return "newsletter_subscribers"
+}
+
+func (s *Subscriber) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// NewsletterDigest - Lịch sử các lần gửi bản tin tổng hợp
+type NewsletterDigest struct {
+	ID             uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	SinceAt        time.Time `json:"since_at"` // Mốc sớm nhất lấy bài (mỗi người đăng ký tính từ lần cuối nhận bản tin)
+	SentAt         time.Time `json:"sent_at" gorm:"index"`
+	ArticleCount   int       `json:"article_count"`
+	RecipientCount int       `json:"recipient_count"` // Số email gửi thành công
+	FailedCount    int       `json:"failed_count"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (NewsletterDigest) TableName() string {
+	return "newsletter_digests"
+}
+
+func (d *NewsletterDigest) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+type SubscribeInput struct {
+	Email       string      `json:"email" binding:"required,email,max=255"`
+	Name        string      `json:"name" binding:"max=255"`
+	CategoryIDs []uuid.UUID `json:"category_ids" binding:"max=50"`
+	TagIDs      []uuid.UUID `json:"tag_ids" binding:"max=50"`
+	Locale      string      `json:"locale"`
+}
+
+// NewsletterTokenInput - Token xác nhận/hủy đăng ký
+type NewsletterTokenInput struct {
+	Token string `json:"token" binding:"required,max=128"`
+}
+
+type SubscriberResponse struct {
+	ID             uuid.UUID   `json:"id"`
+	Email          string      `json:"email"`
+	Name           string      `json:"name"`
+	Locale         string      `json:"locale"`
+	Status         string      `json:"status"`
+	CategoryIDs    []uuid.UUID `json:"category_ids"`
+	TagIDs         []uuid.UUID `json:"tag_ids"`
+	ConfirmedAt    *time.Time  `json:"confirmed_at"`
+	UnsubscribedAt *time.Time  `json:"unsubscribed_at"`
+	LastDigestAt   *time.Time  `json:"last_digest_at"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// GetCategoryIDs giải mã danh sách danh mục quan tâm
+func (s *Subscriber) GetCategoryIDs() []uuid.UUID {
+	ids := []uuid.UUID{}
+	if len(s.CategoryIDs) > 0 {
+		_ = json.Unmarshal(s.CategoryIDs, &ids)
+	}
+	return ids
+}
+
+// GetTagIDs giải mã danh sách tag quan tâm
+func (s *Subscriber) GetTagIDs() []uuid.UUID {
+	ids := []uuid.UUID{}
+	if len(s.TagIDs) > 0 {
+		_ = json.Unmarshal(s.TagIDs, &ids)
+	}
+	return ids
+}
+
+// SetTopics lưu danh mục và tag quan tâm
+func (s *Subscriber) SetTopics(categoryIDs, tagIDs []uuid.UUID) {
+	if categoryIDs == nil {
+		categoryIDs = []uuid.UUID{}
+	}
+	if tagIDs == nil {
+		tagIDs = []uuid.UUID{}
+	}
+	categories, _ := json.Marshal(categoryIDs)
+	tags, _ := json.Marshal(tagIDs)
+	s.CategoryIDs = datatypes.JSON(categories)
+	s.TagIDs = datatypes.JSON(tags)
+}
+
+func (s *Subscriber) ToResponse() SubscriberResponse {
+	return SubscriberResponse{
+		ID:             s.ID,
+		Email:          s.Email,
+		Name:           s.Name,
+		Locale:         s.Locale,
+		Status:         s.Status,
+		CategoryIDs:    s.GetCategoryIDs(),
+		TagIDs:         s.GetTagIDs(),
+		ConfirmedAt:    s.ConfirmedAt,
+		UnsubscribedAt: s.UnsubscribedAt,
+		LastDigestAt:   s.LastDigestAt,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
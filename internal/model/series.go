@@ -0,0 +1,176 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Series - Chuỗi bài viết nhiều phần (ví dụ: hướng dẫn thủ tục Phần 1..5)
+type Series struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Title       string         `json:"title" gorm:"not null;size:500;index"`
+	Slug        string         `json:"slug" gorm:"unique;not null;size:500;index"`
+	Description string         `json:"description" gorm:"type:text"`
+	IsActive    bool           `json:"is_active" gorm:"default:true;index"`
+	Metadata    datatypes.JSON `json:"metadata" gorm:"type:json"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Items []SeriesArticle `json:"items,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+func (s *Series) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// SeriesArticle - Thành viên của chuỗi, mỗi bài viết chỉ thuộc tối đa một chuỗi
+type SeriesArticle struct {
+	ID        uuid.UUID `json:"id" gorm:"type:char(36);primaryKey"`
+	SeriesID  uuid.UUID `json:"series_id" gorm:"type:char(36);not null;index"`
+	ArticleID uuid.UUID `json:"article_id" gorm:"type:char(36);not null;uniqueIndex"`
+	Position  int       `json:"position" gorm:"default:0;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+
+	Series  *Series  `json:"series,omitempty" gorm:"foreignKey:SeriesID"`
+	Article *Article `json:"article,omitempty" gorm:"foreignKey:ArticleID"`
+}
+
+func (SeriesArticle) TableName() string {
+	return "series_articles"
+}
+
+func (s *SeriesArticle) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+type SeriesInput struct {
+	Title       string          `json:"title" binding:"required,min=1,max=500"`
+	Slug        string          `json:"slug" binding:"required,min=1,max=500"`
+	Description string          `json:"description"`
+	IsActive    *bool           `json:"is_active"`
+	Metadata    json.RawMessage `json:"metadata"`
+	ArticleIDs  []uuid.UUID     `json:"article_ids"` // Thứ tự trong mảng là thứ tự các phần
+}
+
+// SeriesArticleInput - Cập nhật danh sách bài viết (theo thứ tự) của chuỗi
+type SeriesArticleInput struct {
+	ArticleIDs []uuid.UUID `json:"article_ids"`
+}
+
+// SeriesNavItem - Thông tin gọn của một phần trong chuỗi
+type SeriesNavItem struct {
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Slug     string    `json:"slug"`
+	Position int       `json:"position"`
+	Status   string    `json:"status,omitempty"`
+}
+
+// ArticleSeriesPosition - Vị trí của bài viết trong chuỗi, đính kèm vào ArticleResponse
+type ArticleSeriesPosition struct {
+	ID       uuid.UUID      `json:"id"`
+	Title    string         `json:"title"`
+	Slug     string         `json:"slug"`
+	Position int            `json:"position"` // Bắt đầu từ 1
+	Total    int            `json:"total"`
+	Prev     *SeriesNavItem `json:"prev,omitempty"`
+	Next     *SeriesNavItem `json:"next,omitempty"`
+}
+
+type SeriesResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	Slug        string          `json:"slug"`
+	Description string          `json:"description"`
+	IsActive    bool            `json:"is_active"`
+	Metadata    json.RawMessage `json:"metadata,omitempty"`
+	Parts       []SeriesNavItem `json:"parts"`
+	Current     *SeriesNavItem  `json:"current,omitempty"`
+	Prev        *SeriesNavItem  `json:"prev,omitempty"`
+	Next        *SeriesNavItem  `json:"next,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// ToResponse chuyển Series thành SeriesResponse. Nếu publishedOnly = true,
+// chỉ các phần đã xuất bản được liệt kê và đánh số lại liên tục
+func (s *Series) ToResponse(publishedOnly bool) SeriesResponse {
+	response := SeriesResponse{
+		ID:          s.ID,
+		Title:       s.Title,
+		Slug:        s.Slug,
+		Description: s.Description,
+		IsActive:    s.IsActive,
+		Parts:       s.Parts(publishedOnly),
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+
+	if len(s.Metadata) > 0 {
+		response.Metadata = json.RawMessage(s.Metadata)
+	}
+
+	return response
+}
+
+// Parts trả về danh sách các phần theo thứ tự (Items phải được preload kèm Article)
+func (s *Series) Parts(publishedOnly bool) []SeriesNavItem {
+	parts := make([]SeriesNavItem, 0, len(s.Items))
+	for _, item := range s.Items {
+		if item.Article == nil {
+			continue
+		}
+		if publishedOnly && !item.Article.IsPublished() {
+			continue
+		}
+		parts = append(parts, SeriesNavItem{
+			ID:       item.Article.ID,
+			Title:    item.Article.Title,
+			Slug:     item.Article.Slug,
+			Position: len(parts) + 1,
+			Status:   item.Article.Status,
+		})
+	}
+	return parts
+}
+
+// PositionOf tính vị trí và điều hướng trước/sau của một bài viết trong danh sách phần
+func PositionOf(s *Series, parts []SeriesNavItem, articleID uuid.UUID) *ArticleSeriesPosition {
+	for i := range parts {
+		if parts[i].ID != articleID {
+			continue
+		}
+		position := &ArticleSeriesPosition{
+			ID:       s.ID,
+			Title:    s.Title,
+			Slug:     s.Slug,
+			Position: parts[i].Position,
+			Total:    len(parts),
+		}
+		if i > 0 {
+			prev := parts[i-1]
+			position.Prev = &prev
+		}
+		if i < len(parts)-1 {
+			next := parts[i+1]
+			position.Next = &next
+		}
+		return position
+	}
+	return nil
+}
@@ -10,18 +10,20 @@ import (
 )
 
 type Tag struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
-	Name         string         `json:"name" gorm:"not null;size:200;index"`
-	Slug         string         `json:"slug" gorm:"unique;not null;size:200;index"`
-	Description  string         `json:"description" gorm:"type:text"`
-	DisplayOrder int            `json:"display_order" gorm:"default:0;index"`
-	IsActive     bool           `json:"is_active" gorm:"default:true;index"`
-	UsageCount   int            `json:"usage_count" gorm:"default:0;index"`
-	Metadata     datatypes.JSON `json:"metadata" gorm:"type:json"`
-	Content      datatypes.JSON `json:"content" gorm:"type:json"`
-	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	Name               string         `json:"name" gorm:"not null;size:200;index"`
+	Slug               string         `json:"slug" gorm:"unique;not null;size:200;index"`
+	Description        string         `json:"description" gorm:"type:text"`
+	DisplayOrder       int            `json:"display_order" gorm:"default:0;index"`
+	IsActive           bool           `json:"is_active" gorm:"default:true;index"`
+	UsageCount         int            `json:"usage_count" gorm:"default:0;index"`
+	Metadata           datatypes.JSON `json:"metadata" gorm:"type:json"`
+	Content            datatypes.JSON `json:"content" gorm:"type:json"`
+	Locale             string         `json:"locale" gorm:"type:varchar(10);default:'vi';index"`
+	TranslationGroupID *uuid.UUID     `json:"translation_group_id" gorm:"type:char(36);index"` // Các bản dịch của cùng nội dung có chung group
+	CreatedAt          time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // BeforeCreate hook để tự động tạo UUID
@@ -29,6 +31,7 @@ func (t *Tag) BeforeCreate(tx *gorm.DB) (err error) {
 	if t.ID == uuid.Nil {
 		t.ID = uuid.New()
 	}
+	ensureTranslationGroup(t.ID, &t.TranslationGroupID)
 	return
 }
 
@@ -69,14 +72,16 @@ type ImageTag struct {
 
 // Input structs
 type TagInput struct {
-	Name         string       `json:"name" binding:"required,min=1,max=200"`
-	Slug         string       `json:"slug" binding:"required,min=1,max=200"`
-	Color        string       `json:"color" binding:"omitempty,len=7"`
-	Description  string       `json:"description" binding:"max=1000"`
-	DisplayOrder *int         `json:"display_order,omitempty"`
-	IsActive     *bool        `json:"is_active,omitempty"`
-	Metadata     *TagMetadata `json:"metadata,omitempty"`
-	Content      *TagContent  `json:"content,omitempty"`
+	Name          string       `json:"name" binding:"required,min=1,max=200"`
+	Slug          string       `json:"slug" binding:"required,min=1,max=200"`
+	Color         string       `json:"color" binding:"omitempty,len=7"`
+	Description   string       `json:"description" binding:"max=1000"`
+	DisplayOrder  *int         `json:"display_order,omitempty"`
+	IsActive      *bool        `json:"is_active,omitempty"`
+	Metadata      *TagMetadata `json:"metadata,omitempty"`
+	Content       *TagContent  `json:"content,omitempty"`
+	Locale        string       `json:"locale,omitempty"`
+	TranslationOf *uuid.UUID   `json:"translation_of,omitempty"`
 }
 
 type TagUpdateInput struct {
@@ -92,18 +97,21 @@ type TagUpdateInput struct {
 
 // Response structs
 type TagResponse struct {
-	ID           uuid.UUID    `json:"id"`
-	Name         string       `json:"name"`
-	Slug         string       `json:"slug"`
-	Description  string       `json:"description"`
-	DisplayOrder int          `json:"display_order"`
-	IsActive     bool         `json:"is_active"`
-	UsageCount   int          `json:"usage_count"`
-	Metadata     *TagMetadata `json:"metadata"`
-	Content      *TagContent  `json:"content"`
-	NewsCount    int          `json:"news_count,omitempty"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
+	ID                 uuid.UUID         `json:"id"`
+	Name               string            `json:"name"`
+	Slug               string            `json:"slug"`
+	Description        string            `json:"description"`
+	DisplayOrder       int               `json:"display_order"`
+	IsActive           bool              `json:"is_active"`
+	UsageCount         int               `json:"usage_count"`
+	Metadata           *TagMetadata      `json:"metadata"`
+	Content            *TagContent       `json:"content"`
+	NewsCount          int               `json:"news_count,omitempty"`
+	Locale             string            `json:"locale"`
+	TranslationGroupID *uuid.UUID        `json:"translation_group_id"`
+	Translations       []TranslationLink `json:"translations,omitempty"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
 }
 
 type TagShortResponse struct {
@@ -121,15 +129,17 @@ type TagSimpleResponse struct {
 // ToResponse chuyển Tag thành TagResponse
 func (t *Tag) ToResponse() TagResponse {
 	resp := TagResponse{
-		ID:           t.ID,
-		Name:         t.Name,
-		Slug:         t.Slug,
-		Description:  t.Description,
-		DisplayOrder: t.DisplayOrder,
-		IsActive:     t.IsActive,
-		UsageCount:   t.UsageCount,
-		CreatedAt:    t.CreatedAt,
-		UpdatedAt:    t.UpdatedAt,
+		ID:                 t.ID,
+		Name:               t.Name,
+		Slug:               t.Slug,
+		Description:        t.Description,
+		DisplayOrder:       t.DisplayOrder,
+		IsActive:           t.IsActive,
+		UsageCount:         t.UsageCount,
+		Locale:             t.Locale,
+		TranslationGroupID: t.TranslationGroupID,
+		CreatedAt:          t.CreatedAt,
+		UpdatedAt:          t.UpdatedAt,
 	}
 
 	// Parse metadata từ JSON sang struct
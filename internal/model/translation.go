@@ -0,0 +1,18 @@
+package model
+
+import "github.com/google/uuid"
+
+// TranslationLink - Liên kết tới một bản dịch khác của cùng nội dung
+type TranslationLink struct {
+	ID     uuid.UUID `json:"id"`
+	Locale string    `json:"locale"`
+	Slug   string    `json:"slug"`
+}
+
+// ensureTranslationGroup gán nhóm bản dịch mặc định là chính ID của bản ghi
+func ensureTranslationGroup(id uuid.UUID, group **uuid.UUID) {
+	if *group == nil || **group == uuid.Nil {
+		g := id
+		*group = &g
+	}
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Upload - Sổ ghi các lần cấp URL upload, dùng để tính dung lượng mỗi người dùng đã dùng (quota).
+// Trước khi xác nhận tính theo DeclaredSize (mức tối đa storage cho phép), sau khi xác nhận tính theo Size thật.
+// File được upload vào khu chờ pending/{ObjectKey}; mọi lần upload (kể cả của khách) đều được kiểm tra,
+// Status dùng chung các giá trị MediaStatus*
+type Upload struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:char(36);primaryKey"`
+	ObjectKey    string         `json:"object_key" gorm:"not null;size:500;uniqueIndex"`
+	Folder       string         `json:"folder" gorm:"type:varchar(20);not null"`
+	ContentType  string         `json:"content_type" gorm:"type:varchar(150);not null"`
+	DeclaredSize int64          `json:"declared_size" gorm:"not null"`
+	Size         int64          `json:"size" gorm:"not null;default:0"` // 0 = chưa xác nhận
+	UploadedByID *uuid.UUID     `json:"uploaded_by_id,omitempty" gorm:"type:char(36);index"`
+	TicketID     *uuid.UUID     `json:"ticket_id,omitempty" gorm:"type:char(36);index"`
+	IPAddress    string         `json:"ip_address,omitempty" gorm:"type:varchar(45)"`
+	Status       string         `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"` // pending, clean, rejected
+	ScanResult   string         `json:"scan_result,omitempty" gorm:"size:500"`
+	ScanAttempts int            `json:"scan_attempts" gorm:"not null;default:0"`
+	ScannedAt    *time.Time     `json:"scanned_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (Upload) TableName() string {
+	return "uploads"
+}
+
+func (u *Upload) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return
+}
+
+// UploadQuotaLock - Dòng khóa của một phạm vi quota (mỗi người dùng, tổng dung lượng của khách).
+// Ghi sổ upload giữ dòng này FOR UPDATE để các request đồng thời được xét quota lần lượt
+type UploadQuotaLock struct {
+	Scope     string    `json:"scope" gorm:"primaryKey;size:50"` // user:{id} hoặc guest
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (UploadQuotaLock) TableName() string {
+	return "upload_quota_locks"
+}
+
+// UploadTicket - Vé upload dùng một lần cho khách chưa đăng nhập (vd đính kèm hồ sơ tư vấn)
+type UploadTicket struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	IPAddress string     `json:"ip_address" gorm:"type:varchar(45)"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (UploadTicket) TableName() string {
+	return "upload_tickets"
+}
+
+func (t *UploadTicket) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
+
+// Trạng thái phiên upload nhiều phần
+const (
+	MultipartStatusUploading = "uploading"
+	MultipartStatusCompleted = "completed"
+	MultipartStatusAborted   = "aborted" // Người dùng hủy hoặc hết hạn bị dọn
+)
+
+// MultipartUpload - Phiên upload nhiều phần đang mở trên storage (video, âm thanh dung lượng lớn).
+// Phiên quá ExpiresAt bị job nền hủy để storage xóa các phần đã upload
+type MultipartUpload struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey"`
+	UploadID     string     `json:"-" gorm:"not null;size:500"` // Upload ID do storage cấp
+	ObjectKey    string     `json:"key" gorm:"not null;size:500;uniqueIndex"`
+	Folder       string     `json:"folder" gorm:"type:varchar(20);not null"`
+	ContentType  string     `json:"content_type" gorm:"type:varchar(150);not null"`
+	FileName     string     `json:"file_name" gorm:"size:255"`
+	Size         int64      `json:"size" gorm:"not null"`      // Kích thước khai báo, tổng các phần không được vượt quá
+	PartSize     int64      `json:"part_size" gorm:"not null"` // Mọi phần trừ phần cuối có đúng kích thước này
+	PartCount    int        `json:"part_count" gorm:"not null"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'uploading';index"` // uploading, completed, aborted
+	UploadedByID uuid.UUID  `json:"uploaded_by_id" gorm:"type:char(36);not null;index"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (MultipartUpload) TableName() string {
+	return "multipart_uploads"
+}
+
+func (m *MultipartUpload) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
+
+// IsActive kiểm tra phiên còn nhận phần upload
+func (m *MultipartUpload) IsActive(now time.Time) bool {
+	return m.Status == MultipartStatusUploading && now.Before(m.ExpiresAt)
+}
+
+// MultipartInitInput - Mở phiên upload nhiều phần
+type MultipartInitInput struct {
+	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+	FileName    string `json:"file_name" binding:"max=255"`
+}
+
+// MultipartPartsInput - Các phần cần URL upload (tối đa 100 mỗi lần)
+type MultipartPartsInput struct {
+	PartNumbers []int `json:"part_numbers" binding:"required,min=1,max=100,dive,min=1"`
+}
+
+// StorageUsage - Dung lượng đã dùng của một người (hoặc của khách nếu UserID nil)
+type StorageUsage struct {
+	UserID *uuid.UUID `json:"user_id"`
+	Files  int64      `json:"files"`
+	Bytes  int64      `json:"bytes"`
+}
@@ -31,7 +31,8 @@ type User struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Quan hệ (constraints handled manually in database.go)
-	Articles []Article `json:"articles,omitempty" gorm:"foreignKey:AuthorID"`
+	Articles []Article      `json:"articles,omitempty" gorm:"foreignKey:AuthorID"`
+	Profile  *AuthorProfile `json:"profile,omitempty" gorm:"foreignKey:UserID"`
 }
 
 type Avatar struct {
@@ -147,3 +148,15 @@ func (u *User) ToResponse() UserResponse {
 
 	return response
 }
+
+// ToAuthorResponse - Thông tin tác giả công khai, lấy từ hồ sơ (nếu có và đang hoạt động)
+func (u *User) ToAuthorResponse() *AuthorResponse {
+	if u.Profile != nil && u.Profile.IsActive {
+		return u.Profile.ToAuthorResponse()
+	}
+	userID := u.ID
+	return &AuthorResponse{
+		ID:       &userID,
+		FullName: u.FullName,
+	}
+}
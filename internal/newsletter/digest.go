@@ -0,0 +1,324 @@
+package newsletter
+
+import (
+	"backend/internal/helpers"
+	"backend/internal/mailer"
+	"backend/internal/model"
+	"backend/internal/repo"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrDigestRunning - Đang có một lần gửi bản tin khác chạy
+var ErrDigestRunning = errors.New("bản tin đang được gửi")
+
+var runMu sync.Mutex
+
+// DigestArticle - Một bài viết trong bản tin
+type DigestArticle struct {
+	Title       string
+	Description string
+	URL         string
+	PublishedAt *time.Time
+}
+
+// DigestSection - Nhóm bài viết theo một chủ đề (danh mục/tag)
+type DigestSection struct {
+	Title    string
+	Articles []DigestArticle
+}
+
+// Issue - Bản tin của một người đăng ký
+type Issue struct {
+	Subscriber   model.Subscriber
+	Sections     []DigestSection
+	ArticleCount int
+}
+
+// Result - Kết quả soạn/gửi bản tin
+type Result struct {
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+	Articles   int       `json:"articles"`   // Số bài mới xuất bản trong khoảng
+	Recipients int       `json:"recipients"` // Số người đăng ký có ít nhất một bài phù hợp
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+}
+
+// Preview - Kết quả soạn thử kèm một email mẫu
+type Preview struct {
+	Result
+	SampleTo      string `json:"sample_to,omitempty"`
+	SampleSubject string `json:"sample_subject,omitempty"`
+	SampleHTML    string `json:"sample_html,omitempty"`
+}
+
+// lookback số ngày lấy bài khi chưa từng gửi bản tin (env NEWSLETTER_DIGEST_LOOKBACK_DAYS, mặc định 7)
+func lookback() time.Duration {
+	days := 7
+	if v, err := strconv.Atoi(os.Getenv("NEWSLETTER_DIGEST_LOOKBACK_DAYS")); err == nil && v > 0 {
+		days = v
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// subscriberSince - Mốc lấy bài của một người đăng ký: lần cuối nhận bản tin, chưa nhận lần nào thì lùi lookback.
+// Người gửi lỗi hoặc không có bài phù hợp ở lần trước vẫn nhận đủ bài từ lần cuối thực sự nhận được
+func subscriberSince(subscriber model.Subscriber, now time.Time) time.Time {
+	if subscriber.LastDigestAt != nil {
+		return *subscriber.LastDigestAt
+	}
+	return now.Add(-lookback())
+}
+
+// compose gom bài viết mới xuất bản từ lần nhận trước của từng người đăng ký và chia theo chủ đề của họ.
+// Result.Since là mốc sớm nhất trong các người đăng ký
+func compose(newsletterRepo *repo.NewsletterRepo, now time.Time) (Result, []Issue, error) {
+	result := Result{Since: now.Add(-lookback()), Until: now}
+	subscribers, err := newsletterRepo.GetConfirmedSubscribers()
+	if err != nil {
+		return result, nil, err
+	}
+	for _, subscriber := range subscribers {
+		if since := subscriberSince(subscriber, now); since.Before(result.Since) {
+			result.Since = since
+		}
+	}
+
+	articles, err := newsletterRepo.GetPublishedArticlesBetween(result.Since, result.Until)
+	if err != nil {
+		return result, nil, err
+	}
+	result.Articles = len(articles)
+	if len(articles) == 0 {
+		return result, nil, nil
+	}
+
+	categoryNames, tagNames, err := topicNames(subscribers)
+	if err != nil {
+		return result, nil, err
+	}
+
+	var issues []Issue
+	for _, subscriber := range subscribers {
+		issue := buildIssue(subscriber, articles, subscriberSince(subscriber, now), categoryNames, tagNames)
+		if issue.ArticleCount > 0 {
+			issues = append(issues, issue)
+		}
+	}
+	result.Recipients = len(issues)
+	return result, issues, nil
+}
+
+// topicNames lấy tên các danh mục và tag mà người đăng ký theo dõi (làm tiêu đề nhóm)
+func topicNames(subscribers []model.Subscriber) (map[uuid.UUID]string, map[uuid.UUID]string, error) {
+	var categoryIDs, tagIDs []uuid.UUID
+	for i := range subscribers {
+		categoryIDs = append(categoryIDs, subscribers[i].GetCategoryIDs()...)
+		tagIDs = append(tagIDs, subscribers[i].GetTagIDs()...)
+	}
+
+	categoryNames := map[uuid.UUID]string{}
+	categories, err := repo.NewCategoryRepo().GetByIDs(categoryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	tagNames := map[uuid.UUID]string{}
+	tags, err := repo.NewTagRepo().GetByIDs(tagIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tag := range tags {
+		tagNames[tag.ID] = tag.Name
+	}
+
+	return categoryNames, tagNames, nil
+}
+
+// buildIssue chia bài viết cùng ngôn ngữ, xuất bản sau since, theo danh mục rồi tới tag người đăng ký quan tâm,
+// mỗi bài chỉ xuất hiện một lần; không chọn chủ đề nào thì nhận mọi bài mới
+func buildIssue(subscriber model.Subscriber, articles []model.Article, since time.Time, categoryNames, tagNames map[uuid.UUID]string) Issue {
+	issue := Issue{Subscriber: subscriber}
+
+	var candidates []*model.Article
+	for i := range articles {
+		if articles[i].Locale == subscriber.Locale && articles[i].PublishedAt != nil && articles[i].PublishedAt.After(since) {
+			candidates = append(candidates, &articles[i])
+		}
+	}
+
+	used := map[uuid.UUID]bool{}
+	addSection := func(title string, match func(a *model.Article) bool) {
+		section := DigestSection{Title: title}
+		for _, article := range candidates {
+			if used[article.ID] || !match(article) {
+				continue
+			}
+			used[article.ID] = true
+			section.Articles = append(section.Articles, DigestArticle{
+				Title:       article.Title,
+				Description: article.Description,
+				URL:         helpers.PublicBaseURL() + helpers.LocalizedPath(article.Locale, "/bai-viet/"+article.Slug),
+				PublishedAt: article.PublishedAt,
+			})
+		}
+		if len(section.Articles) > 0 {
+			issue.Sections = append(issue.Sections, section)
+			issue.ArticleCount += len(section.Articles)
+		}
+	}
+
+	categoryIDs := subscriber.GetCategoryIDs()
+	tagIDs := subscriber.GetTagIDs()
+	if len(categoryIDs) == 0 && len(tagIDs) == 0 {
+		addSection(copyFor(subscriber.Locale)["AllArticles"], func(*model.Article) bool { return true })
+		return issue
+	}
+
+	// Bỏ qua chủ đề đã bị xóa hoặc ẩn
+	for _, categoryID := range categoryIDs {
+		id := categoryID
+		name, ok := categoryNames[id]
+		if !ok {
+			continue
+		}
+		addSection(name, func(a *model.Article) bool {
+			return a.CategoryID != nil && *a.CategoryID == id
+		})
+	}
+	for _, tagID := range tagIDs {
+		id := tagID
+		name, ok := tagNames[id]
+		if !ok {
+			continue
+		}
+		addSection("#"+name, func(a *model.Article) bool {
+			for _, t := range a.GetTagIDs() {
+				if t == id {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return issue
+}
+
+// message dựng email bản tin cho một người đăng ký
+func (issue Issue) message() (mailer.Message, error) {
+	subscriber := issue.Subscriber
+	text := copyFor(subscriber.Locale)
+	html, plain, err := render("digest", map[string]interface{}{
+		"Locale":         subscriber.Locale,
+		"Name":           subscriber.Name,
+		"Copy":           text,
+		"Sections":       issue.Sections,
+		"UnsubscribeURL": UnsubscribeURL(subscriber.Locale, subscriber.UnsubscribeToken),
+	})
+	if err != nil {
+		return mailer.Message{}, err
+	}
+	return mailer.Message{
+		To:      subscriber.Email,
+		Subject: fmt.Sprintf(text["DigestSubject"], issue.ArticleCount),
+		HTML:    html,
+		Text:    plain,
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + oneClickUnsubscribeURL(subscriber.UnsubscribeToken) + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
+
+// PreviewDigest soạn thử bản tin (không gửi, không ghi lịch sử)
+func PreviewDigest(now time.Time) (Preview, error) {
+	result, issues, err := compose(repo.NewNewsletterRepo(), now)
+	preview := Preview{Result: result}
+	if err != nil || len(issues) == 0 {
+		return preview, err
+	}
+
+	msg, err := issues[0].message()
+	if err != nil {
+		return preview, err
+	}
+	preview.SampleTo = msg.To
+	preview.SampleSubject = msg.Subject
+	preview.SampleHTML = msg.HTML
+	return preview, nil
+}
+
+// Run soạn và gửi bản tin cho mọi người đăng ký đã xác nhận, rồi ghi lịch sử gửi
+// (người nhận thành công lần sau chỉ nhận bài xuất bản sau thời điểm này)
+func Run(now time.Time) (Result, error) {
+	if !runMu.TryLock() {
+		return Result{}, ErrDigestRunning
+	}
+	defer runMu.Unlock()
+
+	newsletterRepo := repo.NewNewsletterRepo()
+	result, issues, err := compose(newsletterRepo, now)
+	if err != nil {
+		return result, err
+	}
+
+	m := mailer.Get()
+	sentIDs := make([]uuid.UUID, 0, len(issues))
+	for _, issue := range issues {
+		msg, err := issue.message()
+		if err == nil {
+			err = m.Send(msg)
+		}
+		if err != nil {
+			result.Failed++
+			log.Printf("⚠️  Warning: Failed to send newsletter to %s: %v", issue.Subscriber.Email, err)
+			continue
+		}
+		result.Sent++
+		sentIDs = append(sentIDs, issue.Subscriber.ID)
+	}
+
+	if err := newsletterRepo.MarkDigestSent(sentIDs, now); err != nil {
+		return result, err
+	}
+	err = newsletterRepo.CreateDigest(&model.NewsletterDigest{
+		SinceAt:        result.Since,
+		SentAt:         now,
+		ArticleCount:   result.Articles,
+		RecipientCount: result.Sent,
+		FailedCount:    result.Failed,
+	})
+	return result, err
+}
+
+// Start gửi bản tin định kỳ mỗi NEWSLETTER_DIGEST_INTERVAL_HOURS giờ (mặc định 0 = tắt, chỉ gửi thủ công)
+func Start() {
+	hours, _ := strconv.Atoi(os.Getenv("NEWSLETTER_DIGEST_INTERVAL_HOURS"))
+	if hours <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(hours) * time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			result, err := Run(time.Now())
+			if err != nil {
+				log.Printf("⚠️  Warning: Newsletter digest failed: %v", err)
+			} else if result.Sent > 0 || result.Failed > 0 {
+				log.Printf("📰 Newsletter digest: %d articles, %d sent, %d failed", result.Articles, result.Sent, result.Failed)
+			}
+		}
+	}()
+}
@@ -0,0 +1,143 @@
+package newsletter
+
+import (
+	"backend/internal/model"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func jsonIDs(t *testing.T, ids ...uuid.UUID) datatypes.JSON {
+	t.Helper()
+	if len(ids) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v", err)
+	}
+	return raw
+}
+
+func TestSubscriberSince(t *testing.T) {
+	t.Setenv("NEWSLETTER_DIGEST_LOOKBACK_DAYS", "")
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	lastDigest := now.AddDate(0, 0, -2)
+
+	tests := []struct {
+		name       string
+		subscriber model.Subscriber
+		want       time.Time
+	}{
+		{"chưa nhận bản tin lần nào", model.Subscriber{}, now.AddDate(0, 0, -7)},
+		{"từ lần cuối nhận bản tin", model.Subscriber{LastDigestAt: &lastDigest}, lastDigest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subscriberSince(tt.subscriber, now); !got.Equal(tt.want) {
+				t.Errorf("subscriberSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("lookback theo env", func(t *testing.T) {
+		t.Setenv("NEWSLETTER_DIGEST_LOOKBACK_DAYS", "3")
+		if got, want := subscriberSince(model.Subscriber{}, now), now.AddDate(0, 0, -3); !got.Equal(want) {
+			t.Errorf("subscriberSince() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestBuildIssue(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := since.Add(d)
+		return &v
+	}
+	categoryLaw, categoryTax, categoryHidden := uuid.New(), uuid.New(), uuid.New()
+	tagLand := uuid.New()
+
+	article := func(t *testing.T, slug, locale string, publishedAt *time.Time, categoryID *uuid.UUID, tags ...uuid.UUID) model.Article {
+		return model.Article{ID: uuid.New(), Slug: slug, Title: slug, Locale: locale, PublishedAt: publishedAt, CategoryID: categoryID, TagIDs: jsonIDs(t, tags...)}
+	}
+	articles := []model.Article{
+		article(t, "truoc-moc", "vi", at(-time.Hour), &categoryLaw),
+		article(t, "dung-moc", "vi", at(0), &categoryLaw),
+		article(t, "luat-moi", "vi", at(time.Hour), &categoryLaw, tagLand),
+		article(t, "thue-moi", "vi", at(2*time.Hour), &categoryTax),
+		article(t, "dat-dai", "vi", at(3*time.Hour), nil, tagLand),
+		article(t, "an-danh-muc", "vi", at(4*time.Hour), &categoryHidden),
+		article(t, "ban-tieng-anh", "en", at(5*time.Hour), &categoryLaw),
+		article(t, "chua-co-ngay", "vi", nil, &categoryLaw),
+	}
+	categoryNames := map[uuid.UUID]string{categoryLaw: "Pháp luật", categoryTax: "Thuế"}
+	tagNames := map[uuid.UUID]string{tagLand: "đất đai"}
+
+	type section struct {
+		Title string
+		Slugs []string
+	}
+	tests := []struct {
+		name       string
+		subscriber model.Subscriber
+		want       []section
+	}{
+		{
+			"không chọn chủ đề nhận mọi bài mới cùng ngôn ngữ",
+			model.Subscriber{Locale: "vi"},
+			[]section{{"Bài viết mới", []string{"luat-moi", "thue-moi", "dat-dai", "an-danh-muc"}}},
+		},
+		{
+			"bản tiếng Anh",
+			model.Subscriber{Locale: "en"},
+			[]section{{"New articles", []string{"ban-tieng-anh"}}},
+		},
+		{
+			"theo danh mục rồi tag, mỗi bài một lần",
+			model.Subscriber{Locale: "vi", CategoryIDs: jsonIDs(t, categoryLaw, categoryTax), TagIDs: jsonIDs(t, tagLand)},
+			[]section{
+				{"Pháp luật", []string{"luat-moi"}},
+				{"Thuế", []string{"thue-moi"}},
+				{"#đất đai", []string{"dat-dai"}},
+			},
+		},
+		{
+			"bỏ qua chủ đề đã xóa hoặc ẩn",
+			model.Subscriber{Locale: "vi", CategoryIDs: jsonIDs(t, categoryHidden)},
+			nil,
+		},
+		{
+			"không có bài phù hợp",
+			model.Subscriber{Locale: "en", CategoryIDs: jsonIDs(t, categoryTax)},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := buildIssue(tt.subscriber, articles, since, categoryNames, tagNames)
+
+			var got []section
+			count := 0
+			for _, s := range issue.Sections {
+				var slugs []string
+				for _, a := range s.Articles {
+					slugs = append(slugs, a.Title)
+				}
+				count += len(slugs)
+				got = append(got, section{s.Title, slugs})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildIssue() sections = %v, want %v", got, tt.want)
+			}
+			if issue.ArticleCount != count {
+				t.Errorf("buildIssue() ArticleCount = %d, want %d", issue.ArticleCount, count)
+			}
+		})
+	}
+}
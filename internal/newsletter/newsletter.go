@@ -0,0 +1,197 @@
+package newsletter
+
+import (
+	"backend/internal/consts"
+	"backend/internal/helpers"
+	"backend/internal/mailer"
+	"backend/internal/model"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl"))
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt.tmpl"))
+)
+
+// copyText - Câu chữ trong email theo ngôn ngữ của người đăng ký
+var copyText = map[string]map[string]string{
+	consts.LocaleVI: {
+		"Greeting":        "Xin chào",
+		"ConfirmSubject":  "Xác nhận đăng ký nhận bản tin",
+		"ConfirmIntro":    "Cảm ơn bạn đã đăng ký nhận bản tin. Vui lòng bấm nút bên dưới để xác nhận địa chỉ email.",
+		"ConfirmButton":   "Xác nhận đăng ký",
+		"ConfirmIgnore":   "Nếu bạn không đăng ký, hãy bỏ qua email này.",
+		"DigestSubject":   "Bản tin: %d bài viết mới",
+		"DigestIntro":     "Dưới đây là các bài viết mới theo chủ đề bạn quan tâm.",
+		"AllArticles":     "Bài viết mới",
+		"ReadMore":        "Đọc tiếp",
+		"UnsubscribeNote": "Bạn nhận email này vì đã đăng ký nhận bản tin.",
+		"Unsubscribe":     "Hủy đăng ký",
+		"DownloadSubject": "Link tải tài liệu: %s",
+		"DownloadIntro":   "Đây là link tải tài liệu bạn yêu cầu. Link có hiệu lực tới %s.",
+		"DownloadButton":  "Tải tài liệu",
+		"DownloadIgnore":  "Nếu bạn không yêu cầu tài liệu này, hãy bỏ qua email này.",
+	},
+	consts.LocaleEN: {
+		"Greeting":        "Hello",
+		"ConfirmSubject":  "Confirm your newsletter subscription",
+		"ConfirmIntro":    "Thanks for subscribing to our newsletter. Please click the button below to confirm your email address.",
+		"ConfirmButton":   "Confirm subscription",
+		"ConfirmIgnore":   "If you did not subscribe, you can ignore this email.",
+		"DigestSubject":   "Newsletter: %d new articles",
+		"DigestIntro":     "Here are the latest articles on the topics you follow.",
+		"AllArticles":     "New articles",
+		"ReadMore":        "Read more",
+		"UnsubscribeNote": "You are receiving this email because you subscribed to our newsletter.",
+		"Unsubscribe":     "Unsubscribe",
+		"DownloadSubject": "Your download link: %s",
+		"DownloadIntro":   "Here is the download link you requested. It is valid until %s.",
+		"DownloadButton":  "Download",
+		"DownloadIgnore":  "If you did not request this document, you can ignore this email.",
+	},
+}
+
+func copyFor(locale string) map[string]string {
+	if text, ok := copyText[locale]; ok {
+		return text
+	}
+	return copyText[consts.DefaultLocale]
+}
+
+// NewToken tạo token ngẫu nhiên (hex 64 ký tự)
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken băm token xác nhận trước khi lưu
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ConfirmTTL thời hạn của link xác nhận (env NEWSLETTER_CONFIRM_TTL_HOURS, mặc định 48 giờ)
+func ConfirmTTL() time.Duration {
+	hours := 48
+	if v, err := strconv.Atoi(os.Getenv("NEWSLETTER_CONFIRM_TTL_HOURS")); err == nil && v > 0 {
+		hours = v
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// apiBase trả về địa chỉ API công khai (env NEWSLETTER_API_BASE_URL, mặc định cùng domain trang công khai)
+func apiBase() string {
+	if v := os.Getenv("NEWSLETTER_API_BASE_URL"); v != "" {
+		return strings.TrimRight(v, "/")
+	}
+	return helpers.PublicBaseURL()
+}
+
+// ConfirmURL link trang xác nhận đăng ký trên frontend
+func ConfirmURL(locale, token string) string {
+	return helpers.PublicBaseURL() + helpers.LocalizedPath(locale, "/ban-tin/xac-nhan") + "?token=" + url.QueryEscape(token)
+}
+
+// UnsubscribeURL link trang hủy đăng ký trên frontend
+func UnsubscribeURL(locale, token string) string {
+	return helpers.PublicBaseURL() + helpers.LocalizedPath(locale, "/ban-tin/huy-dang-ky") + "?token=" + url.QueryEscape(token)
+}
+
+// oneClickUnsubscribeURL link API cho header List-Unsubscribe (RFC 8058, client mail gửi POST)
+func oneClickUnsubscribeURL(token string) string {
+	return apiBase() + "/api/newsletter/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+// DownloadURL link API tải tệp dành cho người đăng ký (path dạng /api/..., token do backend ký)
+func DownloadURL(path, token string) string {
+	return apiBase() + path + "?token=" + url.QueryEscape(token)
+}
+
+// render dựng nội dung HTML và text từ cặp template cùng tên
+func render(name string, data interface{}) (string, string, error) {
+	var html, text bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return "", "", err
+	}
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return "", "", err
+	}
+	return html.String(), text.String(), nil
+}
+
+// SendConfirmation gửi email xác nhận đăng ký (bất đồng bộ, lỗi chỉ được ghi log)
+func SendConfirmation(subscriber model.Subscriber, token string) {
+	text := copyFor(subscriber.Locale)
+	html, plain, err := render("confirm", map[string]interface{}{
+		"Locale":     subscriber.Locale,
+		"Name":       subscriber.Name,
+		"Copy":       text,
+		"ConfirmURL": ConfirmURL(subscriber.Locale, token),
+	})
+	if err != nil {
+		log.Printf("⚠️  Warning: Failed to render newsletter confirmation: %v", err)
+		return
+	}
+
+	msg := mailer.Message{
+		To:      subscriber.Email,
+		Subject: text["ConfirmSubject"],
+		HTML:    html,
+		Text:    plain,
+	}
+	m := mailer.Get()
+	go func() {
+		if err := m.Send(msg); err != nil {
+			log.Printf("⚠️  Warning: Failed to send newsletter confirmation to %s: %v", subscriber.Email, err)
+		}
+	}()
+}
+
+// SendDownloadLink gửi link tải tệp dành cho người đăng ký (bất đồng bộ, lỗi chỉ được ghi log)
+func SendDownloadLink(subscriber model.Subscriber, title, downloadURL string, expiresAt time.Time) {
+	text := copyFor(subscriber.Locale)
+	html, plain, err := render("download", map[string]interface{}{
+		"Locale":      subscriber.Locale,
+		"Name":        subscriber.Name,
+		"Copy":        text,
+		"Title":       title,
+		"Intro":       fmt.Sprintf(text["DownloadIntro"], expiresAt.Format("15:04 02/01/2006")),
+		"DownloadURL": downloadURL,
+	})
+	if err != nil {
+		log.Printf("⚠️  Warning: Failed to render download link email: %v", err)
+		return
+	}
+
+	msg := mailer.Message{
+		To:      subscriber.Email,
+		Subject: fmt.Sprintf(text["DownloadSubject"], title),
+		HTML:    html,
+		Text:    plain,
+	}
+	m := mailer.Get()
+	go func() {
+		if err := m.Send(msg); err != nil {
+			log.Printf("⚠️  Warning: Failed to send download link to %s: %v", subscriber.Email, err)
+		}
+	}()
+}
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Loại thông báo
+const (
+	EventConsultationCreated = "consultation.created"
+	EventCommentPending      = "comment.pending"
+	EventLegalReviewDue      = "legal_review.due"
+)
+
+// Notification - Nội dung một thông báo gửi tới nhân viên
+type Notification struct {
+	Event      string                 `json:"event"`
+	Title      string                 `json:"title"`
+	Message    string                 `json:"message"`
+	URL        string                 `json:"url,omitempty"` // Link tới trang quản trị liên quan
+	Data       map[string]interface{} `json:"data,omitempty"`
+	Recipients []string               `json:"recipients,omitempty"` // Email người nhận cụ thể; trống = gửi cho kênh chung
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// Notifier - Kênh gửi thông báo (log, webhook, email...). Có thể thay thế bằng SetNotifier
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// LogNotifier ghi thông báo ra log (mặc định khi chưa cấu hình kênh nào)
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(n Notification) error {
+	if len(n.Recipients) > 0 {
+		log.Printf("🔔 [%s] -> %s: %s - %s", n.Event, strings.Join(n.Recipients, ", "), n.Title, n.Message)
+		return nil
+	}
+	log.Printf("🔔 [%s] %s - %s", n.Event, n.Title, n.Message)
+	return nil
+}
+
+// WebhookNotifier gửi thông báo dạng JSON tới một URL (Slack/Zalo/Teams bridge...)
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *WebhookNotifier) Notify(n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	resp, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook trả về status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiNotifier gửi thông báo tới nhiều kênh, trả về lỗi đầu tiên gặp phải
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Notify(n Notification) error {
+	var firstErr error
+	for _, notifier := range m {
+		if err := notifier.Notify(n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+var (
+	current   Notifier
+	currentMu sync.RWMutex
+)
+
+// defaultNotifier dựng kênh thông báo từ env NOTIFY_WEBHOOK_URLS (phân tách bởi dấu phẩy)
+func defaultNotifier() Notifier {
+	notifiers := MultiNotifier{LogNotifier{}}
+	for _, url := range strings.Split(os.Getenv("NOTIFY_WEBHOOK_URLS"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			notifiers = append(notifiers, NewWebhookNotifier(url))
+		}
+	}
+	return notifiers
+}
+
+// SetNotifier thay thế kênh thông báo mặc định
+func SetNotifier(n Notifier) {
+	currentMu.Lock()
+	current = n
+	currentMu.Unlock()
+}
+
+// Get trả về kênh thông báo đang dùng
+func Get() Notifier {
+	currentMu.RLock()
+	n := current
+	currentMu.RUnlock()
+	if n != nil {
+		return n
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	if current == nil {
+		current = defaultNotifier()
+	}
+	return current
+}
+
+// Send gửi thông báo bất đồng bộ, lỗi chỉ được ghi log để không chặn request
+func Send(n Notification) {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	notifier := Get()
+	go func() {
+		if err := notifier.Notify(n); err != nil {
+			log.Printf("⚠️  Warning: Failed to send notification %s: %v", n.Event, err)
+		}
+	}()
+}
@@ -2,9 +2,11 @@ package repo
 
 import (
 	"backend/app"
+	"backend/internal/consts"
 	"backend/internal/model"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,12 +17,40 @@ type ArticleRepo struct {
 	db *gorm.DB
 }
 
-const (
-	articleStatusDraft = "draft"
-	articleStatusPost  = "post"
-)
+const articleStatusDraft = consts.ArticleStatusDraft
+
+var publishedStatuses = consts.ArticlePublishedStatuses
 
-var publishedStatuses = []string{articleStatusPost}
+// publishedStatusList - Danh sách trạng thái đã xuất bản dạng SQL ('post', ...) cho các câu truy vấn viết tay
+var publishedStatusList = "'" + strings.Join(publishedStatuses, "', '") + "'"
+
+// publishedCondition điều kiện để bài viết hiển thị công khai: đã xuất bản, đang hoạt động và đã tới giờ xuất bản
+// (bài có published_at ở tương lai là bài đặt lịch). prefix là alias bảng kèm dấu chấm, ví dụ "tr."
+func publishedCondition(prefix string) string {
+	return fmt.Sprintf("%[1]sstatus IN (%[2]s) AND %[1]sis_active = 1 AND (%[1]spublished_at IS NULL OR %[1]spublished_at <= NOW())",
+		prefix, publishedStatusList)
+}
+
+// publishedArticleWhere điều kiện bài viết hiển thị công khai cho các truy vấn trên bảng articles
+var publishedArticleWhere = publishedCondition("articles.")
+
+// publishedVariantFilter điều kiện để một bản dịch bài viết được coi là hiển thị công khai
+var publishedVariantFilter = publishedCondition("tr.")
+
+// articleLocaleScope lọc bài viết công khai theo ngôn ngữ, fallback về ngôn ngữ mặc định
+func articleLocaleScope(locale string) func(db *gorm.DB) *gorm.DB {
+	return localeScope("articles", locale, publishedVariantFilter)
+}
+
+// preloadArticleAuthors nạp tác giả chính (kèm hồ sơ), đồng tác giả theo thứ tự và người thẩm định
+func preloadArticleAuthors(db *gorm.DB) *gorm.DB {
+	return db.Preload("Author.Profile").
+		Preload("CoAuthors", func(db *gorm.DB) *gorm.DB {
+			return db.Order("article_co_authors.position ASC")
+		}).
+		Preload("CoAuthors.AuthorProfile").
+		Preload("ReviewedBy")
+}
 
 func NewArticleRepo() *ArticleRepo {
 	return &ArticleRepo{
@@ -30,13 +60,18 @@ func NewArticleRepo() *ArticleRepo {
 
 // Create tạo bài viết mới
 func (r *ArticleRepo) Create(article *model.Article) error {
-	return r.db.Create(article).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityArticle, article.ID)
+	})
 }
 
 // GetByID lấy bài viết theo ID
 func (r *ArticleRepo) GetByID(id uuid.UUID) (*model.Article, error) {
 	var article model.Article
-	err := r.db.Preload("Author").Preload("Category").
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
 		Where("id = ?", id).First(&article).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -50,7 +85,7 @@ func (r *ArticleRepo) GetByID(id uuid.UUID) (*model.Article, error) {
 // GetBySlug lấy bài viết theo slug
 func (r *ArticleRepo) GetBySlug(slug string) (*model.Article, error) {
 	var article model.Article
-	err := r.db.Preload("Author").Preload("Category").
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
 		Where("slug = ?", slug).First(&article).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -81,7 +116,7 @@ func (r *ArticleRepo) GetAll(page, limit int, published *bool) ([]model.Article,
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
 	if err != nil {
@@ -92,20 +127,21 @@ func (r *ArticleRepo) GetAll(page, limit int, published *bool) ([]model.Article,
 }
 
 // GetPublished lấy bài viết đã xuất bản với phân trang
-func (r *ArticleRepo) GetPublished(page, limit int) ([]model.Article, int64, error) {
+func (r *ArticleRepo) GetPublished(page, limit int, locale string) ([]model.Article, int64, error) {
 	var articles []model.Article
 	var total int64
 
 	offset := (page - 1) * limit
 
 	query := r.db.Model(&model.Article{}).
-		Where("status IN ? AND is_active = ?", publishedStatuses, true)
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(locale))
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("published_at DESC").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
@@ -129,7 +165,7 @@ func (r *ArticleRepo) GetByCategoryID(categoryID uuid.UUID, page, limit int) ([]
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
 	if err != nil {
@@ -139,11 +175,12 @@ func (r *ArticleRepo) GetByCategoryID(categoryID uuid.UUID, page, limit int) ([]
 	return articles, total, nil
 }
 
-// GetFeatured lấy bài viết nổi bật
-func (r *ArticleRepo) GetFeatured(limit int) ([]model.Article, error) {
+// GetFeatured lấy bài viết nổi bật (locale rỗng = mọi ngôn ngữ)
+func (r *ArticleRepo) GetFeatured(limit int, locale string) ([]model.Article, error) {
 	var articles []model.Article
-	err := r.db.Preload("Author").Preload("Category").
-		Where("status IN ? AND is_active = ? AND is_hot = ?", publishedStatuses, true, true).
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
+		Where(publishedArticleWhere+" AND is_hot = ?", true).
+		Scopes(articleLocaleScope(locale)).
 		Order("published_at DESC").
 		Order("view_count DESC").
 		Order("created_at DESC").
@@ -155,11 +192,12 @@ func (r *ArticleRepo) GetFeatured(limit int) ([]model.Article, error) {
 }
 
 // GetAllPublishedOrdered lấy tất cả bài viết đã xuất bản (limit tùy chọn) sắp xếp theo view_count desc
-func (r *ArticleRepo) GetAllPublishedOrdered(limit int) ([]model.Article, error) {
+func (r *ArticleRepo) GetAllPublishedOrdered(limit int, locale string) ([]model.Article, error) {
 	var articles []model.Article
 
-	query := r.db.Preload("Author").Preload("Category").
-		Where("status IN ? AND is_active = ?", publishedStatuses, true).
+	query := r.db.Scopes(preloadArticleAuthors).Preload("Category").
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(locale)).
 		Order("view_count DESC").
 		Order("published_at DESC").
 		Order("created_at DESC").
@@ -174,8 +212,8 @@ func (r *ArticleRepo) GetAllPublishedOrdered(limit int) ([]model.Article, error)
 // GetPublishedBySlug lấy bài viết public theo slug
 func (r *ArticleRepo) GetPublishedBySlug(slug string) (*model.Article, error) {
 	var article model.Article
-	err := r.db.Preload("Author").Preload("Category").
-		Where("slug = ? AND status IN ? AND is_active = ?", slug, publishedStatuses, true).
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
+		Where("slug = ? AND "+publishedArticleWhere, slug).
 		First(&article).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -187,22 +225,28 @@ func (r *ArticleRepo) GetPublishedBySlug(slug string) (*model.Article, error) {
 }
 
 // GetPublishedByCategorySlug lấy bài viết public theo slug danh mục
-func (r *ArticleRepo) GetPublishedByCategorySlug(slug string, page, limit int) ([]model.Article, int64, error) {
+// Bài viết gắn với mọi bản dịch của danh mục đều được tính, sau đó lọc theo ngôn ngữ
+func (r *ArticleRepo) GetPublishedByCategorySlug(slug string, page, limit int, locale string) ([]model.Article, int64, error) {
 	var articles []model.Article
 	var total int64
 
 	offset := (page - 1) * limit
 
+	categoryGroup := r.db.Model(&model.Category{}).
+		Select("translation_group_id").
+		Where("slug = ? AND is_active = ?", slug, true)
+
 	query := r.db.Model(&model.Article{}).
 		Joins("JOIN categories ON categories.id = articles.category_id").
-		Where("categories.slug = ? AND categories.is_active = ?", slug, true).
-		Where("articles.status IN ? AND articles.is_active = ?", publishedStatuses, true)
+		Where("categories.translation_group_id IN (?) AND categories.is_active = ?", categoryGroup, true).
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(locale))
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("articles.published_at DESC").
 		Order("articles.created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
@@ -214,7 +258,7 @@ func (r *ArticleRepo) GetPublishedByCategorySlug(slug string, page, limit int) (
 }
 
 // GetPublishedByTagID lấy bài viết public theo tag ID
-func (r *ArticleRepo) GetPublishedByTagID(tagID uuid.UUID, page, limit int) ([]model.Article, int64, error) {
+func (r *ArticleRepo) GetPublishedByTagID(tagID uuid.UUID, page, limit int, locale string) ([]model.Article, int64, error) {
 	var articles []model.Article
 	var total int64
 
@@ -223,13 +267,14 @@ func (r *ArticleRepo) GetPublishedByTagID(tagID uuid.UUID, page, limit int) ([]m
 	jsonContainsValue := fmt.Sprintf("\"%s\"", tagID.String())
 	query := r.db.Model(&model.Article{}).
 		Where("JSON_CONTAINS(tag_id, ?, '$')", jsonContainsValue).
-		Where("status IN ? AND is_active = ?", publishedStatuses, true)
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(locale))
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("published_at DESC").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
@@ -254,7 +299,7 @@ func (r *ArticleRepo) Search(keyword string, page, limit int) ([]model.Article,
 		return nil, 0, err
 	}
 
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
 	if err != nil {
@@ -270,6 +315,7 @@ type ArticleFilter struct {
 	CategoryID *uuid.UUID // Lọc theo category
 	TagID      *uuid.UUID // Lọc theo tag
 	Published  *bool      // Lọc theo trạng thái published
+	Locale     string     // Lọc theo ngôn ngữ (chính xác, không fallback)
 }
 
 // SearchWithFilters tìm kiếm bài viết với nhiều filter và phân trang tối ưu
@@ -308,6 +354,11 @@ func (r *ArticleRepo) SearchWithFilters(filter ArticleFilter, page, limit int) (
 		}
 	}
 
+	// Apply locale filter
+	if filter.Locale != "" {
+		query = query.Where("locale = ?", filter.Locale)
+	}
+
 	// Count total (use a separate query to avoid issues with Preload)
 	countQuery := r.db.Model(&model.Article{})
 	if filter.Search != "" {
@@ -328,13 +379,16 @@ func (r *ArticleRepo) SearchWithFilters(filter ArticleFilter, page, limit int) (
 			countQuery = countQuery.Where("status = ?", articleStatusDraft)
 		}
 	}
+	if filter.Locale != "" {
+		countQuery = countQuery.Where("locale = ?", filter.Locale)
+	}
 
 	if err := countQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch articles with pagination
-	err := query.Preload("Author").Preload("Category").
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
 		Order("created_at DESC").
 		Limit(limit).Offset(offset).Find(&articles).Error
 	if err != nil {
@@ -344,20 +398,25 @@ func (r *ArticleRepo) SearchWithFilters(filter ArticleFilter, page, limit int) (
 	return articles, total, nil
 }
 
-// Update cập nhật bài viết
+// Update cập nhật bài viết (đồng tác giả được cập nhật riêng qua SetCoAuthors).
+// Không ghi lượt xem và số bình luận: các bộ đếm này được cộng dồn riêng, ghi lại giá trị đọc lúc trước sẽ làm mất số đếm
 func (r *ArticleRepo) Update(article *model.Article) error {
-	return r.db.Save(article).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("CoAuthors", "ReviewedBy", "view_count", "comment_count").Save(article).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityArticle, article.ID)
+	})
 }
 
 // Delete xóa bài viết
 func (r *ArticleRepo) Delete(id uuid.UUID) error {
-	return r.db.Delete(&model.Article{}, "id = ?", id).Error
-}
-
-// IncrementViewCount tăng lượt xem
-func (r *ArticleRepo) IncrementViewCount(id uuid.UUID) error {
-	return r.db.Model(&model.Article{}).Where("id = ?", id).
-		UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.Article{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityArticle, id)
+	})
 }
 
 // CheckSlugExists kiểm tra slug đã tồn tại chưa
@@ -375,7 +434,7 @@ func (r *ArticleRepo) CheckSlugExists(slug string, excludeID uuid.UUID) (bool, e
 func (r *ArticleRepo) GetPublishedSlugs() ([]string, error) {
 	var slugs []string
 	err := r.db.Model(&model.Article{}).
-		Where("status IN ? AND is_active = ?", publishedStatuses, true).
+		Where(publishedArticleWhere).
 		Order("published_at DESC").Pluck("slug", &slugs).Error
 	return slugs, err
 }
@@ -384,14 +443,18 @@ func (r *ArticleRepo) GetPublishedSlugs() ([]string, error) {
 func (r *ArticleRepo) GetPublishedSlugsWithUpdatedAt(limit int) ([]struct{
 	Slug string
 	UpdatedAt time.Time
+	Locale string
+	TranslationGroupID *uuid.UUID
 }, error) {
 	var rows []struct{
 		Slug string
 		UpdatedAt time.Time
+		Locale string
+		TranslationGroupID *uuid.UUID
 	}
 	query := r.db.Model(&model.Article{}).
-		Select("slug, updated_at").
-		Where("status IN ? AND is_active = ?", publishedStatuses, true).
+		Select("slug, updated_at, locale, translation_group_id").
+		Where(publishedArticleWhere).
 		Order("published_at DESC")
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -399,3 +462,215 @@ func (r *ArticleRepo) GetPublishedSlugsWithUpdatedAt(limit int) ([]struct{
 	err := query.Find(&rows).Error
 	return rows, err
 }
+
+// GetPinnedRelated lấy các bài viết liên quan được ghim thủ công, theo thứ tự position
+func (r *ArticleRepo) GetPinnedRelated(articleID uuid.UUID, publishedOnly bool) ([]model.Article, error) {
+	var articles []model.Article
+	query := r.db.Model(&model.Article{}).
+		Joins("JOIN article_relations ON article_relations.related_id = articles.id").
+		Where("article_relations.article_id = ?", articleID)
+	if publishedOnly {
+		query = query.Where(publishedArticleWhere)
+	}
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
+		Order("article_relations.position ASC").
+		Find(&articles).Error
+	return articles, err
+}
+
+// GetPublishedByIDs lấy các bài viết đã xuất bản theo danh sách ID, giữ đúng thứ tự ID truyền vào
+func (r *ArticleRepo) GetPublishedByIDs(ids []uuid.UUID) ([]model.Article, error) {
+	if len(ids) == 0 {
+		return []model.Article{}, nil
+	}
+	var found []model.Article
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
+		Where("id IN ? AND "+publishedArticleWhere, ids).
+		Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]model.Article, len(found))
+	for _, article := range found {
+		byID[article.ID] = article
+	}
+	articles := make([]model.Article, 0, len(found))
+	for _, id := range ids {
+		if article, ok := byID[id]; ok {
+			articles = append(articles, article)
+			delete(byID, id)
+		}
+	}
+	return articles, nil
+}
+
+// SetPinnedRelated thay thế toàn bộ danh sách bài viết liên quan được ghim
+func (r *ArticleRepo) SetPinnedRelated(articleID uuid.UUID, relatedIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&model.ArticleRelation{}).Error; err != nil {
+			return err
+		}
+		for i, relatedID := range relatedIDs {
+			relation := model.ArticleRelation{
+				ArticleID: articleID,
+				RelatedID: relatedID,
+				Position:  i,
+			}
+			if err := tx.Create(&relation).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetRelatedCandidates lấy các bài viết đã xuất bản có chung tag hoặc cùng nhóm danh mục
+// với bài viết gốc, dùng làm ứng viên để chấm điểm bài viết liên quan
+func (r *ArticleRepo) GetRelatedCandidates(article *model.Article, categoryIDs []uuid.UUID, limit int) ([]model.Article, error) {
+	var articles []model.Article
+
+	conditions := r.db.Where("1 = 0")
+	if len(categoryIDs) > 0 {
+		conditions = conditions.Or("category_id IN ?", categoryIDs)
+	}
+	for _, tagID := range article.GetTagIDs() {
+		jsonContainsValue := fmt.Sprintf("\"%s\"", tagID.String())
+		conditions = conditions.Or("JSON_CONTAINS(tag_id, ?, '$')", jsonContainsValue)
+	}
+
+	err := r.db.Scopes(preloadArticleAuthors).Preload("Category").
+		Where("id != ?", article.ID).
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(article.Locale)).
+		Where(conditions).
+		Order("published_at DESC").
+		Order("created_at DESC").
+		Limit(limit).Find(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// GetTranslations trả về các bản dịch khác của bài viết (publishedOnly: chỉ bản đã xuất bản)
+func (r *ArticleRepo) GetTranslations(article *model.Article, publishedOnly bool) ([]model.TranslationLink, error) {
+	if publishedOnly {
+		return getTranslationLinks(r.db, &model.Article{}, article.TranslationGroupID, article.ID,
+			publishedArticleWhere)
+	}
+	return getTranslationLinks(r.db, &model.Article{}, article.TranslationGroupID, article.ID, "")
+}
+
+// CheckTranslationLocaleExists kiểm tra nhóm bản dịch đã có bài viết với locale này chưa
+func (r *ArticleRepo) CheckTranslationLocaleExists(groupID uuid.UUID, locale string, excludeID uuid.UUID) (bool, error) {
+	count, err := countTranslationLocale(r.db, &model.Article{}, groupID, locale, excludeID)
+	return count > 0, err
+}
+
+// authorProfileArticles lọc bài viết của một hồ sơ tác giả: tác giả chính (User gắn với hồ sơ) hoặc đồng tác giả
+func authorProfileArticles(profile *model.AuthorProfile) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		coAuthored := "articles.id IN (SELECT article_id FROM article_co_authors WHERE author_profile_id = ?)"
+		if profile.UserID == nil {
+			return db.Where(coAuthored, profile.ID)
+		}
+		return db.Where("(articles.author_id = ? OR "+coAuthored+")", *profile.UserID, profile.ID)
+	}
+}
+
+// GetPublishedByAuthorProfile lấy bài viết public của một hồ sơ tác giả (tác giả chính hoặc đồng tác giả)
+func (r *ArticleRepo) GetPublishedByAuthorProfile(profile *model.AuthorProfile, page, limit int, locale string) ([]model.Article, int64, error) {
+	var articles []model.Article
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.Article{}).
+		Scopes(authorProfileArticles(profile)).
+		Where(publishedArticleWhere).
+		Scopes(articleLocaleScope(locale))
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Scopes(preloadArticleAuthors).Preload("Category").
+		Order("published_at DESC").
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).Find(&articles).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
+
+// CountPublishedByAuthorProfiles đếm số bài viết đã xuất bản của từng hồ sơ tác giả (tác giả chính hoặc đồng tác giả),
+// key là ID hồ sơ
+func (r *ArticleRepo) CountPublishedByAuthorProfiles(profileIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(profileIDs))
+	if len(profileIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ProfileID uuid.UUID
+		Count     int64
+	}
+	err := r.db.Table("author_profiles").
+		Select("author_profiles.id AS profile_id, COUNT(DISTINCT articles.id) AS count").
+		Joins(`JOIN articles ON (articles.author_id = author_profiles.user_id
+			OR articles.id IN (SELECT article_id FROM article_co_authors WHERE author_profile_id = author_profiles.id))`).
+		Where("author_profiles.id IN ?", profileIDs).
+		Where(publishedArticleWhere + " AND articles.deleted_at IS NULL").
+		Group("author_profiles.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ProfileID] = row.Count
+	}
+	return counts, nil
+}
+
+// SetCoAuthors thay thế toàn bộ danh sách đồng tác giả (theo thứ tự)
+func (r *ArticleRepo) SetCoAuthors(articleID uuid.UUID, profileIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceCoAuthors(tx, articleID, profileIDs)
+	})
+}
+
+// SetAttribution thay danh sách đồng tác giả và cập nhật người thẩm định trong cùng một transaction
+func (r *ArticleRepo) SetAttribution(articleID uuid.UUID, profileIDs []uuid.UUID, fields map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := replaceCoAuthors(tx, articleID, profileIDs); err != nil {
+			return err
+		}
+		return tx.Model(&model.Article{}).Where("id = ?", articleID).Updates(fields).Error
+	})
+}
+
+func replaceCoAuthors(tx *gorm.DB, articleID uuid.UUID, profileIDs []uuid.UUID) error {
+	if err := tx.Where("article_id = ?", articleID).Delete(&model.ArticleCoAuthor{}).Error; err != nil {
+		return err
+	}
+	for i, profileID := range profileIDs {
+		coAuthor := model.ArticleCoAuthor{
+			ArticleID:       articleID,
+			AuthorProfileID: profileID,
+			Position:        i,
+		}
+		if err := tx.Create(&coAuthor).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAttribution cập nhật người thẩm định và tác giả chính mà không ghi đè các trường khác
+func (r *ArticleRepo) UpdateAttribution(articleID uuid.UUID, fields map[string]interface{}) error {
+	return r.db.Model(&model.Article{}).Where("id = ?", articleID).Updates(fields).Error
+}
@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleViewDelta - Số lượt xem cộng dồn của một bài viết trong một ngày, chờ ghi xuống DB
+type ArticleViewDelta struct {
+	ArticleID uuid.UUID
+	ViewDate  time.Time
+	Views     int64
+}
+
+type ArticleViewRepo struct {
+	db *gorm.DB
+}
+
+func NewArticleViewRepo() *ArticleViewRepo {
+	return &ArticleViewRepo{
+		db: app.GetDB(),
+	}
+}
+
+// ApplyDeltas ghi một lô lượt xem: cộng vào bảng theo ngày và cộng tổng vào articles.view_count.
+// Mỗi bài viết chỉ bị UPDATE một lần cho cả lô thay vì mỗi request một lần.
+func (r *ArticleViewRepo) ApplyDeltas(deltas []ArticleViewDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	rows := make([]model.ArticleViewDaily, 0, len(deltas))
+	totals := make(map[uuid.UUID]int64)
+	for _, d := range deltas {
+		rows = append(rows, model.ArticleViewDaily{
+			ArticleID: d.ArticleID,
+			ViewDate:  d.ViewDate,
+			Views:     d.Views,
+		})
+		totals[d.ArticleID] += d.Views
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "article_id"}, {Name: "view_date"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "views"}, Value: gorm.Expr("views + VALUES(views)")},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("VALUES(updated_at)")},
+			},
+		}).Create(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for articleID, views := range totals {
+			err := tx.Model(&model.Article{}).Where("id = ?", articleID).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", views)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetDaily lấy lượt xem theo ngày của một bài viết trong khoảng [from, to]
+func (r *ArticleViewRepo) GetDaily(articleID uuid.UUID, from, to time.Time) ([]model.ArticleViewDaily, error) {
+	var rows []model.ArticleViewDaily
+	err := r.db.Where("article_id = ? AND view_date BETWEEN ? AND ?", articleID, from, to).
+		Order("view_date ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+// GetDailyTotals lấy tổng lượt xem toàn trang theo ngày trong khoảng [from, to]
+func (r *ArticleViewRepo) GetDailyTotals(from, to time.Time) ([]model.ArticleViewPoint, error) {
+	var rows []struct {
+		ViewDate time.Time
+		Views    int64
+	}
+	err := r.db.Model(&model.ArticleViewDaily{}).
+		Select("view_date, SUM(views) AS views").
+		Where("view_date BETWEEN ? AND ?", from, to).
+		Group("view_date").
+		Order("view_date ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]model.ArticleViewPoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, model.ArticleViewPoint{
+			Date:  row.ViewDate.Format("2006-01-02"),
+			Views: row.Views,
+		})
+	}
+	return points, nil
+}
+
+// GetTopArticles lấy các bài viết có nhiều lượt xem nhất trong khoảng [from, to]
+func (r *ArticleViewRepo) GetTopArticles(from, to time.Time, limit int) ([]model.TopViewedArticle, error) {
+	var rows []struct {
+		ArticleID uuid.UUID
+		Title     string
+		Slug      string
+		Views     int64
+		ViewCount int
+	}
+	err := r.db.Table("article_view_daily").
+		Select("articles.id AS article_id, articles.title, articles.slug, SUM(article_view_daily.views) AS views, articles.view_count").
+		Joins("JOIN articles ON articles.id = article_view_daily.article_id AND articles.deleted_at IS NULL").
+		Where("article_view_daily.view_date BETWEEN ? AND ?", from, to).
+		Group("articles.id, articles.title, articles.slug, articles.view_count").
+		Order("views DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	top := make([]model.TopViewedArticle, 0, len(rows))
+	for _, row := range rows {
+		top = append(top, model.TopViewedArticle{
+			ArticleID: row.ArticleID,
+			Title:     row.Title,
+			Slug:      row.Slug,
+			Views:     row.Views,
+			ViewCount: row.ViewCount,
+		})
+	}
+	return top, nil
+}
@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuthorProfileRepo struct {
+	db *gorm.DB
+}
+
+func NewAuthorProfileRepo() *AuthorProfileRepo {
+	return &AuthorProfileRepo{
+		db: app.GetDB(),
+	}
+}
+
+// Create tạo hồ sơ tác giả mới
+func (r *AuthorProfileRepo) Create(profile *model.AuthorProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityAuthorProfile, profile.ID)
+	})
+}
+
+// GetByID lấy hồ sơ theo ID
+func (r *AuthorProfileRepo) GetByID(id uuid.UUID) (*model.AuthorProfile, error) {
+	var profile model.AuthorProfile
+	err := r.db.Where("id = ?", id).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("author profile not found")
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetByIDs lấy danh sách hồ sơ theo ID
+func (r *AuthorProfileRepo) GetByIDs(ids []uuid.UUID) ([]model.AuthorProfile, error) {
+	var profiles []model.AuthorProfile
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&profiles).Error
+	return profiles, err
+}
+
+// GetActiveBySlug lấy hồ sơ đang hoạt động theo slug
+func (r *AuthorProfileRepo) GetActiveBySlug(slug string) (*model.AuthorProfile, error) {
+	var profile model.AuthorProfile
+	err := r.db.Where("slug = ? AND is_active = ?", slug, true).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("author profile not found")
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetActive lấy tất cả hồ sơ đang hoạt động theo thứ tự hiển thị
+func (r *AuthorProfileRepo) GetActive() ([]model.AuthorProfile, error) {
+	var profiles []model.AuthorProfile
+	err := r.db.Where("is_active = ?", true).
+		Order("display_order ASC").
+		Order("full_name ASC").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+// Search lấy danh sách hồ sơ có phân trang, tìm theo tên (admin)
+func (r *AuthorProfileRepo) Search(keyword string, page, limit int) ([]model.AuthorProfile, int64, error) {
+	var profiles []model.AuthorProfile
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.AuthorProfile{})
+	if keyword != "" {
+		query = query.Where("full_name LIKE ? OR slug LIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("display_order ASC").
+		Order("full_name ASC").
+		Limit(limit).Offset(offset).Find(&profiles).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return profiles, total, nil
+}
+
+// Update cập nhật hồ sơ
+func (r *AuthorProfileRepo) Update(profile *model.AuthorProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(profile).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityAuthorProfile, profile.ID)
+	})
+}
+
+// Delete xóa mềm hồ sơ, gỡ liên kết User để User có thể gắn với hồ sơ mới
+func (r *AuthorProfileRepo) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.AuthorProfile{}).Where("id = ?", id).Update("user_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.AuthorProfile{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityAuthorProfile, id)
+	})
+}
+
+// CheckSlugExists kiểm tra slug đã được hồ sơ khác sử dụng
+func (r *AuthorProfileRepo) CheckSlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&model.AuthorProfile{}).Where("slug = ?", slug)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// CheckUserLinked kiểm tra User đã được gắn với hồ sơ khác
+func (r *AuthorProfileRepo) CheckUserLinked(userID uuid.UUID, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&model.AuthorProfile{}).Where("user_id = ?", userID)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// GetActiveSlugsWithUpdatedAt trả về slug và updated_at của hồ sơ đang hoạt động (cho sitemap)
+func (r *AuthorProfileRepo) GetActiveSlugsWithUpdatedAt() ([]struct {
+	Slug               string
+	UpdatedAt          time.Time
+	Locale             string
+	TranslationGroupID *uuid.UUID
+}, error) {
+	var rows []struct {
+		Slug               string
+		UpdatedAt          time.Time
+		Locale             string
+		TranslationGroupID *uuid.UUID
+	}
+	err := r.db.Model(&model.AuthorProfile{}).
+		Select("slug, updated_at").
+		Where("is_active = ?", true).
+		Order("display_order ASC").
+		Find(&rows).Error
+	return rows, err
+}
@@ -22,7 +22,12 @@ func NewCategoryRepo() *CategoryRepo {
 
 // Create tạo mới một danh mục
 func (r *CategoryRepo) Create(category *model.Category) error {
-	return r.db.Create(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityCategory, category.ID)
+	})
 }
 
 // GetByID lấy danh mục theo ID
@@ -84,11 +89,17 @@ func (r *CategoryRepo) GetAll() ([]model.Category, error) {
 	return categories, err
 }
 
-// GetActive lấy danh mục đang hoạt động
-func (r *CategoryRepo) GetActive() ([]model.Category, error) {
+// categoryLocaleScope lọc danh mục công khai theo ngôn ngữ, fallback về ngôn ngữ mặc định
+func categoryLocaleScope(locale string) func(db *gorm.DB) *gorm.DB {
+	return localeScope("categories", locale, "tr.is_active = 1")
+}
+
+// GetActive lấy danh mục đang hoạt động theo ngôn ngữ (locale rỗng = mọi ngôn ngữ)
+func (r *CategoryRepo) GetActive(locale string) ([]model.Category, error) {
 	var categories []model.Category
 	err := r.db.Preload("Parent").
 		Where("is_active = ?", true).
+		Scopes(categoryLocaleScope(locale)).
 		Order("display_order ASC, created_at DESC").
 		Find(&categories).Error
 	return categories, err
@@ -98,7 +109,7 @@ func (r *CategoryRepo) GetActive() ([]model.Category, error) {
 func (r *CategoryRepo) GetActiveWithArticles() ([]model.Category, error) {
 	var categories []model.Category
 	err := r.db.Preload("Parent").
-		Preload("Articles", "status IN ? AND is_active = ?", []string{"post"}, true).
+		Preload("Articles", publishedArticleWhere).
 		Where("is_active = ?", true).
 		Order("display_order ASC, created_at DESC").
 		Find(&categories).Error
@@ -161,12 +172,16 @@ func (r *CategoryRepo) GetAllWithArticlesAndPagination(page, limit int) ([]model
 func (r *CategoryRepo) GetAllSlugsWithUpdatedAt(activeOnly bool) ([]struct{
 	Slug string
 	UpdatedAt time.Time
+	Locale string
+	TranslationGroupID *uuid.UUID
 }, error) {
 	var rows []struct{
 		Slug string
 		UpdatedAt time.Time
+		Locale string
+		TranslationGroupID *uuid.UUID
 	}
-	query := r.db.Model(&model.Category{}).Select("slug, updated_at")
+	query := r.db.Model(&model.Category{}).Select("slug, updated_at, locale, translation_group_id")
 	if activeOnly {
 		query = query.Where("is_active = ?", true)
 	}
@@ -176,11 +191,11 @@ func (r *CategoryRepo) GetAllSlugsWithUpdatedAt(activeOnly bool) ([]struct{
 
 // GetHomeCategoriesWithArticles lấy các danh mục có show_on_home = true kèm bài viết đã xuất bản
 // limitPerCategory: số lượng bài viết lấy cho mỗi danh mục (0 = không giới hạn)
-func (r *CategoryRepo) GetHomeCategoriesWithArticles(limitPerCategory int) ([]model.Category, error) {
+func (r *CategoryRepo) GetHomeCategoriesWithArticles(limitPerCategory int, locale string) ([]model.Category, error) {
 	var categories []model.Category
 
 	preloadArticles := func(db *gorm.DB) *gorm.DB {
-		q := db.Where("status IN ? AND is_active = ?", []string{"post"}, true).
+		q := db.Where(publishedArticleWhere).
 			Order("published_at DESC, created_at DESC")
 		if limitPerCategory > 0 {
 			q = q.Limit(limitPerCategory)
@@ -191,6 +206,7 @@ func (r *CategoryRepo) GetHomeCategoriesWithArticles(limitPerCategory int) ([]mo
 	err := r.db.Preload("Parent").
 		Preload("Articles", preloadArticles).
 		Where("show_on_home = ? AND is_active = ?", true, true).
+		Scopes(categoryLocaleScope(locale)).
 		Order("display_order ASC, created_at DESC").
 		Find(&categories).Error
 	return categories, err
@@ -200,7 +216,7 @@ func (r *CategoryRepo) GetHomeCategoriesWithArticles(limitPerCategory int) ([]mo
 func (r *CategoryRepo) GetActiveBySlugWithArticles(slug string) (*model.Category, error) {
 	var category model.Category
 	err := r.db.Preload("Parent").
-		Preload("Articles", "status IN ?", []string{"post"}).
+		Preload("Articles", publishedArticleWhere).
 		Where("slug = ? AND is_active = ?", slug, true).
 		First(&category).Error
 	if err != nil {
@@ -214,12 +230,22 @@ func (r *CategoryRepo) GetActiveBySlugWithArticles(slug string) (*model.Category
 
 // Update cập nhật danh mục
 func (r *CategoryRepo) Update(category *model.Category) error {
-	return r.db.Save(category).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(category).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityCategory, category.ID)
+	})
 }
 
 // Delete xóa mềm một danh mục
 func (r *CategoryRepo) Delete(id uuid.UUID) error {
-	return r.db.Where("id = ?", id).Delete(&model.Category{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).Delete(&model.Category{}).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityCategory, id)
+	})
 }
 
 // CheckSlugExists kiểm tra slug đã tồn tại hay chưa (loại trừ danh mục có ID = excludeID)
@@ -292,3 +318,43 @@ func (r *CategoryRepo) GetAllSlugs(activeOnly bool) ([]string, error) {
 	err := query.Order("display_order ASC, name ASC").Pluck("slug", &slugs).Error
 	return slugs, err
 }
+
+// GetRelativeIDs trả về ID của danh mục cha, danh mục con và các danh mục anh em (cùng ParentID)
+func (r *CategoryRepo) GetRelativeIDs(category *model.Category) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	query := r.db.Model(&model.Category{}).
+		Where("id != ? AND is_active = ?", category.ID, true)
+	if category.ParentID != nil && *category.ParentID != uuid.Nil {
+		query = query.Where("parent_id = ? OR parent_id = ? OR id = ?", *category.ParentID, category.ID, *category.ParentID)
+	} else {
+		query = query.Where("parent_id = ?", category.ID)
+	}
+
+	err := query.Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetTranslations trả về các bản dịch khác của danh mục (activeOnly: chỉ bản đang hoạt động)
+func (r *CategoryRepo) GetTranslations(category *model.Category, activeOnly bool) ([]model.TranslationLink, error) {
+	if activeOnly {
+		return getTranslationLinks(r.db, &model.Category{}, category.TranslationGroupID, category.ID, "is_active = ?", true)
+	}
+	return getTranslationLinks(r.db, &model.Category{}, category.TranslationGroupID, category.ID, "")
+}
+
+// CheckTranslationLocaleExists kiểm tra nhóm bản dịch đã có danh mục với locale này chưa
+func (r *CategoryRepo) CheckTranslationLocaleExists(groupID uuid.UUID, locale string, excludeID uuid.UUID) (bool, error) {
+	count, err := countTranslationLocale(r.db, &model.Category{}, groupID, locale, excludeID)
+	return count > 0, err
+}
+
+// GetByIDs lấy danh sách danh mục theo ID (giữ nguyên thứ tự display_order)
+func (r *CategoryRepo) GetByIDs(ids []uuid.UUID) ([]model.Category, error) {
+	var categories []model.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("display_order ASC").Find(&categories).Error
+	return categories, err
+}
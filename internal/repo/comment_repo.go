@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/consts"
+	"backend/internal/model"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CommentRepo struct {
+	db *gorm.DB
+}
+
+func NewCommentRepo() *CommentRepo {
+	return &CommentRepo{
+		db: app.GetDB(),
+	}
+}
+
+// CommentFilter - Bộ lọc hàng đợi kiểm duyệt bình luận
+type CommentFilter struct {
+	Status    string
+	ArticleID *uuid.UUID
+	Search    string // Tìm theo tên, email hoặc nội dung
+}
+
+// Create lưu bình luận mới
+func (r *CommentRepo) Create(comment *model.ArticleComment) error {
+	return r.db.Create(comment).Error
+}
+
+// GetByID lấy bình luận theo ID kèm bài viết
+func (r *CommentRepo) GetByID(id uuid.UUID) (*model.ArticleComment, error) {
+	var comment model.ArticleComment
+	err := r.db.Preload("Article").Where("id = ?", id).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetApprovedByArticle lấy bình luận gốc đã duyệt của bài viết (cũ nhất trước) kèm các trả lời đã duyệt
+func (r *CommentRepo) GetApprovedByArticle(articleID uuid.UUID, page, limit int) ([]model.ArticleComment, int64, error) {
+	var comments []model.ArticleComment
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.ArticleComment{}).
+		Where("article_id = ? AND parent_id IS NULL AND status = ?", articleID, consts.CommentStatusApproved)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Replies", func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", consts.CommentStatusApproved).Order("article_comments.created_at ASC")
+	}).
+		Order("created_at ASC").
+		Limit(limit).Offset(offset).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// Search lấy danh sách bình luận trên mọi bài viết (admin), mới nhất trước
+func (r *CommentRepo) Search(filter CommentFilter, page, limit int) ([]model.ArticleComment, int64, error) {
+	var comments []model.ArticleComment
+	var total int64
+
+	query := r.db.Model(&model.ArticleComment{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ArticleID != nil {
+		query = query.Where("article_id = ?", *filter.ArticleID)
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("author_name LIKE ? OR author_email LIKE ? OR content LIKE ?", like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Article").
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// CountByStatus đếm số bình luận theo từng trạng thái
+func (r *CommentRepo) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.db.Model(&model.ArticleComment{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// HasApprovedByEmail kiểm tra email đã từng có bình luận được duyệt
+func (r *CommentRepo) HasApprovedByEmail(email string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.ArticleComment{}).
+		Where("author_email = ? AND status = ?", email, consts.CommentStatusApproved).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// Update cập nhật bình luận (không ghi đè quan hệ)
+func (r *CommentRepo) Update(comment *model.ArticleComment) error {
+	return r.db.Omit("Article", "Replies").Save(comment).Error
+}
+
+// Delete xóa mềm bình luận cùng các trả lời của nó
+func (r *CommentRepo) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ? OR parent_id = ?", id, id).Delete(&model.ArticleComment{}).Error
+}
+
+// RefreshArticleCount tính lại số bình luận hiển thị công khai của bài viết (khớp GetApprovedByArticle):
+// bình luận gốc đã duyệt và trả lời đã duyệt thuộc bình luận gốc đã duyệt
+func (r *CommentRepo) RefreshArticleCount(articleID uuid.UUID) error {
+	approvedRoots := r.db.Model(&model.ArticleComment{}).
+		Select("id").
+		Where("article_id = ? AND parent_id IS NULL AND status = ?", articleID, consts.CommentStatusApproved)
+	approved := r.db.Model(&model.ArticleComment{}).
+		Select("COUNT(*)").
+		Where("article_id = ? AND status = ?", articleID, consts.CommentStatusApproved).
+		Where("parent_id IS NULL OR parent_id IN (?)", approvedRoots)
+	return r.db.Model(&model.Article{}).
+		Where("id = ?", articleID).
+		UpdateColumn("comment_count", approved).Error
+}
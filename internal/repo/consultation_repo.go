@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ConsultationRepo struct {
+	db *gorm.DB
+}
+
+func NewConsultationRepo() *ConsultationRepo {
+	return &ConsultationRepo{
+		db: app.GetDB(),
+	}
+}
+
+// ConsultationFilter - Bộ lọc hộp thư yêu cầu tư vấn
+type ConsultationFilter struct {
+	Status     string
+	AssignedTo *uuid.UUID
+	Unassigned bool
+	CategoryID *uuid.UUID
+	Search     string // Tìm theo tên, số điện thoại hoặc email
+}
+
+// Create lưu yêu cầu tư vấn mới
+func (r *ConsultationRepo) Create(request *model.ConsultationRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityConsultation, request.ID)
+	})
+}
+
+// GetByID lấy yêu cầu tư vấn kèm danh mục, người phụ trách và ghi chú
+func (r *ConsultationRepo) GetByID(id uuid.UUID) (*model.ConsultationRequest, error) {
+	var request model.ConsultationRequest
+	err := r.db.Preload("Category").
+		Preload("AssignedTo").
+		Preload("Notes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("consultation_notes.created_at ASC")
+		}).
+		Preload("Notes.Author").
+		Where("id = ?", id).
+		First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("consultation not found")
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Search lấy danh sách yêu cầu tư vấn có phân trang, mới nhất trước
+func (r *ConsultationRepo) Search(filter ConsultationFilter, page, limit int) ([]model.ConsultationRequest, int64, error) {
+	var requests []model.ConsultationRequest
+	var total int64
+
+	query := r.db.Model(&model.ConsultationRequest{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AssignedTo != nil {
+		query = query.Where("assigned_to_id = ?", *filter.AssignedTo)
+	} else if filter.Unassigned {
+		query = query.Where("assigned_to_id IS NULL")
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("full_name LIKE ? OR phone LIKE ? OR email LIKE ?", like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Category").
+		Preload("AssignedTo").
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&requests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
+
+// CountByStatus đếm số yêu cầu theo từng trạng thái (hiển thị badge hộp thư)
+func (r *ConsultationRepo) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.db.Model(&model.ConsultationRequest{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Update cập nhật yêu cầu tư vấn (không ghi đè quan hệ)
+func (r *ConsultationRepo) Update(request *model.ConsultationRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Category", "AssignedTo", "Notes").Save(request).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityConsultation, request.ID)
+	})
+}
+
+// Delete xóa mềm yêu cầu tư vấn
+func (r *ConsultationRepo) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.ConsultationRequest{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityConsultation, id)
+	})
+}
+
+// AddNote thêm ghi chú nội bộ
+func (r *ConsultationRepo) AddNote(note *model.ConsultationNote) error {
+	return r.db.Create(note).Error
+}
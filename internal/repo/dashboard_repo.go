@@ -1,254 +1,181 @@
-//go:build ignore
-// +build ignore
-
-// Dashboard repo disabled for article-first deployment; enable by removing build tag.
 package repo
 
 import (
 	"backend/app"
+	"backend/internal/consts"
 	"backend/internal/model"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Điều kiện bài viết đang hiển thị công khai (alias bảng articles là a)
+var publishedArticleCond = "a.deleted_at IS NULL AND " + publishedCondition("a.")
+
+// Điều kiện bài viết đã đặt lịch: đã xuất bản nhưng published_at ở tương lai nên chưa hiển thị công khai
+var scheduledArticleCond = "a.deleted_at IS NULL AND a.status IN (" + publishedStatusList + ") AND a.is_active = 1 AND a.published_at > NOW()"
+
+// Ngày xuất bản dùng để thống kê: published_at, nếu trống thì lấy created_at
+const publishDateExpr = "COALESCE(a.published_at, a.created_at)"
+
+// Cột chung cho danh sách bài viết trên dashboard
+const dashboardArticleColumns = `a.id, a.title, a.slug, a.status, COALESCE(u.full_name, '') AS author_name,
+	a.view_count, a.published_at, a.created_at, a.updated_at`
+
 type DashboardRepo struct {
 	db *gorm.DB
 }
 
 func NewDashboardRepo() *DashboardRepo {
 	return &DashboardRepo{
-		db: app.DB,
+		db: app.GetDB(),
+	}
+}
+
+// periodFormat chuyển period (day/week/month/year) sang định dạng DATE_FORMAT của MySQL
+func periodFormat(period string) string {
+	switch period {
+	case "week":
+		return "%x-%v" // Năm-Tuần ISO
+	case "month":
+		return "%Y-%m"
+	case "year":
+		return "%Y"
+	default:
+		return "%Y-%m-%d"
 	}
 }
 
-// GetOverviewStats - Lấy thống kê tổng quan dashboard
-func (r *DashboardRepo) GetOverviewStats(startDate, endDate time.Time) (*model.DashboardOverviewResponse, error) {
-	var result model.DashboardOverviewResponse
+// growth tính % tăng trưởng so với kỳ trước
+func growth(current, previous int64) float64 {
+	if previous <= 0 {
+		return 0
+	}
+	return (float64(current) - float64(previous)) / float64(previous) * 100
+}
+
+func (r *DashboardRepo) countPublishedBetween(startDate, endDate time.Time) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM articles a WHERE `+publishedArticleCond+`
+		AND `+publishDateExpr+` BETWEEN ? AND ?`, startDate, endDate).Scan(&count).Error
+	return count, err
+}
+
+func (r *DashboardRepo) sumViewsBetween(startDate, endDate time.Time) (int64, error) {
+	var views int64
+	err := r.db.Raw(`SELECT COALESCE(SUM(views), 0) FROM article_view_daily
+		WHERE view_date BETWEEN DATE(?) AND DATE(?)`, startDate, endDate).Scan(&views).Error
+	return views, err
+}
+
+// GetOverviewStats - Số liệu tổng quan trong kỳ, so sánh với kỳ liền trước cùng độ dài
+func (r *DashboardRepo) GetOverviewStats(startDate, endDate time.Time, staleMonths int) (*model.DashboardSummary, error) {
+	var result model.DashboardSummary
 
-	// Tính toán khoảng thời gian trước đó để so sánh
 	duration := endDate.Sub(startDate)
 	prevStartDate := startDate.Add(-duration)
-	prevEndDate := startDate
-
-	// Tổng doanh thu hiện tại
-	var currentRevenue float64
-	err := r.db.Model(&model.Order{}).
-		Where("created_at BETWEEN ? AND ?", startDate, endDate).
-		Where("status NOT IN (?)", []string{"cancelled"}).
-		Select("COALESCE(SUM(final_amount), 0)").
-		Scan(&currentRevenue).Error
+	prevEndDate := startDate.Add(-time.Nanosecond)
+
+	var err error
+	if result.PublishedInPeriod, err = r.countPublishedBetween(startDate, endDate); err != nil {
+		return nil, err
+	}
+	prevPublished, err := r.countPublishedBetween(prevStartDate, prevEndDate)
 	if err != nil {
 		return nil, err
 	}
-	result.TotalRevenue = currentRevenue
-
-	// Tổng doanh thu kỳ trước
-	var prevRevenue float64
-	r.db.Model(&model.Order{}).
-		Where("created_at BETWEEN ? AND ?", prevStartDate, prevEndDate).
-		Where("status NOT IN (?)", []string{"cancelled"}).
-		Select("COALESCE(SUM(final_amount), 0)").
-		Scan(&prevRevenue)
-
-	// Tính % tăng trưởng doanh thu
-	if prevRevenue > 0 {
-		result.RevenueGrowth = ((currentRevenue - prevRevenue) / prevRevenue) * 100
-	}
-
-	// Tổng số đơn hàng hiện tại
-	var currentOrders int64
-	r.db.Model(&model.Order{}).
-		Where("created_at BETWEEN ? AND ?", startDate, endDate).
-		Where("status NOT IN (?)", []string{"cancelled"}).
-		Count(&currentOrders)
-	result.TotalOrders = int(currentOrders)
-
-	// Tổng số đơn hàng kỳ trước
-	var prevOrders int64
-	r.db.Model(&model.Order{}).
-		Where("created_at BETWEEN ? AND ?", prevStartDate, prevEndDate).
-		Where("status NOT IN (?)", []string{"cancelled"}).
-		Count(&prevOrders)
-
-	// Tính % tăng trưởng đơn hàng
-	if prevOrders > 0 {
-		result.OrdersGrowth = ((float64(currentOrders) - float64(prevOrders)) / float64(prevOrders)) * 100
-	}
-
-	// Tổng số sản phẩm đã bán hiện tại
-	var currentProductsSold int64
-	r.db.Model(&model.OrderItem{}).
-		Joins("JOIN orders ON orders.id = order_items.order_id").
-		Where("orders.created_at BETWEEN ? AND ?", startDate, endDate).
-		Where("orders.status NOT IN (?)", []string{"cancelled"}).
-		Select("COALESCE(SUM(order_items.quantity), 0)").
-		Scan(&currentProductsSold)
-	result.TotalProducts = int(currentProductsSold)
-
-	// Tổng số sản phẩm đã bán kỳ trước
-	var prevProductsSold int64
-	r.db.Model(&model.OrderItem{}).
-		Joins("JOIN orders ON orders.id = order_items.order_id").
-		Where("orders.created_at BETWEEN ? AND ?", prevStartDate, prevEndDate).
-		Where("orders.status NOT IN (?)", []string{"cancelled"}).
-		Select("COALESCE(SUM(order_items.quantity), 0)").
-		Scan(&prevProductsSold)
-
-	// Tính % tăng trưởng sản phẩm
-	if prevProductsSold > 0 {
-		result.ProductsGrowth = ((float64(currentProductsSold) - float64(prevProductsSold)) / float64(prevProductsSold)) * 100
-	}
-
-	// Tổng số khách hàng hiện tại
-	var currentCustomers int64
-	r.db.Model(&model.User{}).
-		Where("created_at BETWEEN ? AND ?", startDate, endDate).
-		Where("role IN (?)", []string{"user", "member"}).
-		Count(&currentCustomers)
-	result.TotalCustomers = int(currentCustomers)
-
-	// Tổng số khách hàng kỳ trước
-	var prevCustomers int64
-	r.db.Model(&model.User{}).
-		Where("created_at BETWEEN ? AND ?", prevStartDate, prevEndDate).
-		Where("role IN (?)", []string{"user", "member"}).
-		Count(&prevCustomers)
-
-	// Tính % tăng trưởng khách hàng
-	if prevCustomers > 0 {
-		result.CustomersGrowth = ((float64(currentCustomers) - float64(prevCustomers)) / float64(prevCustomers)) * 100
-	}
-
-	// Đơn hàng đang chờ xử lý
-	var pendingOrders int64
-	r.db.Model(&model.Order{}).
-		Where("status IN (?)", []string{"pending", "new", "confirmed"}).
-		Count(&pendingOrders)
-	result.PendingOrders = int(pendingOrders)
-
-	// Sản phẩm sắp hết hàng
-	var lowStockCount int64
-	r.db.Model(&model.Product{}).
-		Where("stock <= min_stock").
-		Where("stock > 0").
-		Count(&lowStockCount)
-	result.LowStockProducts = int(lowStockCount)
-
-	// Giá trị đơn hàng trung bình
-	if result.TotalOrders > 0 {
-		result.AverageOrderValue = result.TotalRevenue / float64(result.TotalOrders)
+	result.PublishedGrowth = growth(result.PublishedInPeriod, prevPublished)
+
+	if result.ViewsInPeriod, err = r.sumViewsBetween(startDate, endDate); err != nil {
+		return nil, err
+	}
+	prevViews, err := r.sumViewsBetween(prevStartDate, prevEndDate)
+	if err != nil {
+		return nil, err
 	}
+	result.ViewsGrowth = growth(result.ViewsInPeriod, prevViews)
 
-	return &result, nil
-}
+	if err := r.db.Raw(`SELECT COUNT(*) FROM articles a WHERE ` + publishedArticleCond).
+		Scan(&result.TotalPublished).Error; err != nil {
+		return nil, err
+	}
+	if result.PublishedInPeriod > 0 {
+		result.AverageViewsPerPost = float64(result.ViewsInPeriod) / float64(result.PublishedInPeriod)
+	}
 
-// GetRevenueByTime - Lấy doanh thu theo thời gian (ngày/tuần/tháng/năm)
-func (r *DashboardRepo) GetRevenueByTime(startDate, endDate time.Time, period string) ([]model.RevenueByTimeResponse, error) {
-	var results []model.RevenueByTimeResponse
+	if result.TotalDrafts, err = r.CountDrafts(); err != nil {
+		return nil, err
+	}
+	if result.ScheduledCount, err = r.CountScheduled(); err != nil {
+		return nil, err
+	}
+	if result.StaleCount, err = r.CountStale(endDate, staleMonths); err != nil {
+		return nil, err
+	}
 
-	var dateFormat string
-	switch period {
-	case "day":
-		dateFormat = "%Y-%m-%d"
-	case "week":
-		dateFormat = "%Y-%u" // Year-Week
-	case "month":
-		dateFormat = "%Y-%m"
-	case "year":
-		dateFormat = "%Y"
-	default:
-		dateFormat = "%Y-%m-%d"
+	if err := r.db.Model(&model.ArticleComment{}).
+		Where("status = ?", consts.CommentStatusPending).
+		Count(&result.PendingComments).Error; err != nil {
+		return nil, err
 	}
+	if err := r.db.Model(&model.ConsultationRequest{}).
+		Where("status = ?", consts.ConsultationStatusNew).
+		Count(&result.NewConsultations).Error; err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPublishedByTime - Số bài xuất bản theo kỳ (ngày/tuần/tháng/năm)
+func (r *DashboardRepo) GetPublishedByTime(startDate, endDate time.Time, period string) ([]model.PublishingByTime, error) {
+	results := []model.PublishingByTime{}
 
 	query := `
-		SELECT 
-			DATE_FORMAT(created_at, ?) as period,
-			COALESCE(SUM(final_amount), 0) as revenue,
-			COUNT(*) as orders,
-			COALESCE(SUM((SELECT SUM(quantity) FROM order_items WHERE order_items.order_id = orders.id)), 0) as products_sold
-		FROM orders
-		WHERE created_at BETWEEN ? AND ?
-			AND status NOT IN ('cancelled')
+		SELECT
+			DATE_FORMAT(` + publishDateExpr + `, ?) AS period,
+			COUNT(*) AS published
+		FROM articles a
+		WHERE ` + publishedArticleCond + `
+			AND ` + publishDateExpr + ` BETWEEN ? AND ?
 		GROUP BY period
 		ORDER BY period ASC
 	`
 
-	err := r.db.Raw(query, dateFormat, startDate, endDate).Scan(&results).Error
+	err := r.db.Raw(query, periodFormat(period), startDate, endDate).Scan(&results).Error
 	return results, err
 }
 
-// GetCategoryStatistics - Thống kê theo danh mục
-func (r *DashboardRepo) GetCategoryStatistics(startDate, endDate time.Time, limit int) ([]model.CategoryStatistics, error) {
-	var results []model.CategoryStatistics
+// GetViewsByTime - Lượt xem theo kỳ, lấy từ bảng article_view_daily
+func (r *DashboardRepo) GetViewsByTime(startDate, endDate time.Time, period string) ([]model.ViewsByTime, error) {
+	results := []model.ViewsByTime{}
 
 	query := `
-		SELECT 
-			c.id as category_id,
-			c.name as category_name,
-			COALESCE(SUM(oi.quantity), 0) as products_sold,
-			COALESCE(SUM(oi.total), 0) as revenue,
-			COUNT(DISTINCT o.id) as orders
-		FROM categories c
-		LEFT JOIN products p ON p.category_id = c.id
-		LEFT JOIN order_items oi ON oi.product_id = p.id
-		LEFT JOIN orders o ON o.id = oi.order_id
-		WHERE o.created_at BETWEEN ? AND ?
-			AND o.status NOT IN ('cancelled')
-		GROUP BY c.id, c.name
-		ORDER BY revenue DESC
+		SELECT
+			DATE_FORMAT(view_date, ?) AS period,
+			COALESCE(SUM(views), 0) AS views
+		FROM article_view_daily
+		WHERE view_date BETWEEN DATE(?) AND DATE(?)
+		GROUP BY period
+		ORDER BY period ASC
 	`
 
-	if limit > 0 {
-		query += " LIMIT ?"
-		err := r.db.Raw(query, startDate, endDate, limit).Scan(&results).Error
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := r.db.Raw(query, startDate, endDate).Scan(&results).Error
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Tính tổng doanh thu để tính phần trăm
-	var totalRevenue float64
-	for _, result := range results {
-		totalRevenue += result.Revenue
-	}
-
-	// Tính phần trăm cho mỗi danh mục
-	for i := range results {
-		if totalRevenue > 0 {
-			results[i].Percentage = (results[i].Revenue / totalRevenue) * 100
-		}
-	}
-
-	return results, nil
+	err := r.db.Raw(query, periodFormat(period), startDate, endDate).Scan(&results).Error
+	return results, err
 }
 
-// GetTopProducts - Lấy top sản phẩm bán chạy
-func (r *DashboardRepo) GetTopProducts(startDate, endDate time.Time, limit int) ([]model.ProductStatistics, error) {
-	var results []model.ProductStatistics
+// GetTopArticles - Bài viết có nhiều lượt xem nhất trong kỳ
+func (r *DashboardRepo) GetTopArticles(startDate, endDate time.Time, limit int) ([]model.DashboardArticle, error) {
+	results := []model.DashboardArticle{}
 
 	query := `
-		SELECT 
-			p.id as product_id,
-			p.name as product_name,
-			p.sku,
-			COALESCE(SUM(oi.quantity), 0) as quantity_sold,
-			COALESCE(SUM(oi.total), 0) as revenue,
-			p.stock,
-			p.rating,
-			p.review_count
-		FROM products p
-		LEFT JOIN order_items oi ON oi.product_id = p.id
-		LEFT JOIN orders o ON o.id = oi.order_id
-		WHERE o.created_at BETWEEN ? AND ?
-			AND o.status NOT IN ('cancelled')
-		GROUP BY p.id, p.name, p.sku, p.stock, p.rating, p.review_count
-		ORDER BY quantity_sold DESC
+		SELECT ` + dashboardArticleColumns + `, SUM(v.views) AS views
+		FROM article_view_daily v
+		JOIN articles a ON a.id = v.article_id AND a.deleted_at IS NULL
+		LEFT JOIN users u ON u.id = a.author_id
+		WHERE v.view_date BETWEEN DATE(?) AND DATE(?)
+		GROUP BY a.id, a.title, a.slug, a.status, u.full_name, a.view_count, a.published_at, a.created_at, a.updated_at
+		ORDER BY views DESC
 		LIMIT ?
 	`
 
@@ -256,411 +183,294 @@ func (r *DashboardRepo) GetTopProducts(startDate, endDate time.Time, limit int)
 	return results, err
 }
 
-// GetLowStockProducts - Lấy sản phẩm sắp hết hàng
-func (r *DashboardRepo) GetLowStockProducts(limit int) ([]model.LowStockProduct, error) {
-	var results []model.LowStockProduct
+// GetCategoryStatistics - Số bài xuất bản và lượt xem trong kỳ theo danh mục (limit = 0: tất cả)
+func (r *DashboardRepo) GetCategoryStatistics(startDate, endDate time.Time, limit int) ([]model.CategoryContentStats, error) {
+	results := []model.CategoryContentStats{}
 
-	err := r.db.Model(&model.Product{}).
-		Select("id as product_id, name as product_name, sku, stock as current_stock, min_stock").
-		Where("stock <= min_stock").
-		Where("stock > 0").
-		Order("stock ASC").
-		Limit(limit).
-		Scan(&results).Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Xác định status dựa trên mức độ thiếu hàng
-	for i := range results {
-		if results[i].CurrentStock == 0 {
-			results[i].Status = "out_of_stock"
-		} else if results[i].CurrentStock <= results[i].MinStock/2 {
-			results[i].Status = "critical"
-		} else {
-			results[i].Status = "low"
-		}
+	query := `
+		SELECT
+			c.id AS category_id,
+			c.name AS category_name,
+			(SELECT COUNT(*) FROM articles a
+				WHERE a.category_id = c.id AND ` + publishedArticleCond + `
+					AND ` + publishDateExpr + ` BETWEEN ? AND ?) AS published,
+			(SELECT COALESCE(SUM(v.views), 0) FROM article_view_daily v
+				JOIN articles a ON a.id = v.article_id
+				WHERE a.category_id = c.id AND a.deleted_at IS NULL
+					AND v.view_date BETWEEN DATE(?) AND DATE(?)) AS views
+		FROM categories c
+		WHERE c.deleted_at IS NULL
+		ORDER BY views DESC, published DESC, c.name ASC
+	`
+	args := []interface{}{startDate, endDate, startDate, endDate}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
 	}
 
-	return results, nil
+	err := r.db.Raw(query, args...).Scan(&results).Error
+	return results, err
 }
 
-// GetOrderStatusStatistics - Thống kê trạng thái đơn hàng
-func (r *DashboardRepo) GetOrderStatusStatistics(startDate, endDate time.Time) ([]model.OrderStatusStatistics, error) {
-	var results []model.OrderStatusStatistics
+// GetTagStatistics - Số bài xuất bản và lượt xem trong kỳ theo tag (limit = 0: tất cả)
+func (r *DashboardRepo) GetTagStatistics(startDate, endDate time.Time, limit int) ([]model.TagContentStats, error) {
+	results := []model.TagContentStats{}
 
 	query := `
-		SELECT 
-			status,
-			COUNT(*) as count
-		FROM orders
-		WHERE created_at BETWEEN ? AND ?
-		GROUP BY status
-		ORDER BY count DESC
+		SELECT
+			t.id AS tag_id,
+			t.name AS tag_name,
+			(SELECT COUNT(*) FROM articles a
+				WHERE JSON_CONTAINS(a.tag_id, JSON_QUOTE(t.id), '$') AND ` + publishedArticleCond + `
+					AND ` + publishDateExpr + ` BETWEEN ? AND ?) AS published,
+			(SELECT COALESCE(SUM(v.views), 0) FROM article_view_daily v
+				JOIN articles a ON a.id = v.article_id
+				WHERE JSON_CONTAINS(a.tag_id, JSON_QUOTE(t.id), '$') AND a.deleted_at IS NULL
+					AND v.view_date BETWEEN DATE(?) AND DATE(?)) AS views
+		FROM tags t
+		WHERE t.deleted_at IS NULL
+		ORDER BY views DESC, published DESC, t.name ASC
 	`
-
-	err := r.db.Raw(query, startDate, endDate).Scan(&results).Error
-	if err != nil {
-		return nil, err
-	}
-
-	// Tính tổng để tính phần trăm
-	var total int
-	for _, result := range results {
-		total += result.Count
-	}
-
-	// Tính phần trăm cho mỗi status
-	for i := range results {
-		if total > 0 {
-			results[i].Percentage = (float64(results[i].Count) / float64(total)) * 100
-		}
+	args := []interface{}{startDate, endDate, startDate, endDate}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
 	}
 
-	return results, nil
+	err := r.db.Raw(query, args...).Scan(&results).Error
+	return results, err
 }
 
-// GetPaymentMethodStatistics - Thống kê phương thức thanh toán
-func (r *DashboardRepo) GetPaymentMethodStatistics(startDate, endDate time.Time) ([]model.PaymentMethodStatistics, error) {
-	var results []model.PaymentMethodStatistics
+// GetAuthorProductivity - Năng suất của từng tác giả chính trong kỳ
+func (r *DashboardRepo) GetAuthorProductivity(startDate, endDate time.Time) ([]model.AuthorProductivity, error) {
+	results := []model.AuthorProductivity{}
 
 	query := `
-		SELECT 
-			payment_method as method,
-			COUNT(*) as count,
-			COALESCE(SUM(final_amount), 0) as revenue
-		FROM orders
-		WHERE created_at BETWEEN ? AND ?
-			AND status NOT IN ('cancelled')
-		GROUP BY payment_method
-		ORDER BY revenue DESC
+		SELECT
+			u.id AS author_id,
+			u.full_name,
+			SUM(CASE WHEN a.status IN (` + publishedStatusList + `) AND a.is_active = 1
+				AND ` + publishDateExpr + ` BETWEEN ? AND ? THEN 1 ELSE 0 END) AS published,
+			SUM(CASE WHEN a.status = 'draft' THEN 1 ELSE 0 END) AS drafts,
+			SUM(CASE WHEN a.updated_at BETWEEN ? AND ? THEN 1 ELSE 0 END) AS updated,
+			(SELECT COALESCE(SUM(v.views), 0) FROM article_view_daily v
+				JOIN articles va ON va.id = v.article_id
+				WHERE va.author_id = u.id AND va.deleted_at IS NULL
+					AND v.view_date BETWEEN DATE(?) AND DATE(?)) AS views,
+			MAX(CASE WHEN a.status IN (` + publishedStatusList + `) THEN a.published_at END) AS last_published
+		FROM users u
+		JOIN articles a ON a.author_id = u.id AND a.deleted_at IS NULL
+		WHERE u.deleted_at IS NULL
+		GROUP BY u.id, u.full_name
+		ORDER BY published DESC, views DESC, u.full_name ASC
 	`
 
-	err := r.db.Raw(query, startDate, endDate).Scan(&results).Error
-	if err != nil {
-		return nil, err
-	}
-
-	// Tính tổng để tính phần trăm
-	var totalRevenue float64
-	for _, result := range results {
-		totalRevenue += result.Revenue
-	}
-
-	// Tính phần trăm cho mỗi phương thức
-	for i := range results {
-		if totalRevenue > 0 {
-			results[i].Percentage = (results[i].Revenue / totalRevenue) * 100
-		}
-	}
-
-	return results, nil
+	err := r.db.Raw(query, startDate, endDate, startDate, endDate, startDate, endDate).Scan(&results).Error
+	return results, err
 }
 
-// GetOrderTypeStatistics - Thống kê loại đơn hàng
-func (r *DashboardRepo) GetOrderTypeStatistics(startDate, endDate time.Time) ([]model.OrderTypeStatistics, error) {
-	var results []model.OrderTypeStatistics
+// GetRecentArticles - Bài viết mới tạo/cập nhật gần đây
+func (r *DashboardRepo) GetRecentArticles(limit int) ([]model.DashboardArticle, error) {
+	results := []model.DashboardArticle{}
 
 	query := `
-		SELECT 
-			COALESCE(order_type, 'online') as type,
-			COUNT(*) as count,
-			COALESCE(SUM(final_amount), 0) as revenue
-		FROM orders
-		WHERE created_at BETWEEN ? AND ?
-			AND status NOT IN ('cancelled')
-		GROUP BY order_type
-		ORDER BY revenue DESC
+		SELECT ` + dashboardArticleColumns + `
+		FROM articles a
+		LEFT JOIN users u ON u.id = a.author_id
+		WHERE a.deleted_at IS NULL
+		ORDER BY a.updated_at DESC
+		LIMIT ?
 	`
 
-	err := r.db.Raw(query, startDate, endDate).Scan(&results).Error
-	if err != nil {
-		return nil, err
-	}
-
-	// Tính tổng để tính phần trăm
-	var totalRevenue float64
-	for _, result := range results {
-		totalRevenue += result.Revenue
-	}
-
-	// Tính phần trăm cho mỗi loại
-	for i := range results {
-		if totalRevenue > 0 {
-			results[i].Percentage = (results[i].Revenue / totalRevenue) * 100
-		}
-	}
+	err := r.db.Raw(query, limit).Scan(&results).Error
+	return results, err
+}
 
-	return results, nil
+// CountDrafts - Số bản nháp hiện có
+func (r *DashboardRepo) CountDrafts() (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM articles a WHERE a.deleted_at IS NULL AND a.status = 'draft'`).
+		Scan(&count).Error
+	return count, err
 }
 
-// GetTopCustomers - Lấy top khách hàng chi tiêu nhiều
-func (r *DashboardRepo) GetTopCustomers(startDate, endDate time.Time, limit int) ([]model.CustomerStatistics, error) {
-	var results []model.CustomerStatistics
+// GetDraftBacklog - Bản nháp tồn đọng, cũ nhất trước
+func (r *DashboardRepo) GetDraftBacklog(limit int) ([]model.DashboardArticle, error) {
+	results := []model.DashboardArticle{}
 
 	query := `
-		SELECT 
-			u.id as user_id,
-			u.full_name,
-			u.email,
-			u.phone,
-			COUNT(o.id) as total_orders,
-			COALESCE(SUM(o.final_amount), 0) as total_spent,
-			MAX(o.created_at) as last_order_date,
-			COALESCE(AVG(o.final_amount), 0) as average_order_value
-		FROM users u
-		LEFT JOIN orders o ON o.user_id = u.id
-		WHERE o.created_at BETWEEN ? AND ?
-			AND o.status NOT IN ('cancelled')
-		GROUP BY u.id, u.full_name, u.email, u.phone
-		ORDER BY total_spent DESC
+		SELECT ` + dashboardArticleColumns + `, DATEDIFF(NOW(), a.updated_at) AS age_days
+		FROM articles a
+		LEFT JOIN users u ON u.id = a.author_id
+		WHERE a.deleted_at IS NULL AND a.status = 'draft'
+		ORDER BY a.updated_at ASC
 		LIMIT ?
 	`
 
-	err := r.db.Raw(query, startDate, endDate, limit).Scan(&results).Error
+	err := r.db.Raw(query, limit).Scan(&results).Error
 	return results, err
 }
 
-// GetNewCustomers - Lấy khách hàng mới
-func (r *DashboardRepo) GetNewCustomers(startDate, endDate time.Time, limit int) ([]model.CustomerStatistics, error) {
-	var results []model.CustomerStatistics
+// CountScheduled - Số bài đã đặt lịch xuất bản (status post, published_at ở tương lai)
+func (r *DashboardRepo) CountScheduled() (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM articles a WHERE ` + scheduledArticleCond).
+		Scan(&count).Error
+	return count, err
+}
+
+// GetScheduledQueue - Hàng đợi bài đã đặt lịch xuất bản, gần nhất trước
+func (r *DashboardRepo) GetScheduledQueue(limit int) ([]model.DashboardArticle, error) {
+	results := []model.DashboardArticle{}
 
 	query := `
-		SELECT 
-			u.id as user_id,
-			u.full_name,
-			u.email,
-			u.phone,
-			COUNT(o.id) as total_orders,
-			COALESCE(SUM(o.final_amount), 0) as total_spent,
-			MAX(o.created_at) as last_order_date,
-			COALESCE(AVG(o.final_amount), 0) as average_order_value
-		FROM users u
-		LEFT JOIN orders o ON o.user_id = u.id
-		WHERE u.created_at BETWEEN ? AND ?
-			AND u.role IN ('user', 'member')
-		GROUP BY u.id, u.full_name, u.email, u.phone
-		ORDER BY u.created_at DESC
+		SELECT ` + dashboardArticleColumns + `
+		FROM articles a
+		LEFT JOIN users u ON u.id = a.author_id
+		WHERE ` + scheduledArticleCond + `
+		ORDER BY a.published_at ASC
 		LIMIT ?
 	`
 
-	err := r.db.Raw(query, startDate, endDate, limit).Scan(&results).Error
+	err := r.db.Raw(query, limit).Scan(&results).Error
 	return results, err
 }
 
-// GetRecentActivities - Lấy hoạt động gần đây
-func (r *DashboardRepo) GetRecentActivities(limit int) ([]model.RecentActivity, error) {
-	var results []model.RecentActivity
-
-	// Lấy đơn hàng mới nhất
-	var orders []struct {
-		Type      string
-		Desc      string
-		Timestamp time.Time
-		UserName  string
-		Amount    float64
-	}
-
-	r.db.Raw(`
-		SELECT 
-			'order' as type,
-			CONCAT('Đơn hàng #', order_code, ' - ', status) as desc,
-			created_at as timestamp,
-			name as user_name,
-			final_amount as amount
-		FROM orders
-		ORDER BY created_at DESC
-		LIMIT ?
-	`, limit/2).Scan(&orders)
-
-	for _, order := range orders {
-		results = append(results, model.RecentActivity{
-			Type:        order.Type,
-			Description: order.Desc,
-			Timestamp:   order.Timestamp.Format("2006-01-02 15:04:05"),
-			UserName:    order.UserName,
-			Amount:      order.Amount,
-		})
-	}
-
-	// Lấy đánh giá mới nhất
-	var reviews []struct {
-		Type      string
-		Desc      string
-		Timestamp time.Time
-		UserName  string
-	}
-
-	r.db.Raw(`
-		SELECT 
-			'review' as type,
-			CONCAT('Đánh giá ', r.rating, ' sao cho sản phẩm: ', p.name) as desc,
-			r.created_at as timestamp,
-			u.full_name as user_name
-		FROM reviews r
-		JOIN products p ON p.id = r.product_id
-		JOIN users u ON u.id = r.user_id
-		ORDER BY r.created_at DESC
-		LIMIT ?
-	`, limit/2).Scan(&reviews)
+// CountStale - Số bài đã xuất bản không được cập nhật trong staleMonths tháng tính tới asOf
+func (r *DashboardRepo) CountStale(asOf time.Time, staleMonths int) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM articles a WHERE `+publishedArticleCond+` AND a.updated_at < ?`,
+		asOf.AddDate(0, -staleMonths, 0)).Scan(&count).Error
+	return count, err
+}
 
-	for _, review := range reviews {
-		results = append(results, model.RecentActivity{
-			Type:        review.Type,
-			Description: review.Desc,
-			Timestamp:   review.Timestamp.Format("2006-01-02 15:04:05"),
-			UserName:    review.UserName,
-		})
-	}
+// GetStaleArticles - Bài đã xuất bản lâu chưa cập nhật, cũ nhất trước
+func (r *DashboardRepo) GetStaleArticles(asOf time.Time, staleMonths, limit int) ([]model.DashboardArticle, error) {
+	results := []model.DashboardArticle{}
 
-	return results, nil
+	query := `
+		SELECT ` + dashboardArticleColumns + `, DATEDIFF(?, a.updated_at) AS age_days
+		FROM articles a
+		LEFT JOIN users u ON u.id = a.author_id
+		WHERE ` + publishedArticleCond + ` AND a.updated_at < ?
+		ORDER BY a.updated_at ASC
+		LIMIT ?
+	`
+
+	err := r.db.Raw(query, asOf, asOf.AddDate(0, -staleMonths, 0), limit).Scan(&results).Error
+	return results, err
 }
 
-// GetFullOverview - API 1: Lấy tất cả dữ liệu overview (gộp 5 API)
-func (r *DashboardRepo) GetFullOverview(startDate, endDate time.Time, period string) (*model.DashboardFullOverview, error) {
+// GetFullOverview - API 1: Tổng quan dashboard (gộp 6 báo cáo)
+func (r *DashboardRepo) GetFullOverview(startDate, endDate time.Time, period string, staleMonths int) (*model.DashboardFullOverview, error) {
 	result := &model.DashboardFullOverview{}
 
 	// 1. Summary statistics
-	summary, err := r.GetOverviewStats(startDate, endDate)
+	summary, err := r.GetOverviewStats(startDate, endDate, staleMonths)
 	if err != nil {
 		return nil, err
 	}
 	result.Summary = *summary
 
-	// 2. Revenue chart (7 days for quick load)
-	revenueChart, err := r.GetRevenueByTime(startDate, endDate, period)
-	if err != nil {
+	// 2. Published chart
+	if result.PublishedChart, err = r.GetPublishedByTime(startDate, endDate, period); err != nil {
 		return nil, err
 	}
-	result.RevenueChart = revenueChart
 
-	// 3. Order status chart
-	orderStatusChart, err := r.GetOrderStatusStatistics(startDate, endDate)
-	if err != nil {
+	// 3. Views chart
+	if result.ViewsChart, err = r.GetViewsByTime(startDate, endDate, period); err != nil {
 		return nil, err
 	}
-	result.OrderStatusChart = orderStatusChart
 
-	// 4. Top 5 products
-	topProducts, err := r.GetTopProducts(startDate, endDate, 5)
-	if err != nil {
+	// 4. Top 5 articles
+	if result.TopArticles, err = r.GetTopArticles(startDate, endDate, 5); err != nil {
 		return nil, err
 	}
-	result.TopProducts = topProducts
 
 	// 5. Top 5 categories
-	topCategories, err := r.GetCategoryStatistics(startDate, endDate, 5)
-	if err != nil {
+	if result.TopCategories, err = r.GetCategoryStatistics(startDate, endDate, 5); err != nil {
 		return nil, err
 	}
-	result.TopCategories = topCategories
 
-	// 6. Recent 10 activities
-	recentActivities, err := r.GetRecentActivities(10)
-	if err != nil {
+	// 6. Recent 10 articles
+	if result.RecentArticles, err = r.GetRecentArticles(10); err != nil {
 		return nil, err
 	}
-	result.RecentActivities = recentActivities
 
 	return result, nil
 }
 
-// GetAnalytics - API 2: Lấy dữ liệu phân tích chi tiết (gộp 4 API)
+// GetAnalytics - API 2: Phân tích chi tiết (gộp 6 báo cáo)
 func (r *DashboardRepo) GetAnalytics(startDate, endDate time.Time, period string) (*model.DashboardAnalytics, error) {
 	result := &model.DashboardAnalytics{}
+	var err error
 
 	// 1. All category stats
-	categoryStats, err := r.GetCategoryStatistics(startDate, endDate, 0)
-	if err != nil {
+	if result.CategoryStats, err = r.GetCategoryStatistics(startDate, endDate, 0); err != nil {
 		return nil, err
 	}
-	result.CategoryStats = categoryStats
 
-	// 2. Top 20 products
-	productStats, err := r.GetTopProducts(startDate, endDate, 20)
-	if err != nil {
+	// 2. Top 20 tags
+	if result.TagStats, err = r.GetTagStatistics(startDate, endDate, 20); err != nil {
 		return nil, err
 	}
-	result.ProductStats = productStats
 
-	// 3. Payment method statistics
-	paymentStats, err := r.GetPaymentMethodStatistics(startDate, endDate)
-	if err != nil {
+	// 3. Top 20 articles
+	if result.TopArticles, err = r.GetTopArticles(startDate, endDate, 20); err != nil {
 		return nil, err
 	}
-	result.PaymentMethodStats = paymentStats
 
-	// 4. Order type statistics
-	orderTypeStats, err := r.GetOrderTypeStatistics(startDate, endDate)
-	if err != nil {
+	// 4. Author productivity
+	if result.AuthorProductivity, err = r.GetAuthorProductivity(startDate, endDate); err != nil {
 		return nil, err
 	}
-	result.OrderTypeStats = orderTypeStats
 
-	// 5. Top 10 customers
-	topCustomers, err := r.GetTopCustomers(startDate, endDate, 10)
-	if err != nil {
+	// 5. Published chart
+	if result.PublishedChart, err = r.GetPublishedByTime(startDate, endDate, period); err != nil {
 		return nil, err
 	}
-	result.TopCustomers = topCustomers
 
-	// 6. Revenue by time (for detailed chart)
-	revenueByTime, err := r.GetRevenueByTime(startDate, endDate, period)
-	if err != nil {
+	// 6. Views chart
+	if result.ViewsChart, err = r.GetViewsByTime(startDate, endDate, period); err != nil {
 		return nil, err
 	}
-	result.RevenueByTime = revenueByTime
 
 	return result, nil
 }
 
-// GetAlerts - API 3: Lấy cảnh báo và hoạt động (gộp 2 API)
-func (r *DashboardRepo) GetAlerts() (*model.DashboardAlerts, error) {
-	result := &model.DashboardAlerts{}
+// GetAlerts - API 3: Việc cần xử lý (bản nháp tồn đọng, hàng đợi lên lịch, bài lâu chưa cập nhật)
+func (r *DashboardRepo) GetAlerts(endDate time.Time, staleMonths, limit int) (*model.DashboardAlerts, error) {
+	result := &model.DashboardAlerts{StaleMonths: staleMonths}
+	var err error
 
-	// 1. Low stock products (top 20)
-	lowStockProducts, err := r.GetLowStockProducts(20)
+	if result.DraftBacklog, err = r.GetDraftBacklog(limit); err != nil {
+		return nil, err
+	}
+	if result.ScheduledQueue, err = r.GetScheduledQueue(limit); err != nil {
+		return nil, err
+	}
+	if result.StaleArticles, err = r.GetStaleArticles(endDate, staleMonths, limit); err != nil {
+		return nil, err
+	}
+
+	drafts, err := r.CountDrafts()
 	if err != nil {
 		return nil, err
 	}
-	result.LowStockProducts = lowStockProducts
-
-	// 2. Count critical and warning alerts
-	criticalCount := 0
-	warningCount := 0
-	for _, product := range lowStockProducts {
-		if product.Status == "critical" || product.Status == "out_of_stock" {
-			criticalCount++
-		} else if product.Status == "low" {
-			warningCount++
-		}
-	}
-	result.CriticalAlerts = criticalCount
-	result.WarningAlerts = warningCount
-
-	// 3. Pending orders count
-	var pendingOrders int64
-	r.db.Model(&model.Order{}).
-		Where("status IN (?)", []string{"pending", "new", "confirmed"}).
-		Count(&pendingOrders)
-	result.PendingOrders = int(pendingOrders)
-
-	// 4. New customers (last 7 days)
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -7)
-	newCustomers, err := r.GetNewCustomers(startDate, endDate, 10)
+	scheduled, err := r.CountScheduled()
 	if err != nil {
 		return nil, err
 	}
-	result.NewCustomers = newCustomers
-
-	// 5. Recent activities (20 items)
-	recentActivities, err := r.GetRecentActivities(20)
+	stale, err := r.CountStale(endDate, staleMonths)
 	if err != nil {
 		return nil, err
 	}
-	result.RecentActivities = recentActivities
+
+	result.AlertCounts = map[string]int64{
+		"drafts":    drafts,
+		"scheduled": scheduled,
+		"stale":     stale,
+	}
 
 	return result, nil
 }
@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type FAQRepo struct {
+	db *gorm.DB
+}
+
+func NewFAQRepo() *FAQRepo {
+	return &FAQRepo{
+		db: app.GetDB(),
+	}
+}
+
+// FAQFilter - Bộ lọc danh sách câu hỏi thường gặp
+type FAQFilter struct {
+	Keyword    string // Tìm theo câu hỏi và câu trả lời
+	CategoryID *uuid.UUID
+	Locale     string
+	IsActive   *bool
+}
+
+func (r *FAQRepo) filterQuery(filter FAQFilter) *gorm.DB {
+	query := r.db.Model(&model.FAQ{})
+	if filter.Keyword != "" {
+		like := "%" + filter.Keyword + "%"
+		query = query.Where("question LIKE ? OR answer_text LIKE ?", like, like)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.Locale != "" {
+		query = query.Where("locale = ?", filter.Locale)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	return query
+}
+
+// Create tạo câu hỏi mới
+func (r *FAQRepo) Create(faq *model.FAQ) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(faq).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityFAQ, faq.ID)
+	})
+}
+
+// GetByID lấy câu hỏi theo ID
+func (r *FAQRepo) GetByID(id uuid.UUID) (*model.FAQ, error) {
+	var faq model.FAQ
+	err := r.db.Preload("Category").Where("id = ?", id).First(&faq).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("faq not found")
+		}
+		return nil, err
+	}
+	return &faq, nil
+}
+
+// GetByIDs lấy danh sách câu hỏi theo ID
+func (r *FAQRepo) GetByIDs(ids []uuid.UUID) ([]model.FAQ, error) {
+	var faqs []model.FAQ
+	if len(ids) == 0 {
+		return faqs, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&faqs).Error
+	return faqs, err
+}
+
+// GetAllWithPagination lấy danh sách câu hỏi có phân trang (admin)
+func (r *FAQRepo) GetAllWithPagination(filter FAQFilter, page, limit int) ([]model.FAQ, int64, error) {
+	var faqs []model.FAQ
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.filterQuery(filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Category").
+		Order("display_order ASC").
+		Order("created_at ASC").
+		Limit(limit).Offset(offset).
+		Find(&faqs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return faqs, total, nil
+}
+
+// GetActive lấy các câu hỏi đang hiển thị theo thứ tự (công khai)
+func (r *FAQRepo) GetActive(filter FAQFilter, limit int) ([]model.FAQ, error) {
+	var faqs []model.FAQ
+	active := true
+	filter.IsActive = &active
+	err := r.filterQuery(filter).
+		Preload("Category").
+		Order("display_order ASC").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&faqs).Error
+	return faqs, err
+}
+
+// Update cập nhật câu hỏi
+func (r *FAQRepo) Update(faq *model.FAQ) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Category").Save(faq).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityFAQ, faq.ID)
+	})
+}
+
+// UpdateOrder cập nhật thứ tự hiển thị của nhiều câu hỏi trong một transaction
+func (r *FAQRepo) UpdateOrder(items []model.FAQOrderItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			err := tx.Model(&model.FAQ{}).
+				Where("id = ?", item.ID).
+				Update("display_order", item.DisplayOrder).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete xóa mềm câu hỏi và gỡ khỏi các bài viết đang nhúng
+func (r *FAQRepo) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("faq_id = ?", id).Delete(&model.ArticleFAQ{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.FAQ{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityFAQ, id)
+	})
+}
+
+// GetByArticle lấy các câu hỏi nhúng trong bài viết theo thứ tự
+func (r *FAQRepo) GetByArticle(articleID uuid.UUID, publicOnly bool) ([]model.FAQ, error) {
+	var faqs []model.FAQ
+	query := r.db.Model(&model.FAQ{}).
+		Joins("JOIN article_faqs af ON af.faq_id = faqs.id").
+		Where("af.article_id = ?", articleID)
+	if publicOnly {
+		query = query.Where("faqs.is_active = ?", true)
+	}
+	err := query.Order("af.position ASC").Find(&faqs).Error
+	return faqs, err
+}
+
+// SetArticleFAQs thay thế danh sách câu hỏi nhúng trong bài viết
+func (r *FAQRepo) SetArticleFAQs(articleID uuid.UUID, faqIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&model.ArticleFAQ{}).Error; err != nil {
+			return err
+		}
+		if len(faqIDs) == 0 {
+			return nil
+		}
+		links := make([]model.ArticleFAQ, 0, len(faqIDs))
+		for i, faqID := range faqIDs {
+			links = append(links, model.ArticleFAQ{
+				ArticleID: articleID,
+				FAQID:     faqID,
+				Position:  i,
+			})
+		}
+		return tx.Create(&links).Error
+	})
+}
@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedbackRepo struct {
+	db *gorm.DB
+}
+
+func NewFeedbackRepo() *FeedbackRepo {
+	return &FeedbackRepo{
+		db: app.GetDB(),
+	}
+}
+
+// FeedbackStatsFilter - Bộ lọc thống kê đánh giá theo bài viết
+type FeedbackStatsFilter struct {
+	CategoryID *uuid.UUID
+	MinVotes   int    // Chỉ lấy bài có tối thiểu số phiếu này
+	Sort       string // not_helpful (mặc định), helpful, total, ratio
+}
+
+// feedbackAggregates - biểu thức đếm phiếu hữu ích / không hữu ích
+const feedbackAggregates = "SUM(CASE WHEN article_feedback.helpful THEN 1 ELSE 0 END) AS helpful, " +
+	"SUM(CASE WHEN article_feedback.helpful THEN 0 ELSE 1 END) AS not_helpful"
+
+// Upsert lưu phiếu đánh giá; khách đã đánh giá bài này thì cập nhật phiếu cũ
+func (r *FeedbackRepo) Upsert(feedback *model.ArticleFeedback) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "visitor_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"helpful", "reason", "locale", "updated_at"}),
+	}).Create(feedback).Error
+}
+
+// GetSummary tổng hợp đánh giá của một bài viết
+func (r *FeedbackRepo) GetSummary(articleID uuid.UUID) (model.FeedbackSummary, error) {
+	var row struct {
+		Helpful    int64
+		NotHelpful int64
+	}
+	err := r.db.Model(&model.ArticleFeedback{}).
+		Select(feedbackAggregates).
+		Where("article_id = ?", articleID).
+		Scan(&row).Error
+	if err != nil {
+		return model.FeedbackSummary{}, err
+	}
+	return model.NewFeedbackSummary(row.Helpful, row.NotHelpful), nil
+}
+
+// GetReasons lấy các phiếu có lý do của một bài viết, mới nhất trước
+func (r *FeedbackRepo) GetReasons(articleID uuid.UUID, helpful *bool, page, limit int) ([]model.ArticleFeedback, int64, error) {
+	var feedback []model.ArticleFeedback
+	var total int64
+
+	query := r.db.Model(&model.ArticleFeedback{}).
+		Where("article_id = ? AND reason <> ''", articleID)
+	if helpful != nil {
+		query = query.Where("helpful = ?", *helpful)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&feedback).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return feedback, total, nil
+}
+
+// GetArticleStats thống kê đánh giá theo bài viết (chỉ bài đã có phiếu), có phân trang
+func (r *FeedbackRepo) GetArticleStats(filter FeedbackStatsFilter, page, limit int) ([]model.ArticleFeedbackStats, int64, error) {
+	query := r.db.Table("article_feedback").
+		Joins("JOIN articles ON articles.id = article_feedback.article_id AND articles.deleted_at IS NULL")
+	if filter.CategoryID != nil {
+		query = query.Where("articles.category_id = ?", *filter.CategoryID)
+	}
+	query = query.Group("articles.id, articles.title, articles.slug, articles.view_count")
+	if filter.MinVotes > 0 {
+		query = query.Having("COUNT(*) >= ?", filter.MinVotes)
+	}
+	// Session mới để câu đếm và câu lấy dữ liệu không ảnh hưởng lẫn nhau
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := r.db.Table("(?) AS grouped", query.Select("articles.id")).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "not_helpful DESC, total DESC"
+	switch filter.Sort {
+	case "helpful":
+		order = "helpful DESC, total DESC"
+	case "total":
+		order = "total DESC"
+	case "ratio":
+		// Tỉ lệ hữu ích thấp nhất trước: ứng viên cần viết lại
+		order = "helpful_ratio ASC, total DESC"
+	}
+
+	var rows []struct {
+		ArticleID  uuid.UUID
+		Title      string
+		Slug       string
+		ViewCount  int
+		Helpful    int64
+		NotHelpful int64
+	}
+	offset := (page - 1) * limit
+	err := query.Select("articles.id AS article_id, articles.title, articles.slug, articles.view_count, " +
+		feedbackAggregates + ", COUNT(*) AS total, " +
+		"SUM(CASE WHEN article_feedback.helpful THEN 1 ELSE 0 END) / COUNT(*) AS helpful_ratio").
+		Order(order).
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stats := make([]model.ArticleFeedbackStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, model.ArticleFeedbackStats{
+			ArticleID:       row.ArticleID,
+			Title:           row.Title,
+			Slug:            row.Slug,
+			ViewCount:       row.ViewCount,
+			FeedbackSummary: model.NewFeedbackSummary(row.Helpful, row.NotHelpful),
+		})
+	}
+	return stats, total, nil
+}
+
+// GetCategoryStats thống kê đánh giá theo danh mục của bài viết
+func (r *FeedbackRepo) GetCategoryStats() ([]model.CategoryFeedbackStats, error) {
+	var rows []struct {
+		CategoryID   *uuid.UUID
+		CategoryName string
+		ArticleCount int64
+		Helpful      int64
+		NotHelpful   int64
+	}
+	err := r.db.Table("article_feedback").
+		Joins("JOIN articles ON articles.id = article_feedback.article_id AND articles.deleted_at IS NULL").
+		Joins("LEFT JOIN categories ON categories.id = articles.category_id").
+		Select("articles.category_id, COALESCE(categories.name, '') AS category_name, " +
+			"COUNT(DISTINCT articles.id) AS article_count, " + feedbackAggregates).
+		Group("articles.category_id, categories.name").
+		Order("not_helpful DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]model.CategoryFeedbackStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, model.CategoryFeedbackStats{
+			CategoryID:      row.CategoryID,
+			CategoryName:    row.CategoryName,
+			ArticleCount:    row.ArticleCount,
+			FeedbackSummary: model.NewFeedbackSummary(row.Helpful, row.NotHelpful),
+		})
+	}
+	return stats, nil
+}
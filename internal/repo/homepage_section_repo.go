@@ -2,6 +2,7 @@ package repo
 
 import (
 	"backend/app"
+	"backend/internal/consts"
 	"backend/internal/model"
 	"errors"
 
@@ -21,7 +22,12 @@ func NewHomepageSectionRepo() *HomepageSectionRepo {
 
 // Create tạo section mới
 func (r *HomepageSectionRepo) Create(section *model.HomepageSection) error {
-	return r.db.Create(section).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(section).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityHomepageSection, section.ID)
+	})
 }
 
 // GetByID lấy section theo ID
@@ -37,10 +43,14 @@ func (r *HomepageSectionRepo) GetByID(id uuid.UUID) (*model.HomepageSection, err
 	return &section, nil
 }
 
-// GetByTypeKey lấy section theo TypeKey
-func (r *HomepageSectionRepo) GetByTypeKey(typeKey string) (*model.HomepageSection, error) {
+// GetByTypeKey lấy section theo TypeKey và ngôn ngữ
+// Nếu chưa có bản cho locale yêu cầu và fallback = true, trả về bản ngôn ngữ mặc định
+func (r *HomepageSectionRepo) GetByTypeKey(typeKey, locale string, fallback bool) (*model.HomepageSection, error) {
 	var section model.HomepageSection
-	err := r.db.Where("type_key = ?", typeKey).First(&section).Error
+	err := r.db.Where("type_key = ? AND locale = ?", typeKey, locale).First(&section).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) && fallback && locale != consts.DefaultLocale {
+		err = r.db.Where("type_key = ? AND locale = ?", typeKey, consts.DefaultLocale).First(&section).Error
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("homepage section not found")
@@ -50,10 +60,10 @@ func (r *HomepageSectionRepo) GetByTypeKey(typeKey string) (*model.HomepageSecti
 	return &section, nil
 }
 
-// CheckTypeKeyExists kiểm tra TypeKey đã tồn tại chưa (trừ excludeID)
-func (r *HomepageSectionRepo) CheckTypeKeyExists(typeKey string, excludeID uuid.UUID) (bool, error) {
+// CheckTypeKeyExists kiểm tra TypeKey đã tồn tại trong ngôn ngữ này chưa (trừ excludeID)
+func (r *HomepageSectionRepo) CheckTypeKeyExists(typeKey, locale string, excludeID uuid.UUID) (bool, error) {
 	var count int64
-	query := r.db.Model(&model.HomepageSection{}).Where("type_key = ?", typeKey)
+	query := r.db.Model(&model.HomepageSection{}).Where("type_key = ? AND locale = ?", typeKey, locale)
 	if excludeID != uuid.Nil {
 		query = query.Where("id != ?", excludeID)
 	}
@@ -85,14 +95,17 @@ func (r *HomepageSectionRepo) GetAll(page, limit int) ([]model.HomepageSection,
 	return sections, total, nil
 }
 
-// SearchByTitle tìm kiếm sections theo title với phân trang
-func (r *HomepageSectionRepo) SearchByTitle(keyword string, page, limit int) ([]model.HomepageSection, int64, error) {
+// SearchByTitle tìm kiếm sections theo title với phân trang (locale rỗng = mọi ngôn ngữ)
+func (r *HomepageSectionRepo) SearchByTitle(keyword, locale string, page, limit int) ([]model.HomepageSection, int64, error) {
 	var sections []model.HomepageSection
 	var total int64
 
 	offset := (page - 1) * limit
 
 	query := r.db.Model(&model.HomepageSection{})
+	if locale != "" {
+		query = query.Where("locale = ?", locale)
+	}
 
 	// Apply search filter if keyword is provided
 	if keyword != "" {
@@ -114,10 +127,21 @@ func (r *HomepageSectionRepo) SearchByTitle(keyword string, page, limit int) ([]
 }
 
 // GetPublic lấy tất cả sections công khai (show_home = true) sắp xếp theo position
-func (r *HomepageSectionRepo) GetPublic() ([]model.HomepageSection, error) {
+// Với type_key chưa có bản cho locale yêu cầu, bản ngôn ngữ mặc định được dùng thay thế
+func (r *HomepageSectionRepo) GetPublic(locale string) ([]model.HomepageSection, error) {
 	var sections []model.HomepageSection
 
-	err := r.db.Where("show_home = ?", true).
+	query := r.db.Where("show_home = ?", true)
+	if locale == consts.DefaultLocale {
+		query = query.Where("locale = ?", locale)
+	} else {
+		query = query.Where(`(locale = ? OR (locale = ? AND type_key NOT IN (
+			SELECT tr.type_key FROM homepage_sections tr
+			WHERE tr.locale = ? AND tr.show_home = 1 AND tr.deleted_at IS NULL)))`,
+			locale, consts.DefaultLocale, locale)
+	}
+
+	err := query.
 		Order("position ASC, created_at DESC").
 		Find(&sections).Error
 	if err != nil {
@@ -129,15 +153,30 @@ func (r *HomepageSectionRepo) GetPublic() ([]model.HomepageSection, error) {
 
 // Update cập nhật section
 func (r *HomepageSectionRepo) Update(section *model.HomepageSection) error {
-	return r.db.Save(section).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(section).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityHomepageSection, section.ID)
+	})
 }
 
 // Delete xóa section (soft delete)
 func (r *HomepageSectionRepo) Delete(id uuid.UUID) error {
-	return r.db.Delete(&model.HomepageSection{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.HomepageSection{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityHomepageSection, id)
+	})
 }
 
 // HardDelete xóa vĩnh viễn section
 func (r *HomepageSectionRepo) HardDelete(id uuid.UUID) error {
-	return r.db.Unscoped().Delete(&model.HomepageSection{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&model.HomepageSection{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityHomepageSection, id)
+	})
 }
@@ -0,0 +1,308 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LegalDocumentRepo struct {
+	db *gorm.DB
+}
+
+func NewLegalDocumentRepo() *LegalDocumentRepo {
+	return &LegalDocumentRepo{
+		db: app.GetDB(),
+	}
+}
+
+// LegalDocumentFilter - Bộ lọc thư viện văn bản
+type LegalDocumentFilter struct {
+	Keyword          string // Tìm theo tên, số hiệu, tóm tắt
+	DocType          string
+	Status           string
+	IssuingAuthority string
+	CategoryID       *uuid.UUID
+	Year             int  // Năm ban hành
+	PublicOnly       bool // Chỉ văn bản đang hiển thị
+}
+
+// baseQuery áp dụng điều kiện chung (từ khóa, hiển thị công khai) - dùng cho cả danh sách và bộ lọc
+func (r *LegalDocumentRepo) baseQuery(filter LegalDocumentFilter) *gorm.DB {
+	query := r.db.Model(&model.LegalDocument{})
+	if filter.PublicOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	if filter.Keyword != "" {
+		like := "%" + filter.Keyword + "%"
+		query = query.Where("title LIKE ? OR document_number LIKE ? OR summary LIKE ?", like, like, like)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id = ?", *filter.CategoryID)
+	}
+	return query
+}
+
+// applyFacetFilters áp dụng các bộ lọc theo facet (loại, hiệu lực, cơ quan, năm)
+func applyFacetFilters(query *gorm.DB, filter LegalDocumentFilter) *gorm.DB {
+	if filter.DocType != "" {
+		query = query.Where("doc_type = ?", filter.DocType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.IssuingAuthority != "" {
+		query = query.Where("issuing_authority = ?", filter.IssuingAuthority)
+	}
+	if filter.Year > 0 {
+		query = query.Where("YEAR(issue_date) = ?", filter.Year)
+	}
+	return query
+}
+
+// Create tạo văn bản mới
+func (r *LegalDocumentRepo) Create(doc *model.LegalDocument) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(doc).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityLegalDocument, doc.ID)
+	})
+}
+
+// GetByID lấy văn bản theo ID
+func (r *LegalDocumentRepo) GetByID(id uuid.UUID) (*model.LegalDocument, error) {
+	var doc model.LegalDocument
+	err := r.db.Preload("Category").Where("id = ?", id).First(&doc).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("legal document not found")
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// GetActiveBySlug lấy văn bản đang hiển thị theo slug
+func (r *LegalDocumentRepo) GetActiveBySlug(slug string) (*model.LegalDocument, error) {
+	var doc model.LegalDocument
+	err := r.db.Preload("Category").Where("slug = ? AND is_active = ?", slug, true).First(&doc).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("legal document not found")
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// GetByIDs lấy danh sách văn bản theo ID
+func (r *LegalDocumentRepo) GetByIDs(ids []uuid.UUID) ([]model.LegalDocument, error) {
+	var docs []model.LegalDocument
+	if len(ids) == 0 {
+		return docs, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&docs).Error
+	return docs, err
+}
+
+// Search lấy danh sách văn bản có phân trang, mới ban hành trước
+func (r *LegalDocumentRepo) Search(filter LegalDocumentFilter, page, limit int) ([]model.LegalDocument, int64, error) {
+	var docs []model.LegalDocument
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := applyFacetFilters(r.baseQuery(filter), filter)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Category").
+		Order("issue_date IS NULL, issue_date DESC").
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&docs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return docs, total, nil
+}
+
+// facetCounts đếm số văn bản theo một cột, áp dụng mọi bộ lọc trừ chính facet đó
+func (r *LegalDocumentRepo) facetCounts(filter LegalDocumentFilter, column string) ([]model.LegalDocumentFacet, error) {
+	var rows []struct {
+		Value string
+		Count int64
+	}
+	err := applyFacetFilters(r.baseQuery(filter), filter).
+		Select(column + " AS value, COUNT(*) AS count").
+		Where(column + " IS NOT NULL AND " + column + " <> ''").
+		Group("value").
+		Order("count DESC, value ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make([]model.LegalDocumentFacet, 0, len(rows))
+	for _, row := range rows {
+		facets = append(facets, model.LegalDocumentFacet{Value: row.Value, Count: row.Count})
+	}
+	return facets, nil
+}
+
+// GetFacets trả về số văn bản theo loại, hiệu lực, cơ quan ban hành và năm ban hành
+func (r *LegalDocumentRepo) GetFacets(filter LegalDocumentFilter) (*model.LegalDocumentFacets, error) {
+	var facets model.LegalDocumentFacets
+	var err error
+
+	withoutType := filter
+	withoutType.DocType = ""
+	if facets.DocTypes, err = r.facetCounts(withoutType, "doc_type"); err != nil {
+		return nil, err
+	}
+
+	withoutStatus := filter
+	withoutStatus.Status = ""
+	if facets.Statuses, err = r.facetCounts(withoutStatus, "status"); err != nil {
+		return nil, err
+	}
+
+	withoutAuthority := filter
+	withoutAuthority.IssuingAuthority = ""
+	if facets.Authorities, err = r.facetCounts(withoutAuthority, "issuing_authority"); err != nil {
+		return nil, err
+	}
+
+	withoutYear := filter
+	withoutYear.Year = 0
+	var years []struct {
+		Year  int
+		Count int64
+	}
+	err = applyFacetFilters(r.baseQuery(withoutYear), withoutYear).
+		Select("YEAR(issue_date) AS year, COUNT(*) AS count").
+		Where("issue_date IS NOT NULL").
+		Group("year").
+		Order("year DESC").
+		Scan(&years).Error
+	if err != nil {
+		return nil, err
+	}
+	facets.Years = make([]model.LegalDocumentFacet, 0, len(years))
+	for _, y := range years {
+		facets.Years = append(facets.Years, model.LegalDocumentFacet{Value: strconv.Itoa(y.Year), Count: y.Count})
+	}
+
+	return &facets, nil
+}
+
+// Update cập nhật văn bản
+func (r *LegalDocumentRepo) Update(doc *model.LegalDocument) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Category").Save(doc).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityLegalDocument, doc.ID)
+	})
+}
+
+// Delete xóa mềm văn bản và gỡ liên kết với các bài viết
+func (r *LegalDocumentRepo) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("legal_document_id = ?", id).Delete(&model.ArticleLegalDocument{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.LegalDocument{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityLegalDocument, id)
+	})
+}
+
+// CheckSlugExists kiểm tra slug đã được văn bản khác sử dụng
+func (r *LegalDocumentRepo) CheckSlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&model.LegalDocument{}).Where("slug = ?", slug)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// GetByArticle lấy các văn bản bài viết trích dẫn theo thứ tự
+func (r *LegalDocumentRepo) GetByArticle(articleID uuid.UUID, publicOnly bool) ([]model.LegalDocument, error) {
+	var docs []model.LegalDocument
+	query := r.db.Model(&model.LegalDocument{}).
+		Joins("JOIN article_legal_documents ald ON ald.legal_document_id = legal_documents.id").
+		Where("ald.article_id = ?", articleID)
+	if publicOnly {
+		query = query.Where("legal_documents.is_active = ?", true)
+	}
+	err := query.Order("ald.position ASC").Find(&docs).Error
+	return docs, err
+}
+
+// SetArticleDocuments thay thế danh sách văn bản bài viết trích dẫn
+func (r *LegalDocumentRepo) SetArticleDocuments(articleID uuid.UUID, documentIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&model.ArticleLegalDocument{}).Error; err != nil {
+			return err
+		}
+		if len(documentIDs) == 0 {
+			return nil
+		}
+		links := make([]model.ArticleLegalDocument, 0, len(documentIDs))
+		for i, documentID := range documentIDs {
+			links = append(links, model.ArticleLegalDocument{
+				ArticleID:       articleID,
+				LegalDocumentID: documentID,
+				Position:        i,
+			})
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+// GetCitingArticles lấy các bài viết đã xuất bản trích dẫn văn bản
+func (r *LegalDocumentRepo) GetCitingArticles(documentID uuid.UUID, limit int) ([]model.Article, error) {
+	var articles []model.Article
+	err := r.db.Model(&model.Article{}).
+		Joins("JOIN article_legal_documents ald ON ald.article_id = articles.id").
+		Where("ald.legal_document_id = ?", documentID).
+		Where(publishedArticleWhere).
+		Order("articles.published_at DESC").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+// GetActiveSlugsWithUpdatedAt trả về slug và updated_at của văn bản đang hiển thị (cho sitemap)
+func (r *LegalDocumentRepo) GetActiveSlugsWithUpdatedAt() ([]struct {
+	Slug               string
+	UpdatedAt          time.Time
+	Locale             string
+	TranslationGroupID *uuid.UUID
+}, error) {
+	var rows []struct {
+		Slug               string
+		UpdatedAt          time.Time
+		Locale             string
+		TranslationGroupID *uuid.UUID
+	}
+	err := r.db.Model(&model.LegalDocument{}).
+		Select("slug, updated_at").
+		Where("is_active = ?", true).
+		Order("updated_at DESC").
+		Find(&rows).Error
+	return rows, err
+}
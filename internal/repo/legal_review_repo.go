@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LegalReviewRepo truy vấn phục vụ nhắc rà soát nội dung pháp lý
+type LegalReviewRepo struct {
+	db *gorm.DB
+}
+
+func NewLegalReviewRepo() *LegalReviewRepo {
+	return &LegalReviewRepo{
+		db: app.GetDB(),
+	}
+}
+
+// GetCandidates lấy các bài đã xuất bản chưa bị đánh dấu nhưng có thể đến hạn rà soát:
+// review_by đã qua, hoặc có khai báo căn cứ pháp lý (hạn hiệu lực được kiểm tra ở tầng gọi)
+func (r *LegalReviewRepo) GetCandidates(now time.Time) ([]model.Article, error) {
+	var articles []model.Article
+	err := r.db.Preload("Author").
+		Select("id, title, slug, author_id, status, is_active, reviewed_at, review_by, legal_basis, needs_review, updated_at").
+		Where("status IN ? AND is_active = ?", publishedStatuses, true).
+		Where("needs_review = ?", false).
+		Where("review_by <= ? OR (legal_basis IS NOT NULL AND JSON_LENGTH(legal_basis) > 0)", now).
+		Find(&articles).Error
+	return articles, err
+}
+
+// Flag đánh dấu bài viết cần rà soát kèm lý do
+func (r *LegalReviewRepo) Flag(article *model.Article, reasons []string, now time.Time) error {
+	article.NeedsReview = true
+	article.SetReviewReasons(reasons)
+	article.ReviewFlaggedAt = &now
+	return r.db.Model(&model.Article{}).Where("id = ?", article.ID).
+		UpdateColumns(map[string]interface{}{
+			"needs_review":      true,
+			"review_reasons":    article.ReviewReasons,
+			"review_flagged_at": now,
+		}).Error
+}
+
+// GetNeedsReview lấy hàng đợi bài cần rà soát, đánh dấu sớm nhất trước
+func (r *LegalReviewRepo) GetNeedsReview(authorID *uuid.UUID, page, limit int) ([]model.Article, int64, error) {
+	var articles []model.Article
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.Article{}).Where("needs_review = ?", true)
+	if authorID != nil {
+		query = query.Where("author_id = ?", *authorID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Author.Profile").
+		Order("review_flagged_at ASC").
+		Limit(limit).Offset(offset).
+		Find(&articles).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
+
+// MarkReviewed ghi nhận đã rà soát: cập nhật người/ngày rà soát, hạn tiếp theo và gỡ cờ
+func (r *LegalReviewRepo) MarkReviewed(articleID uuid.UUID, reviewedByID *uuid.UUID, reviewedAt time.Time, nextReviewBy *time.Time) error {
+	fields := map[string]interface{}{
+		"reviewed_at":       reviewedAt,
+		"needs_review":      false,
+		"review_reasons":    nil,
+		"review_flagged_at": nil,
+	}
+	if reviewedByID != nil {
+		fields["reviewed_by_id"] = *reviewedByID
+	}
+	if nextReviewBy != nil {
+		fields["review_by"] = *nextReviewBy
+	}
+	return r.db.Model(&model.Article{}).Where("id = ?", articleID).UpdateColumns(fields).Error
+}
@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"backend/internal/consts"
+	"backend/internal/model"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// localeScope lọc bản ghi theo ngôn ngữ. Với những nhóm bản dịch chưa có bản cho locale
+// yêu cầu (hoặc bản đó chưa hiển thị được), bản ngôn ngữ mặc định sẽ được dùng thay thế.
+// variantFilter là điều kiện bổ sung cho bản dịch (alias "tr"), ví dụ "tr.is_active = 1".
+// locale rỗng nghĩa là không lọc.
+func localeScope(table, locale, variantFilter string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if locale == "" {
+			return db
+		}
+		if locale == consts.DefaultLocale {
+			return db.Where(fmt.Sprintf("%s.locale = ?", table), locale)
+		}
+
+		extra := ""
+		if variantFilter != "" {
+			extra = " AND " + variantFilter
+		}
+		condition := fmt.Sprintf(`(%[1]s.locale = ? OR (%[1]s.locale = ? AND %[1]s.translation_group_id NOT IN (
+			SELECT tr.translation_group_id FROM %[1]s tr
+			WHERE tr.locale = ? AND tr.deleted_at IS NULL AND tr.translation_group_id IS NOT NULL%[2]s)))`, table, extra)
+		return db.Where(condition, locale, consts.DefaultLocale, locale)
+	}
+}
+
+// getTranslationLinks trả về các bản dịch (ID, locale, slug) trong cùng nhóm bản dịch
+func getTranslationLinks(db *gorm.DB, m interface{}, groupID *uuid.UUID, excludeID uuid.UUID, where string, args ...interface{}) ([]model.TranslationLink, error) {
+	links := []model.TranslationLink{}
+	if groupID == nil {
+		return links, nil
+	}
+	query := db.Model(m).
+		Select("id, locale, slug").
+		Where("translation_group_id = ? AND id != ?", *groupID, excludeID)
+	if where != "" {
+		query = query.Where(where, args...)
+	}
+	err := query.Order("locale ASC").Scan(&links).Error
+	return links, err
+}
+
+// countTranslationLocale đếm số bản ghi cùng nhóm bản dịch đã dùng locale chỉ định
+func countTranslationLocale(db *gorm.DB, m interface{}, groupID uuid.UUID, locale string, excludeID uuid.UUID) (int64, error) {
+	var count int64
+	query := db.Model(m).Where("translation_group_id = ? AND locale = ?", groupID, locale)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,192 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MediaRepo struct {
+	db *gorm.DB
+}
+
+func NewMediaRepo() *MediaRepo {
+	return &MediaRepo{
+		db: app.GetDB(),
+	}
+}
+
+// MediaFilter - Bộ lọc thư viện media
+type MediaFilter struct {
+	Keyword      string // Tìm theo tên file, tiêu đề, alt text, chú thích
+	Folder       string
+	ContentType  string // Khớp tiền tố, vd: "image/"
+	UploadedByID *uuid.UUID
+	Status       string // pending, clean, rejected
+	Visibility   string // public, private
+}
+
+// Create lưu file mới vào thư viện
+func (r *MediaRepo) Create(asset *model.MediaAsset) error {
+	return r.db.Create(asset).Error
+}
+
+// GetByID lấy file theo ID
+func (r *MediaRepo) GetByID(id uuid.UUID) (*model.MediaAsset, error) {
+	var asset model.MediaAsset
+	err := r.db.Preload("UploadedBy").Where("id = ?", id).First(&asset).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("media asset not found")
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetByKey lấy file theo object key (kể cả bản đã xóa mềm, để xác nhận lại cùng key)
+func (r *MediaRepo) GetByKey(key string) (*model.MediaAsset, error) {
+	var asset model.MediaAsset
+	err := r.db.Unscoped().Where("`key` = ?", key).First(&asset).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// GetByObjectKey tìm file theo một object key bất kỳ của nó: key gốc, key riêng tư hoặc key của variant
+func (r *MediaRepo) GetByObjectKey(key string) (*model.MediaAsset, error) {
+	var asset model.MediaAsset
+	err := r.db.Unscoped().
+		Where("`key` = ? OR private_key = ? OR JSON_CONTAINS(variants, JSON_OBJECT('key', ?))", key, key, key).
+		First(&asset).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+// Search lấy danh sách file có phân trang, mới upload trước
+func (r *MediaRepo) Search(filter MediaFilter, page, limit int) ([]model.MediaAsset, int64, error) {
+	var assets []model.MediaAsset
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.MediaAsset{})
+	if filter.Keyword != "" {
+		like := "%" + filter.Keyword + "%"
+		query = query.Where("file_name LIKE ? OR title LIKE ? OR alt_text LIKE ? OR caption LIKE ?", like, like, like, like)
+	}
+	if filter.Folder != "" {
+		query = query.Where("folder = ?", filter.Folder)
+	}
+	if filter.ContentType != "" {
+		query = query.Where("content_type LIKE ?", filter.ContentType+"%")
+	}
+	if filter.UploadedByID != nil {
+		query = query.Where("uploaded_by_id = ?", *filter.UploadedByID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Visibility != "" {
+		query = query.Where("visibility = ?", filter.Visibility)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("UploadedBy").
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&assets).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return assets, total, nil
+}
+
+// Save lưu thay đổi (kể cả khôi phục bản đã xóa mềm)
+func (r *MediaRepo) Save(asset *model.MediaAsset) error {
+	return r.db.Unscoped().Omit("UploadedBy").Save(asset).Error
+}
+
+// Delete xóa mềm file khỏi thư viện
+func (r *MediaRepo) Delete(id uuid.UUID) error {
+	return r.db.Delete(&model.MediaAsset{}, "id = ?", id).Error
+}
+
+// DeleteByKey xóa mềm file khỏi thư viện theo object key (file đã bị xóa trên storage)
+func (r *MediaRepo) DeleteByKey(key string) error {
+	return r.db.Where("`key` = ?", key).Delete(&model.MediaAsset{}).Error
+}
+
+// GetPendingScan lấy các file đang chờ quét chưa quá số lần thử, cập nhật trước thời điểm before (cũ trước)
+func (r *MediaRepo) GetPendingScan(maxAttempts int, before time.Time, limit int) ([]model.MediaAsset, error) {
+	var assets []model.MediaAsset
+	err := r.db.Where("status = ? AND scan_attempts < ? AND updated_at < ?", model.MediaStatusPending, maxAttempts, before).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&assets).Error
+	return assets, err
+}
+
+// GetLibraryKeys lấy tập object key (gồm cả variant) của các file còn trong thư viện
+func (r *MediaRepo) GetLibraryKeys() (map[string]struct{}, error) {
+	var assets []model.MediaAsset
+	if err := r.db.Select("id", "`key`", "variants").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+	library := make(map[string]struct{}, len(assets))
+	for i := range assets {
+		for _, key := range assets[i].ObjectKeys() {
+			library[key] = struct{}{}
+		}
+	}
+	return library, nil
+}
+
+// RecordDownload ghi nhật ký tải file và tăng số lượt tải
+func (r *MediaRepo) RecordDownload(download *model.MediaDownload) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(download).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.MediaAsset{}).
+			Where("id = ?", download.MediaID).
+			UpdateColumn("download_count", gorm.Expr("download_count + 1")).Error
+	})
+}
+
+// GetDownloads lấy nhật ký tải của một file có phân trang, mới nhất trước
+func (r *MediaRepo) GetDownloads(mediaID uuid.UUID, page, limit int) ([]model.MediaDownload, int64, error) {
+	var downloads []model.MediaDownload
+	var total int64
+
+	query := r.db.Model(&model.MediaDownload{}).Where("media_id = ?", mediaID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").Preload("Subscriber").
+		Order("created_at DESC").
+		Limit(limit).Offset((page - 1) * limit).
+		Find(&downloads).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return downloads, total, nil
+}
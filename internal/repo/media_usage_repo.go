@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Số dòng đọc/ghi mỗi lượt khi dựng lại chỉ mục
+const rebuildBatchSize = 500
+
+// mediaSource - Bảng và các cột JSON có thể chứa link file
+type mediaSource struct {
+	table   string
+	columns []string
+}
+
+// mediaSources - Nơi tìm tham chiếu file theo từng loại entity
+var mediaSources = map[string]mediaSource{
+	model.MediaEntityArticle:         {table: "articles", columns: []string{"metadata", "content", "attachments"}},
+	model.MediaEntityCategory:        {table: "categories", columns: []string{"metadata"}},
+	model.MediaEntityTag:             {table: "tags", columns: []string{"metadata", "content"}},
+	model.MediaEntityUser:            {table: "users", columns: []string{"avatar"}},
+	model.MediaEntityAuthorProfile:   {table: "author_profiles", columns: []string{"photo"}},
+	model.MediaEntityHomepageSection: {table: "homepage_sections", columns: []string{"metadata"}},
+	model.MediaEntityLegalDocument:   {table: "legal_documents", columns: []string{"content", "attachments"}},
+	model.MediaEntityConsultation:    {table: "consultation_requests", columns: []string{"attachments"}},
+	model.MediaEntityFAQ:             {table: "faqs", columns: []string{"answer"}},
+	model.MediaEntitySeries:          {table: "series", columns: []string{"metadata"}},
+}
+
+// mediaPayloads lấy nội dung các cột nguồn của một dòng
+func mediaPayloads(row map[string]interface{}, columns []string) [][]byte {
+	payloads := make([][]byte, 0, len(columns))
+	for _, column := range columns {
+		switch v := row[column].(type) {
+		case []byte:
+			payloads = append(payloads, v)
+		case string:
+			payloads = append(payloads, []byte(v))
+		}
+	}
+	return payloads
+}
+
+// reindexMediaUsage quét lại các cột của một entity và thay toàn bộ tham chiếu của nó.
+// Entity đã bị xóa (kể cả xóa mềm) không còn tham chiếu nào
+func reindexMediaUsage(tx *gorm.DB, entityType string, entityID uuid.UUID) error {
+	source, ok := mediaSources[entityType]
+	if !ok {
+		return fmt.Errorf("loại entity không hỗ trợ: %s", entityType)
+	}
+
+	var keys []string
+	row := map[string]interface{}{}
+	result := tx.Table(source.table).
+		Select(source.columns).
+		Where("id = ? AND deleted_at IS NULL", entityID).
+		Limit(1).
+		Find(&row)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		keys = model.ExtractMediaKeys(mediaPayloads(row, source.columns)...)
+	}
+
+	if err := tx.Where("entity_type = ? AND entity_id = ?", entityType, entityID).Delete(&model.MediaUsage{}).Error; err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	usages := make([]model.MediaUsage, 0, len(keys))
+	for _, key := range keys {
+		usages = append(usages, model.MediaUsage{ObjectKey: key, EntityType: entityType, EntityID: entityID})
+	}
+	return tx.Create(&usages).Error
+}
+
+type MediaUsageRepo struct {
+	db *gorm.DB
+}
+
+func NewMediaUsageRepo() *MediaUsageRepo {
+	return &MediaUsageRepo{
+		db: app.GetDB(),
+	}
+}
+
+// GetByKey lấy các entity đang dùng file
+func (r *MediaUsageRepo) GetByKey(key string) ([]model.MediaUsage, error) {
+	var usages []model.MediaUsage
+	err := r.db.Where("object_key = ?", key).
+		Order("entity_type ASC, created_at ASC").
+		Find(&usages).Error
+	return usages, err
+}
+
+// GetByKeys lấy các entity đang dùng một trong các file (vd ảnh gốc và các variant)
+func (r *MediaUsageRepo) GetByKeys(keys []string) ([]model.MediaUsage, error) {
+	var usages []model.MediaUsage
+	err := r.db.Where("object_key IN ?", keys).
+		Order("entity_type ASC, created_at ASC").
+		Find(&usages).Error
+	return usages, err
+}
+
+// GetReferencedKeys lấy tập các object key đang được tham chiếu
+func (r *MediaUsageRepo) GetReferencedKeys() (map[string]struct{}, error) {
+	var keys []string
+	if err := r.db.Model(&model.MediaUsage{}).Distinct("object_key").Pluck("object_key", &keys).Error; err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		referenced[key] = struct{}{}
+	}
+	return referenced, nil
+}
+
+// Rebuild quét lại toàn bộ các bảng nguồn và dựng lại chỉ mục (bắt kịp dữ liệu cập nhật ngoài repo).
+// Quét và thay chỉ mục trong cùng một transaction; các dòng nguồn được đọc bằng khóa chia sẻ nên lần lưu entity
+// chạy song song phải chờ chỉ mục mới được ghi xong, tham chiếu của nó không bị bản quét cũ ghi đè.
+// Trả về số tham chiếu sau khi dựng lại
+func (r *MediaUsageRepo) Rebuild() (int, error) {
+	var usages []model.MediaUsage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for entityType, source := range mediaSources {
+			lastID := ""
+			for {
+				var rows []map[string]interface{}
+				err := tx.Table(source.table).
+					Select(append([]string{"id"}, source.columns...)).
+					Where("deleted_at IS NULL AND id > ?", lastID).
+					Order("id ASC").
+					Limit(rebuildBatchSize).
+					Clauses(clause.Locking{Strength: "SHARE"}).
+					Find(&rows).Error
+				if err != nil {
+					return fmt.Errorf("quét bảng %s: %w", source.table, err)
+				}
+				for _, row := range rows {
+					id, err := parseRowID(row["id"])
+					if err != nil {
+						return err
+					}
+					lastID = id.String()
+					for _, key := range model.ExtractMediaKeys(mediaPayloads(row, source.columns)...) {
+						usages = append(usages, model.MediaUsage{ObjectKey: key, EntityType: entityType, EntityID: id})
+					}
+				}
+				if len(rows) < rebuildBatchSize {
+					break
+				}
+			}
+		}
+
+		if err := tx.Where("1 = 1").Delete(&model.MediaUsage{}).Error; err != nil {
+			return err
+		}
+		if len(usages) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&usages, rebuildBatchSize).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(usages), nil
+}
+
+func parseRowID(v interface{}) (uuid.UUID, error) {
+	switch id := v.(type) {
+	case []byte:
+		return uuid.ParseBytes(id)
+	case string:
+		return uuid.Parse(id)
+	}
+	return uuid.Nil, fmt.Errorf("id không hợp lệ: %v", v)
+}
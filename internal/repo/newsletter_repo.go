@@ -0,0 +1,172 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/consts"
+	"backend/internal/model"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NewsletterRepo struct {
+	db *gorm.DB
+}
+
+func NewNewsletterRepo() *NewsletterRepo {
+	return &NewsletterRepo{
+		db: app.GetDB(),
+	}
+}
+
+// SubscriberFilter - Bộ lọc danh sách người đăng ký
+type SubscriberFilter struct {
+	Keyword string // Tìm theo email, tên
+	Status  string
+}
+
+func (r *NewsletterRepo) subscriberQuery(filter SubscriberFilter) *gorm.DB {
+	query := r.db.Model(&model.Subscriber{})
+	if filter.Keyword != "" {
+		like := "%" + filter.Keyword + "%"
+		query = query.Where("email LIKE ? OR name LIKE ?", like, like)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
+
+// CreateSubscriber tạo người đăng ký mới
+func (r *NewsletterRepo) CreateSubscriber(subscriber *model.Subscriber) error {
+	return r.db.Create(subscriber).Error
+}
+
+// UpdateSubscriber cập nhật người đăng ký
+func (r *NewsletterRepo) UpdateSubscriber(subscriber *model.Subscriber) error {
+	return r.db.Save(subscriber).Error
+}
+
+// DeleteSubscriber xóa hẳn người đăng ký (theo yêu cầu xóa dữ liệu cá nhân)
+func (r *NewsletterRepo) DeleteSubscriber(id uuid.UUID) error {
+	return r.db.Delete(&model.Subscriber{}, "id = ?", id).Error
+}
+
+func (r *NewsletterRepo) findSubscriber(query string, args ...interface{}) (*model.Subscriber, error) {
+	var subscriber model.Subscriber
+	err := r.db.Where(query, args...).First(&subscriber).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("subscriber not found")
+		}
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+// GetSubscriberByID lấy người đăng ký theo ID
+func (r *NewsletterRepo) GetSubscriberByID(id uuid.UUID) (*model.Subscriber, error) {
+	return r.findSubscriber("id = ?", id)
+}
+
+// GetSubscriberByEmail lấy người đăng ký theo email
+func (r *NewsletterRepo) GetSubscriberByEmail(email string) (*model.Subscriber, error) {
+	return r.findSubscriber("email = ?", email)
+}
+
+// GetSubscriberByConfirmToken lấy người đăng ký theo hash của token xác nhận
+func (r *NewsletterRepo) GetSubscriberByConfirmToken(tokenHash string) (*model.Subscriber, error) {
+	return r.findSubscriber("confirm_token_hash = ?", tokenHash)
+}
+
+// GetSubscriberByUnsubscribeToken lấy người đăng ký theo token hủy đăng ký
+func (r *NewsletterRepo) GetSubscriberByUnsubscribeToken(token string) (*model.Subscriber, error) {
+	return r.findSubscriber("unsubscribe_token = ?", token)
+}
+
+// GetSubscribers lấy danh sách người đăng ký có phân trang, mới nhất trước
+func (r *NewsletterRepo) GetSubscribers(filter SubscriberFilter, page, limit int) ([]model.Subscriber, int64, error) {
+	var subscribers []model.Subscriber
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.subscriberQuery(filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&subscribers).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscribers, total, nil
+}
+
+// FindSubscribersInBatches duyệt toàn bộ người đăng ký theo bộ lọc (dùng cho xuất CSV)
+func (r *NewsletterRepo) FindSubscribersInBatches(filter SubscriberFilter, batchSize int, fn func(batch []model.Subscriber) error) error {
+	var batch []model.Subscriber
+	return r.subscriberQuery(filter).Order("created_at ASC").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// GetConfirmedSubscribers lấy người đăng ký đã xác nhận (nhận bản tin)
+func (r *NewsletterRepo) GetConfirmedSubscribers() ([]model.Subscriber, error) {
+	var subscribers []model.Subscriber
+	err := r.db.Where("status = ?", consts.SubscriberStatusConfirmed).
+		Order("created_at ASC").
+		Find(&subscribers).Error
+	return subscribers, err
+}
+
+// MarkDigestSent ghi nhận thời điểm gửi bản tin cho các người đăng ký
+func (r *NewsletterRepo) MarkDigestSent(ids []uuid.UUID, sentAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&model.Subscriber{}).
+		Where("id IN ?", ids).
+		Update("last_digest_at", sentAt).Error
+}
+
+// GetPublishedArticlesBetween lấy bài viết xuất bản trong khoảng (since, until], mới nhất trước
+func (r *NewsletterRepo) GetPublishedArticlesBetween(since, until time.Time) ([]model.Article, error) {
+	var articles []model.Article
+	err := r.db.Model(&model.Article{}).
+		Where(publishedArticleWhere).
+		Where("published_at > ? AND published_at <= ?", since, until).
+		Preload("Category").
+		Order("published_at DESC").
+		Find(&articles).Error
+	return articles, err
+}
+
+// CreateDigest lưu lịch sử một lần gửi bản tin
+func (r *NewsletterRepo) CreateDigest(digest *model.NewsletterDigest) error {
+	return r.db.Create(digest).Error
+}
+
+// GetDigests lấy lịch sử gửi bản tin có phân trang
+func (r *NewsletterRepo) GetDigests(page, limit int) ([]model.NewsletterDigest, int64, error) {
+	var digests []model.NewsletterDigest
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.NewsletterDigest{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("sent_at DESC").Limit(limit).Offset(offset).Find(&digests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return digests, total, nil
+}
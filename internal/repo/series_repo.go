@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SeriesRepo struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepo() *SeriesRepo {
+	return &SeriesRepo{
+		db: app.GetDB(),
+	}
+}
+
+// preloadSeriesItems nạp các phần của chuỗi kèm bài viết, sắp xếp theo position
+func preloadSeriesItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("series_articles.position ASC")
+	}).Preload("Items.Article")
+}
+
+// Create tạo chuỗi bài viết mới
+func (r *SeriesRepo) Create(series *model.Series) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(series).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntitySeries, series.ID)
+	})
+}
+
+// GetByID lấy chuỗi theo ID kèm danh sách phần
+func (r *SeriesRepo) GetByID(id uuid.UUID) (*model.Series, error) {
+	var series model.Series
+	err := preloadSeriesItems(r.db).Where("id = ?", id).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetActiveBySlug lấy chuỗi đang hoạt động theo slug kèm danh sách phần
+func (r *SeriesRepo) GetActiveBySlug(slug string) (*model.Series, error) {
+	var series model.Series
+	err := preloadSeriesItems(r.db).Where("slug = ? AND is_active = ?", slug, true).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Search lấy danh sách chuỗi có phân trang, tìm theo tiêu đề
+func (r *SeriesRepo) Search(keyword string, page, limit int) ([]model.Series, int64, error) {
+	var series []model.Series
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&model.Series{})
+	if keyword != "" {
+		query = query.Where("title LIKE ?", "%"+keyword+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := preloadSeriesItems(query).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).Find(&series).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return series, total, nil
+}
+
+// Update cập nhật chuỗi
+func (r *SeriesRepo) Update(series *model.Series) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(series).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntitySeries, series.ID)
+	})
+}
+
+// Delete xóa mềm chuỗi và gỡ toàn bộ thành viên
+func (r *SeriesRepo) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&model.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.Series{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntitySeries, id)
+	})
+}
+
+// CheckSlugExists kiểm tra slug đã tồn tại chưa
+func (r *SeriesRepo) CheckSlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&model.Series{}).Where("slug = ?", slug)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// FindArticleConflicts trả về các bài viết trong danh sách đã thuộc một chuỗi khác
+func (r *SeriesRepo) FindArticleConflicts(seriesID uuid.UUID, articleIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(articleIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&model.SeriesArticle{}).
+		Where("article_id IN ? AND series_id != ?", articleIDs, seriesID).
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// SetArticles thay thế danh sách bài viết của chuỗi theo đúng thứ tự truyền vào
+func (r *SeriesRepo) SetArticles(seriesID uuid.UUID, articleIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&model.SeriesArticle{}).Error; err != nil {
+			return err
+		}
+		for i, articleID := range articleIDs {
+			item := model.SeriesArticle{
+				SeriesID:  seriesID,
+				ArticleID: articleID,
+				Position:  i + 1,
+			}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetByArticleIDs lấy các chuỗi (kèm danh sách phần) chứa ít nhất một bài viết trong danh sách
+func (r *SeriesRepo) GetByArticleIDs(articleIDs []uuid.UUID, activeOnly bool) ([]model.Series, error) {
+	var series []model.Series
+	if len(articleIDs) == 0 {
+		return series, nil
+	}
+
+	subQuery := r.db.Model(&model.SeriesArticle{}).
+		Select("series_id").
+		Where("article_id IN ?", articleIDs)
+
+	query := preloadSeriesItems(r.db).Where("id IN (?)", subQuery)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	err := query.Find(&series).Error
+	return series, err
+}
@@ -22,11 +22,21 @@ func NewTagRepo() *TagRepo {
 
 // Create tạo tag mới
 func (r *TagRepo) Create(tag *model.Tag) error {
-	return r.db.Create(tag).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(tag).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityTag, tag.ID)
+	})
+}
+
+// tagLocaleScope lọc tag công khai theo ngôn ngữ, fallback về ngôn ngữ mặc định
+func tagLocaleScope(locale string) func(db *gorm.DB) *gorm.DB {
+	return localeScope("tags", locale, "tr.is_active = 1")
 }
 
-// GetAll lấy tất cả tags với phân trang
-func (r *TagRepo) GetAll(page, limit int, activeOnly bool) ([]model.Tag, int64, error) {
+// GetAll lấy tất cả tags với phân trang (locale rỗng = mọi ngôn ngữ)
+func (r *TagRepo) GetAll(page, limit int, activeOnly bool, locale string) ([]model.Tag, int64, error) {
 	var tags []model.Tag
 	var total int64
 
@@ -34,6 +44,7 @@ func (r *TagRepo) GetAll(page, limit int, activeOnly bool) ([]model.Tag, int64,
 	if activeOnly {
 		query = query.Where("is_active = ?", true)
 	}
+	query = query.Scopes(tagLocaleScope(locale))
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -83,12 +94,22 @@ func (r *TagRepo) GetByIDs(ids []uuid.UUID) ([]model.Tag, error) {
 
 // Update cập nhật tag
 func (r *TagRepo) Update(tag *model.Tag) error {
-	return r.db.Save(tag).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityTag, tag.ID)
+	})
 }
 
 // Delete xóa mềm tag
 func (r *TagRepo) Delete(id uuid.UUID) error {
-	return r.db.Where("id = ?", id).Delete(&model.Tag{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).Delete(&model.Tag{}).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityTag, id)
+	})
 }
 
 // CheckSlugExists kiểm tra slug đã tồn tại chưa
@@ -102,10 +123,11 @@ func (r *TagRepo) CheckSlugExists(slug string, excludeID uuid.UUID) (bool, error
 	return count > 0, err
 }
 
-// GetPopularTags lấy tags phổ biến nhất
-func (r *TagRepo) GetPopularTags(limit int) ([]model.Tag, error) {
+// GetPopularTags lấy tags phổ biến nhất (locale rỗng = mọi ngôn ngữ)
+func (r *TagRepo) GetPopularTags(limit int, locale string) ([]model.Tag, error) {
 	var tags []model.Tag
 	err := r.db.Where("is_active = ? AND usage_count > 0", true).
+		Scopes(tagLocaleScope(locale)).
 		Order("usage_count DESC").Limit(limit).Find(&tags).Error
 	return tags, err
 }
@@ -166,15 +188,33 @@ func (r *TagRepo) GetAllSlugs(activeOnly bool) ([]string, error) {
 func (r *TagRepo) GetAllSlugsWithUpdatedAt(activeOnly bool) ([]struct{
 	Slug string
 	UpdatedAt time.Time
+	Locale string
+	TranslationGroupID *uuid.UUID
 }, error) {
 	var rows []struct{
 		Slug string
 		UpdatedAt time.Time
+		Locale string
+		TranslationGroupID *uuid.UUID
 	}
-	query := r.db.Model(&model.Tag{}).Select("slug, updated_at")
+	query := r.db.Model(&model.Tag{}).Select("slug, updated_at, locale, translation_group_id")
 	if activeOnly {
 		query = query.Where("is_active = ?", true)
 	}
 	err := query.Order("name ASC").Find(&rows).Error
 	return rows, err
 }
+
+// GetTranslations trả về các bản dịch khác của tag (activeOnly: chỉ bản đang hoạt động)
+func (r *TagRepo) GetTranslations(tag *model.Tag, activeOnly bool) ([]model.TranslationLink, error) {
+	if activeOnly {
+		return getTranslationLinks(r.db, &model.Tag{}, tag.TranslationGroupID, tag.ID, "is_active = ?", true)
+	}
+	return getTranslationLinks(r.db, &model.Tag{}, tag.TranslationGroupID, tag.ID, "")
+}
+
+// CheckTranslationLocaleExists kiểm tra nhóm bản dịch đã có tag với locale này chưa
+func (r *TagRepo) CheckTranslationLocaleExists(groupID uuid.UUID, locale string, excludeID uuid.UUID) (bool, error) {
+	count, err := countTranslationLocale(r.db, &model.Tag{}, groupID, locale, excludeID)
+	return count > 0, err
+}
@@ -0,0 +1,240 @@
+package repo
+
+import (
+	"backend/app"
+	"backend/internal/model"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dung lượng tính cho một lần upload: kích thước thật nếu đã xác nhận, chưa thì theo mức đã khai báo
+const uploadBytesExpr = "COALESCE(SUM(CASE WHEN size > 0 THEN size ELSE declared_size END), 0)"
+
+type UploadRepo struct {
+	db *gorm.DB
+}
+
+func NewUploadRepo() *UploadRepo {
+	return &UploadRepo{
+		db: app.GetDB(),
+	}
+}
+
+// guestQuotaScope - Phạm vi quota chung của mọi khách chưa đăng nhập
+const guestQuotaScope = "guest"
+
+// Reserve ghi sổ upload nếu dung lượng đã dùng của phạm vi cộng file mới không vượt limit (0 = không giới hạn).
+// Phạm vi là người upload, hoặc toàn bộ khách (UploadedByID nil) tính từ since. Trả về dung lượng đã dùng
+// và false nếu vượt quota (không ghi sổ). Dòng khóa của phạm vi được giữ tới hết transaction nên hai request
+// đồng thời không cùng lọt qua quota
+func (r *UploadRepo) Reserve(upload *model.Upload, limit int64, since time.Time) (int64, bool, error) {
+	var used int64
+	var reserved bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		used, reserved, err = reserveUpload(tx, upload, limit, since)
+		return err
+	})
+	return used, reserved, err
+}
+
+// reserveUpload xét quota và ghi sổ trong transaction tx (xem Reserve)
+func reserveUpload(tx *gorm.DB, upload *model.Upload, limit int64, since time.Time) (int64, bool, error) {
+	scope := guestQuotaScope
+	query := tx.Model(&model.Upload{}).Where("uploaded_by_id IS NULL AND created_at >= ?", since)
+	if upload.UploadedByID != nil {
+		scope = "user:" + upload.UploadedByID.String()
+		query = tx.Model(&model.Upload{}).Where("uploaded_by_id = ?", *upload.UploadedByID)
+	}
+
+	if limit > 0 {
+		lock := model.UploadQuotaLock{Scope: scope}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock).Error; err != nil {
+			return 0, false, err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("scope = ?", scope).First(&lock).Error; err != nil {
+			return 0, false, err
+		}
+	}
+
+	var used int64
+	if err := query.Select(uploadBytesExpr).Scan(&used).Error; err != nil {
+		return 0, false, err
+	}
+	if limit > 0 && used+upload.DeclaredSize > limit {
+		return used, false, nil
+	}
+	return used, true, tx.Create(upload).Error
+}
+
+// MarkSize cập nhật kích thước thật sau khi file được xác nhận trên storage
+func (r *UploadRepo) MarkSize(key string, size int64) error {
+	return r.db.Model(&model.Upload{}).Where("object_key = ?", key).Update("size", size).Error
+}
+
+// GetByKey lấy bản ghi upload theo object key, nil nếu không có
+func (r *UploadRepo) GetByKey(key string) (*model.Upload, error) {
+	var upload model.Upload
+	err := r.db.Where("object_key = ?", key).First(&upload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// MarkScanned lưu kết quả kiểm tra file (clean/rejected) cùng kích thước thật
+func (r *UploadRepo) MarkScanned(key, status, result string, size int64, now time.Time) error {
+	return r.db.Model(&model.Upload{}).Where("object_key = ?", key).Updates(map[string]interface{}{
+		"status":      status,
+		"scan_result": result,
+		"size":        size,
+		"scanned_at":  now,
+	}).Error
+}
+
+// MarkScanFailed tăng số lần quét lỗi (scanner/storage lỗi), file vẫn chờ quét lại
+func (r *UploadRepo) MarkScanFailed(key, result string) error {
+	return r.db.Model(&model.Upload{}).Where("object_key = ?", key).Updates(map[string]interface{}{
+		"scan_result":   result,
+		"scan_attempts": gorm.Expr("scan_attempts + 1"),
+	}).Error
+}
+
+// GetPendingScan lấy các lần upload chưa kiểm tra được cấp URL trước thời điểm before
+// (trừ phiên upload nhiều phần còn đang mở), cũ trước
+func (r *UploadRepo) GetPendingScan(maxAttempts int, before time.Time, limit int) ([]model.Upload, error) {
+	var uploads []model.Upload
+	active := r.db.Model(&model.MultipartUpload{}).Select("object_key").Where("status = ?", model.MultipartStatusUploading)
+	err := r.db.Where("status = ? AND scan_attempts < ? AND created_at < ?", model.MediaStatusPending, maxAttempts, before).
+		Where("object_key NOT IN (?)", active).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
+
+// DeleteByKey xóa mềm bản ghi upload khi file bị xóa khỏi storage (trả lại quota)
+func (r *UploadRepo) DeleteByKey(key string) error {
+	return r.db.Where("object_key = ?", key).Delete(&model.Upload{}).Error
+}
+
+// GetPendingKeys lấy key của các lần upload chưa xác nhận được cấp URL trước thời điểm before
+// (trừ phiên upload nhiều phần còn đang mở)
+func (r *UploadRepo) GetPendingKeys(before time.Time) (map[string]struct{}, error) {
+	var keys []string
+	active := r.db.Model(&model.MultipartUpload{}).Select("object_key").Where("status = ?", model.MultipartStatusUploading)
+	err := r.db.Model(&model.Upload{}).
+		Where("size = 0 AND created_at < ?", before).
+		Where("object_key NOT IN (?)", active).
+		Pluck("object_key", &keys).Error
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		pending[key] = struct{}{}
+	}
+	return pending, nil
+}
+
+// GetUsage lấy số file và dung lượng đang dùng của một người dùng
+func (r *UploadRepo) GetUsage(userID uuid.UUID) (model.StorageUsage, error) {
+	usage := model.StorageUsage{UserID: &userID}
+	err := r.db.Model(&model.Upload{}).
+		Select("COUNT(*) AS files, "+uploadBytesExpr+" AS bytes").
+		Where("uploaded_by_id = ?", userID).
+		Scan(&usage).Error
+	return usage, err
+}
+
+// GetUsageByUser lấy dung lượng theo từng người upload, nhiều nhất trước (khách có user_id = null)
+func (r *UploadRepo) GetUsageByUser() ([]model.StorageUsage, error) {
+	var usages []model.StorageUsage
+	err := r.db.Model(&model.Upload{}).
+		Select("uploaded_by_id AS user_id, COUNT(*) AS files, " + uploadBytesExpr + " AS bytes").
+		Group("uploaded_by_id").
+		Order("bytes DESC").
+		Scan(&usages).Error
+	return usages, err
+}
+
+// CreateTicket tạo vé upload công khai
+func (r *UploadRepo) CreateTicket(ticket *model.UploadTicket) error {
+	return r.db.Create(ticket).Error
+}
+
+// ConsumeTicket đánh dấu vé đã dùng; false nếu vé không tồn tại, hết hạn hoặc đã được dùng (atomic)
+func (r *UploadRepo) ConsumeTicket(id uuid.UUID, now time.Time) (bool, error) {
+	result := r.db.Model(&model.UploadTicket{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", id, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpiredTickets xóa vé đã hết hạn trước thời điểm before
+func (r *UploadRepo) DeleteExpiredTickets(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&model.UploadTicket{}).Error
+}
+
+// CreateMultipart ghi nhận phiên upload nhiều phần cùng bản ghi upload, xét quota như Reserve
+func (r *UploadRepo) CreateMultipart(multipart *model.MultipartUpload, upload *model.Upload, limit int64) (int64, bool, error) {
+	var used int64
+	var reserved bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		used, reserved, err = reserveUpload(tx, upload, limit, time.Time{})
+		if err != nil || !reserved {
+			return err
+		}
+		return tx.Create(multipart).Error
+	})
+	return used, reserved, err
+}
+
+// GetMultipart lấy phiên upload nhiều phần theo ID
+func (r *UploadRepo) GetMultipart(id uuid.UUID) (*model.MultipartUpload, error) {
+	var multipart model.MultipartUpload
+	err := r.db.Where("id = ?", id).First(&multipart).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("multipart upload not found")
+		}
+		return nil, err
+	}
+	return &multipart, nil
+}
+
+// FinishMultipart chuyển phiên đang mở sang completed/aborted; false nếu phiên đã được kết thúc trước đó (atomic)
+func (r *UploadRepo) FinishMultipart(id uuid.UUID, status string, now time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if status == model.MultipartStatusCompleted {
+		updates["completed_at"] = now
+	}
+	result := r.db.Model(&model.MultipartUpload{}).
+		Where("id = ? AND status = ?", id, model.MultipartStatusUploading).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetExpiredMultiparts lấy các phiên còn mở đã hết hạn trước thời điểm now (cũ trước)
+func (r *UploadRepo) GetExpiredMultiparts(now time.Time, limit int) ([]model.MultipartUpload, error) {
+	var multiparts []model.MultipartUpload
+	err := r.db.Where("status = ? AND expires_at < ?", model.MultipartStatusUploading, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&multiparts).Error
+	return multiparts, err
+}
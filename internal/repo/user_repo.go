@@ -16,7 +16,12 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 }
 
 func (r *UserRepository) CreateUser(user *model.User) error {
-	return r.db.Create(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityUser, user.ID)
+	})
 }
 
 func (r *UserRepository) GetUserByID(id uuid.UUID) (*model.User, error) {
@@ -58,11 +63,21 @@ func (r *UserRepository) GetUserByEmail(email string) (*model.User, error) {
 }
 
 func (r *UserRepository) UpdateUser(user *model.User) error {
-	return r.db.Save(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityUser, user.ID)
+	})
 }
 
 func (r *UserRepository) DeleteUser(id uuid.UUID) error {
-	return r.db.Delete(&model.User{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.User{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return reindexMediaUsage(tx, model.MediaEntityUser, id)
+	})
 }
 
 func (r *UserRepository) GetAllUsers() ([]model.User, error) {
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultClamAVAddress = "tcp://127.0.0.1:3310"
+	defaultClamAVTimeout = 2 * time.Minute
+	clamAVChunkSize      = 64 << 10
+)
+
+// ClamAV - Quét bằng clamd qua lệnh INSTREAM (TCP hoặc unix socket)
+type ClamAV struct {
+	Network string // tcp hoặc unix
+	Address string
+	Timeout time.Duration // Tổng thời gian một lần quét (gửi file + chờ kết quả)
+}
+
+// NewClamAVFromEnv tạo scanner từ CLAMAV_ADDRESS (tcp://host:port hoặc unix:///path/clamd.sock)
+// và CLAMAV_TIMEOUT_SECONDS
+func NewClamAVFromEnv() *ClamAV {
+	address := strings.TrimSpace(os.Getenv("CLAMAV_ADDRESS"))
+	if address == "" {
+		address = defaultClamAVAddress
+	}
+	c := &ClamAV{Network: "tcp", Address: address, Timeout: defaultClamAVTimeout}
+	switch {
+	case strings.HasPrefix(address, "unix://"):
+		c.Network, c.Address = "unix", strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "tcp://"):
+		c.Address = strings.TrimPrefix(address, "tcp://")
+	}
+	if v, err := strconv.Atoi(os.Getenv("CLAMAV_TIMEOUT_SECONDS")); err == nil && v > 0 {
+		c.Timeout = time.Duration(v) * time.Second
+	}
+	return c
+}
+
+func (c *ClamAV) Name() string { return "clamav" }
+
+// Scan gửi file theo từng chunk [độ dài 4 byte big-endian][dữ liệu], kết thúc bằng chunk rỗng,
+// rồi đọc kết quả "stream: OK" hoặc "stream: <signature> FOUND"
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, c.Network, c.Address)
+	if err != nil {
+		return Result{}, fmt.Errorf("không kết nối được clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return Result{}, err
+	}
+
+	if err := c.stream(conn, r); err != nil {
+		// clamd đóng kết nối khi file vượt StreamMaxLength; đọc thông báo lỗi nếu có
+		if reply, readErr := readClamAVReply(conn); readErr == nil && reply != "" {
+			return Result{}, fmt.Errorf("clamd: %s", reply)
+		}
+		return Result{}, err
+	}
+
+	reply, err := readClamAVReply(conn)
+	if err != nil {
+		return Result{}, fmt.Errorf("không đọc được kết quả clamd: %w", err)
+	}
+	return parseClamAVReply(reply)
+}
+
+func (c *ClamAV) stream(conn net.Conn, r io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+	buf := make([]byte, clamAVChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, werr := conn.Write(size); werr != nil {
+				return werr
+			}
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	_, err := conn.Write(size)
+	return err
+}
+
+func readClamAVReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+func parseClamAVReply(reply string) (Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", reply)
+	}
+}
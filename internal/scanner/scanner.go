@@ -0,0 +1,75 @@
+// Package scanner - Kiểm tra file upload: đối chiếu loại file thật với loại khai báo, chặn SVG có script
+// và quét mã độc (ClamAV qua socket, hoặc bỏ qua khi chạy local)
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrRejected - File không an toàn hoặc sai loại, phải cách ly. Các lỗi khác là lỗi hạ tầng, có thể quét lại
+var ErrRejected = errors.New("file bị từ chối")
+
+// Result - Kết quả quét mã độc
+type Result struct {
+	Clean     bool
+	Signature string // Tên mẫu mã độc khi phát hiện
+}
+
+// Scanner - Trình quét mã độc. Có thể thay thế bằng SetScanner
+type Scanner interface {
+	// Name trả về tên scanner (lưu cùng kết quả quét)
+	Name() string
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Noop - Không quét, mọi file đều sạch (mặc định khi chưa cấu hình ClamAV, dùng cho môi trường local)
+type Noop struct{}
+
+func (Noop) Name() string { return "none" }
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Clean: true}, nil
+}
+
+var (
+	current   Scanner
+	currentMu sync.RWMutex
+)
+
+// defaultScanner chọn scanner theo SCANNER_DRIVER (clamav, mặc định none)
+func defaultScanner() Scanner {
+	switch os.Getenv("SCANNER_DRIVER") {
+	case "clamav":
+		return NewClamAVFromEnv()
+	default:
+		return Noop{}
+	}
+}
+
+// SetScanner thay thế scanner mặc định
+func SetScanner(s Scanner) {
+	currentMu.Lock()
+	current = s
+	currentMu.Unlock()
+}
+
+// Get trả về scanner đang dùng
+func Get() Scanner {
+	currentMu.RLock()
+	s := current
+	currentMu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	if current == nil {
+		current = defaultScanner()
+	}
+	return current
+}
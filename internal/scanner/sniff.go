@@ -0,0 +1,222 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SniffBytes - Số byte đầu file dùng để nhận diện loại file và tìm nội dung lai (polyglot).
+// Ảnh và PDF (loại trình duyệt mở trực tiếp) được tìm dấu hiệu lai trên toàn bộ file bằng CheckPolyglot;
+// video, âm thanh và file Office (nén, dễ trùng ngẫu nhiên) chỉ kiểm tra phần đầu này
+const SniffBytes = 8 << 10
+
+const oleContentType = "application/x-ole-storage" // .doc, .xls (Compound File Binary)
+
+var (
+	oleMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	asfMagic = []byte{0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11} // .wmv
+)
+
+// Loại file thật được chấp nhận cho mỗi content type khai báo (danh sách khớp với getFolder khi upload)
+var acceptedTypes = map[string][]string{
+	"image/png":                {"image/png"},
+	"image/jpeg":               {"image/jpeg"},
+	"image/jpg":                {"image/jpeg"},
+	"image/bmp":                {"image/bmp"},
+	"image/gif":                {"image/gif"},
+	"image/webp":               {"image/webp"},
+	"image/svg+xml":            {"image/svg+xml"},
+	"application/pdf":          {"application/pdf"},
+	"application/msword":       {oleContentType},
+	"application/vnd.ms-excel": {oleContentType},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   {"application/zip"},
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         {"application/zip"},
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": {"application/zip"},
+	"text/plain":      {"text/plain"},
+	"video/mp4":       {"video/mp4", "video/quicktime"},
+	"video/quicktime": {"video/quicktime", "video/mp4"},
+	"video/mpeg":      {"video/mpeg"},
+	"video/x-ms-wmv":  {"video/x-ms-asf"},
+	"audio/mpeg":      {"audio/mpeg"},
+	"audio/wav":       {"audio/wave"},
+	"audio/ogg":       {"application/ogg"},
+}
+
+// Dấu hiệu HTML/script/mã server trong file nhị phân: file lai có thể bị trình duyệt hoặc server hiểu thành trang web
+var polyglotMarkers = [][]byte{
+	[]byte("<script"), []byte("<html"), []byte("<iframe"), []byte("<body"),
+	[]byte("<?php"), []byte("<%@"), []byte("javascript:"),
+}
+
+// DetectContentType nhận diện loại file từ các byte đầu: http.DetectContentType bổ sung Office, video, MP3 và SVG
+func DetectContentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, oleMagic):
+		return oleContentType
+	case bytes.HasPrefix(head, asfMagic):
+		return "video/x-ms-asf"
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		if string(head[8:12]) == "qt  " {
+			return "video/quicktime"
+		}
+		return "video/mp4"
+	case len(head) >= 8 && (string(head[4:8]) == "moov" || string(head[4:8]) == "mdat" || string(head[4:8]) == "wide"):
+		return "video/quicktime"
+	case bytes.HasPrefix(head, []byte{0x00, 0x00, 0x01, 0xBA}) || bytes.HasPrefix(head, []byte{0x00, 0x00, 0x01, 0xB3}):
+		return "video/mpeg"
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		return "audio/mpeg" // MP3 không có thẻ ID3
+	case looksLikeSVG(head):
+		return "image/svg+xml"
+	}
+
+	detected := http.DetectContentType(head)
+	if i := strings.Index(detected, ";"); i >= 0 {
+		detected = detected[:i]
+	}
+	return detected
+}
+
+// looksLikeSVG - Văn bản XML có thẻ gốc <svg
+func looksLikeSVG(head []byte) bool {
+	text := bytes.ToLower(bytes.TrimSpace(bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF"))))
+	if !bytes.HasPrefix(text, []byte("<")) {
+		return false
+	}
+	return bytes.Contains(text, []byte("<svg"))
+}
+
+// CheckContent đối chiếu các byte đầu file với content type khai báo, trả về loại file thật.
+// Lỗi bọc ErrRejected khi file sai loại hoặc là file lai chứa HTML/script. SVG cần kiểm tra thêm bằng CheckSVG
+func CheckContent(declared string, head []byte) (string, error) {
+	detected := DetectContentType(head)
+	accepted, ok := acceptedTypes[declared]
+	if !ok {
+		return detected, fmt.Errorf("%w: loại file %s không được phép", ErrRejected, declared)
+	}
+
+	matched := false
+	for _, t := range accepted {
+		if t == detected {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return detected, fmt.Errorf("%w: nội dung là %s nhưng khai báo %s", ErrRejected, detected, declared)
+	}
+
+	if detected != "text/plain" && detected != "image/svg+xml" {
+		if marker := findPolyglotMarker(bytes.ToLower(head)); marker != nil {
+			return detected, fmt.Errorf("%w: file %s chứa %q (file lai)", ErrRejected, detected, marker)
+		}
+	}
+	return detected, nil
+}
+
+func findPolyglotMarker(lower []byte) []byte {
+	for _, marker := range polyglotMarkers {
+		if bytes.Contains(lower, marker) {
+			return marker
+		}
+	}
+	return nil
+}
+
+// NeedsFullPolyglotScan kiểm tra loại file (đã nhận diện) cần tìm dấu hiệu lai trên toàn bộ nội dung
+func NeedsFullPolyglotScan(detected string) bool {
+	return detected != "image/svg+xml" && (strings.HasPrefix(detected, "image/") || detected == "application/pdf")
+}
+
+// polyglotChunkBytes - Kích thước mỗi lần đọc khi quét toàn bộ file
+const polyglotChunkBytes = 64 << 10
+
+// CheckPolyglot đọc toàn bộ file theo từng đoạn và tìm dấu hiệu HTML/script (kể cả nằm vắt qua hai đoạn).
+// Lỗi bọc ErrRejected khi tìm thấy; lỗi khác là lỗi đọc
+func CheckPolyglot(detected string, r io.Reader) error {
+	overlap := 0
+	for _, marker := range polyglotMarkers {
+		if len(marker)-1 > overlap {
+			overlap = len(marker) - 1
+		}
+	}
+
+	buf := make([]byte, overlap+polyglotChunkBytes)
+	kept := 0
+	for {
+		n, err := io.ReadFull(r, buf[kept:])
+		if n > 0 {
+			window := bytes.ToLower(buf[:kept+n])
+			if marker := findPolyglotMarker(window); marker != nil {
+				return fmt.Errorf("%w: file %s chứa %q (file lai)", ErrRejected, detected, marker)
+			}
+			kept = copy(buf, buf[max(0, kept+n-overlap):kept+n])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Thẻ SVG có thể chạy script hoặc nhúng trang khác
+var unsafeSVGElements = map[string]bool{
+	"script": true, "foreignobject": true, "iframe": true, "embed": true, "object": true, "handler": true, "listener": true,
+}
+
+// CheckSVG phân tích toàn bộ SVG, từ chối script, thuộc tính sự kiện (onload...), link javascript:/data:text/html
+// và khai báo ENTITY (XXE). SVG không phân tích được cũng bị từ chối
+func CheckSVG(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+	root := true
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: SVG không hợp lệ: %v", ErrRejected, err)
+		}
+
+		switch t := token.(type) {
+		case xml.Directive:
+			if bytes.Contains(bytes.ToUpper(t), []byte("ENTITY")) {
+				return fmt.Errorf("%w: SVG khai báo ENTITY", ErrRejected)
+			}
+		case xml.ProcInst:
+			if t.Target == "xml-stylesheet" {
+				return fmt.Errorf("%w: SVG nhúng stylesheet ngoài", ErrRejected)
+			}
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if root && name != "svg" {
+				return fmt.Errorf("%w: thẻ gốc là <%s>, không phải <svg>", ErrRejected, t.Name.Local)
+			}
+			root = false
+			if unsafeSVGElements[name] {
+				return fmt.Errorf("%w: SVG chứa thẻ <%s>", ErrRejected, t.Name.Local)
+			}
+			for _, attr := range t.Attr {
+				attrName := strings.ToLower(attr.Name.Local)
+				if strings.HasPrefix(attrName, "on") {
+					return fmt.Errorf("%w: SVG chứa thuộc tính sự kiện %s", ErrRejected, attr.Name.Local)
+				}
+				value := strings.ToLower(strings.Join(strings.Fields(attr.Value), ""))
+				if strings.HasPrefix(value, "javascript:") || strings.HasPrefix(value, "vbscript:") || strings.HasPrefix(value, "data:text/html") {
+					return fmt.Errorf("%w: SVG chứa link %s", ErrRejected, attr.Name.Local)
+				}
+			}
+		}
+	}
+	if root {
+		return fmt.Errorf("%w: file không có thẻ <svg>", ErrRejected)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+var (
+	pngHead  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	jpegHead = []byte("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00")
+	pdfHead  = []byte("%PDF-1.7\n")
+	zipHead  = []byte("PK\x03\x04\x14\x00\x06\x00")
+	mp4Head  = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00")
+	movHead  = []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00")
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"png", pngHead, "image/png"},
+		{"jpeg", jpegHead, "image/jpeg"},
+		{"gif", []byte("GIF89a\x01\x00"), "image/gif"},
+		{"pdf", pdfHead, "application/pdf"},
+		{"docx/xlsx", zipHead, "application/zip"},
+		{"doc/xls", append(append([]byte{}, oleMagic...), 0x00), oleContentType},
+		{"wmv", append(append([]byte{}, asfMagic...), 0x00), "video/x-ms-asf"},
+		{"mp4", mp4Head, "video/mp4"},
+		{"mov", movHead, "video/quicktime"},
+		{"mpeg", []byte{0x00, 0x00, 0x01, 0xBA, 0x44}, "video/mpeg"},
+		{"mp3 không ID3", []byte{0xFF, 0xFB, 0x90, 0x00}, "audio/mpeg"},
+		{"svg", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`), "image/svg+xml"},
+		{"svg có BOM", []byte("\xEF\xBB\xBF<svg></svg>"), "image/svg+xml"},
+		{"html", []byte("<!DOCTYPE html><html></html>"), "text/html"},
+		{"văn bản", []byte("Xin chào"), "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.head); got != tt.want {
+				t.Errorf("DetectContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared string
+		head     []byte
+		want     string
+		rejected bool
+	}{
+		{"png đúng khai báo", "image/png", pngHead, "image/png", false},
+		{"image/jpg là jpeg", "image/jpg", jpegHead, "image/jpeg", false},
+		{"docx là zip", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", zipHead, "application/zip", false},
+		{"mov khai báo mp4", "video/mp4", movHead, "video/quicktime", false},
+		{"văn bản chứa thẻ html vẫn hợp lệ", "text/plain", []byte("dùng <script> để..."), "text/plain", false},
+		{"loại không được phép", "application/x-msdownload", []byte("MZ\x90\x00"), "", true},
+		{"html khai báo là png", "image/png", []byte("<html><script>alert(1)</script>"), "text/html", true},
+		{"pdf khai báo là jpeg", "image/jpeg", pdfHead, "application/pdf", true},
+		{"png lai chứa script", "image/png", append(append([]byte{}, pngHead...), "<SCRIPT>alert(1)"...), "image/png", true},
+		{"pdf lai chứa php", "application/pdf", append(append([]byte{}, pdfHead...), "<?php system($_GET[1]);"...), "application/pdf", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckContent(tt.declared, tt.head)
+			if rejected := errors.Is(err, ErrRejected); rejected != tt.rejected {
+				t.Fatalf("CheckContent() err = %v, want rejected = %v", err, tt.rejected)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("CheckContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsFullPolyglotScan(t *testing.T) {
+	tests := []struct {
+		detected string
+		want     bool
+	}{
+		{"image/png", true},
+		{"image/jpeg", true},
+		{"image/gif", true},
+		{"application/pdf", true},
+		{"image/svg+xml", false},
+		{"application/zip", false},
+		{"video/mp4", false},
+		{"text/plain", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.detected, func(t *testing.T) {
+			if got := NeedsFullPolyglotScan(tt.detected); got != tt.want {
+				t.Errorf("NeedsFullPolyglotScan(%q) = %v, want %v", tt.detected, got, tt.want)
+			}
+		})
+	}
+}
+
+// oneByteReader trả về từng byte một để kiểm tra dấu hiệu nằm vắt qua nhiều lần đọc
+type oneByteReader struct{ r io.Reader }
+
+func (o oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return o.r.Read(p[:1])
+}
+
+func TestCheckPolyglot(t *testing.T) {
+	padding := func(n int) []byte { return bytes.Repeat([]byte{0x00}, n) }
+	concat := func(parts ...[]byte) []byte { return bytes.Join(parts, nil) }
+
+	tests := []struct {
+		name     string
+		data     []byte
+		rejected bool
+	}{
+		{"file rỗng", nil, false},
+		{"ảnh sạch", concat(pngHead, padding(3*polyglotChunkBytes)), false},
+		{"dấu hiệu ở đầu", concat(pngHead, []byte("<script>")), true},
+		{"dấu hiệu sau phần sniff", concat(pngHead, padding(SniffBytes*2), []byte("<iframe src=x>")), true},
+		{"dấu hiệu vắt qua hai đoạn", concat(padding(polyglotChunkBytes-3), []byte("<html>")), true},
+		{"dấu hiệu ở cuối đoạn thứ hai", concat(padding(2*polyglotChunkBytes-8), []byte("<script")), true},
+		{"không phân biệt hoa thường", concat(padding(polyglotChunkBytes+10), []byte("JavaScript:alert(1)")), true},
+		{"dấu hiệu bị cắt ngang bởi byte khác", concat(padding(polyglotChunkBytes-3), []byte("<scr\x00ipt")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPolyglot("image/png", bytes.NewReader(tt.data))
+			if rejected := errors.Is(err, ErrRejected); rejected != tt.rejected || (!tt.rejected && err != nil) {
+				t.Errorf("CheckPolyglot() = %v, want rejected = %v", err, tt.rejected)
+			}
+		})
+	}
+
+	t.Run("đọc từng byte", func(t *testing.T) {
+		data := concat(padding(100), []byte("<?php"))
+		if err := CheckPolyglot("image/png", oneByteReader{bytes.NewReader(data)}); !errors.Is(err, ErrRejected) {
+			t.Errorf("CheckPolyglot() = %v, want ErrRejected", err)
+		}
+	})
+
+	t.Run("lỗi đọc", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		err := CheckPolyglot("image/png", io.MultiReader(bytes.NewReader(padding(10)), errReader{readErr}))
+		if !errors.Is(err, readErr) || errors.Is(err, ErrRejected) {
+			t.Errorf("CheckPolyglot() = %v, want %v", err, readErr)
+		}
+	})
+}
+
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) { return 0, e.err }
+
+func TestCheckSVG(t *testing.T) {
+	tests := []struct {
+		name     string
+		svg      string
+		rejected bool
+	}{
+		{"svg sạch", `<svg xmlns="http://www.w3.org/2000/svg"><circle cx="5" cy="5" r="4" fill="#000"/></svg>`, false},
+		{"link nội bộ", `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="#a"/></svg>`, false},
+		{"thẻ script", `<svg><script>alert(1)</script></svg>`, true},
+		{"foreignObject", `<svg><foreignObject><div/></foreignObject></svg>`, true},
+		{"thuộc tính sự kiện", `<svg onload="alert(1)"></svg>`, true},
+		{"link javascript có khoảng trắng", `<svg><a href=" java script:alert(1)"/></svg>`, true},
+		{"link data html", `<svg><image href="data:text/html;base64,PHNjcmlwdD4="/></svg>`, true},
+		{"khai báo ENTITY", `<?xml version="1.0"?><!DOCTYPE svg [<!entity xxe SYSTEM "file:///etc/passwd">]><svg></svg>`, true},
+		{"stylesheet ngoài", `<?xml-stylesheet href="https://evil.example/a.css"?><svg></svg>`, true},
+		{"thẻ gốc không phải svg", `<html><svg></svg></html>`, true},
+		{"không có thẻ", `<?xml version="1.0"?>`, true},
+		{"xml hỏng", `<svg><g></svg>`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSVG([]byte(tt.svg))
+			if rejected := errors.Is(err, ErrRejected); rejected != tt.rejected || (!tt.rejected && err != nil) {
+				t.Errorf("CheckSVG() = %v, want rejected = %v", err, tt.rejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package sectiontype
+
+// Các loại section có sẵn. TYPE01, TYPE02 khớp dữ liệu mặc định tạo trong createDefaultHomepageSections;
+// TYPE03 trở đi là section động, metadata là tham số truy vấn
+func init() {
+	Register(&Type{
+		Key:         "TYPE01",
+		Name:        "Chúng tôi chuyên",
+		Description: "Danh sách thẻ giới thiệu: tiêu đề, mô tả ngắn, icon và màu nhấn",
+		Position:    1,
+		Schema:      cardListSchema(8),
+	})
+	Register(&Type{
+		Key:         "TYPE02",
+		Name:        "Chủ đề - lĩnh vực",
+		Description: "Danh sách lĩnh vực hỗ trợ: tiêu đề, mô tả ngắn, icon và màu nhấn",
+		Position:    2,
+		Schema:      cardListSchema(12),
+	})
+	Register(&Type{
+		Key:         "TYPE03",
+		Name:        "Bài viết mới theo danh mục",
+		Description: "Các bài viết mới nhất của một danh mục (cả danh mục con cùng nhóm bản dịch)",
+		Source:      SourceCategoryArticles,
+		Position:    3,
+		Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"category_slug": {Type: "string", Title: "Slug danh mục", MaxLength: intPtr(200), Pattern: `^[a-z0-9]+(-[a-z0-9]+)*$`},
+				"limit":         limitSchema(6, 20),
+			},
+			PropertyOrder:        []string{"category_slug", "limit"},
+			Required:             []string{"category_slug"},
+			AdditionalProperties: boolPtr(false),
+		},
+	})
+	Register(&Type{
+		Key:         "TYPE04",
+		Name:        "Bài viết nổi bật",
+		Description: "Các bài viết được đánh dấu nổi bật, mới nhất trước",
+		Source:      SourceHotArticles,
+		Position:    4,
+		Schema:      limitOnlySchema(6, 20),
+	})
+	Register(&Type{
+		Key:         "TYPE05",
+		Name:        "Tags phổ biến",
+		Description: "Các tags được dùng nhiều nhất",
+		Source:      SourcePopularTags,
+		Position:    5,
+		Schema:      limitOnlySchema(10, 50),
+	})
+	Register(&Type{
+		Key:         "TYPE06",
+		Name:        "Bài viết chọn lọc",
+		Description: "Các bài viết chọn tay, hiển thị theo đúng thứ tự đã chọn (bài chưa xuất bản bị bỏ qua)",
+		Source:      SourceSelectedArticles,
+		Position:    6,
+		Schema: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"article_ids": {
+					Type:     "array",
+					Title:    "Bài viết",
+					MinItems: intPtr(1),
+					MaxItems: intPtr(20),
+					Items:    &Schema{Type: "string", Format: "uuid"},
+				},
+			},
+			Required:             []string{"article_ids"},
+			AdditionalProperties: boolPtr(false),
+		},
+	})
+}
+
+// cardListSchema - Mảng thẻ {title, des, icon, color}
+func cardListSchema(maxItems int) *Schema {
+	return &Schema{
+		Type:     "array",
+		Title:    "Danh sách thẻ",
+		MinItems: intPtr(1),
+		MaxItems: intPtr(maxItems),
+		Items: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"title": {Type: "string", Title: "Tiêu đề", MaxLength: intPtr(255)},
+				"des":   {Type: "string", Title: "Mô tả", MaxLength: intPtr(1000)},
+				"icon":  {Type: "string", Title: "Icon", Description: "Tên icon Iconify, vd ph:monitor", MaxLength: intPtr(100), Pattern: `^[a-z0-9-]+:[a-z0-9-]+$`},
+				"color": {Type: "string", Title: "Màu nhấn", Format: "color"},
+			},
+			PropertyOrder:        []string{"title", "des", "icon", "color"},
+			Required:             []string{"title", "des", "icon", "color"},
+			AdditionalProperties: boolPtr(false),
+		},
+	}
+}
+
+// limitSchema - Số mục hiển thị (mặc định def, tối đa max)
+func limitSchema(def, max int) *Schema {
+	return &Schema{Type: "integer", Title: "Số mục hiển thị", Minimum: floatPtr(1), Maximum: floatPtr(float64(max)), Default: def}
+}
+
+// limitOnlySchema - Object chỉ có tham số limit
+func limitOnlySchema(def, max int) *Schema {
+	return &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{"limit": limitSchema(def, max)},
+		AdditionalProperties: boolPtr(false),
+	}
+}
+
+func intPtr(v int) *int { return &v }
+
+func floatPtr(v float64) *float64 { return &v }
+
+func boolPtr(v bool) *bool { return &v }
@@ -0,0 +1,117 @@
+package sectiontype
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Nguồn nội dung của section. Section tĩnh chỉ có metadata; section động dùng metadata làm tham số truy vấn
+// và nội dung được lấy khi đọc
+const (
+	SourceStatic           = "static"
+	SourceCategoryArticles = "category_articles" // Bài viết mới nhất của một danh mục
+	SourceHotArticles      = "hot_articles"      // Bài viết nổi bật (is_hot)
+	SourcePopularTags      = "popular_tags"      // Tags dùng nhiều nhất
+	SourceSelectedArticles = "selected_articles" // Bài viết chọn tay theo ID
+)
+
+// Type - Loại section trang chủ: key (giá trị section_type của section) và schema của metadata.
+// Nhiều section (khác type_key) có thể dùng cùng một loại, vd nhiều khối bài viết theo danh mục
+type Type struct {
+	Key         string  `json:"type_key"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Source      string  `json:"source"`   // static hoặc nguồn nội dung động
+	Position    int     `json:"position"` // Thứ tự gợi ý trên trang quản trị
+	Schema      *Schema `json:"schema"`   // Schema của trường metadata
+}
+
+// IsDynamic kiểm tra section lấy nội dung khi đọc
+func (t *Type) IsDynamic() bool {
+	return t.Source != SourceStatic
+}
+
+// Validate kiểm tra metadata của section theo schema của loại
+func (t *Type) Validate(metadata json.RawMessage) []Violation {
+	return t.Schema.Validate("metadata", metadata)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Type{}
+)
+
+// Register đăng ký loại section. Panic khi trùng type_key hoặc schema có pattern sai (lỗi lập trình)
+func Register(t *Type) {
+	t.Key = NormalizeKey(t.Key)
+	if t.Key == "" || t.Schema == nil {
+		panic("sectiontype: type_key và schema là bắt buộc")
+	}
+	if t.Source == "" {
+		t.Source = SourceStatic
+	}
+	checkPatterns(t.Schema)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[t.Key]; exists {
+		panic(fmt.Sprintf("sectiontype: %s đã được đăng ký", t.Key))
+	}
+	registry[t.Key] = t
+}
+
+// Get lấy loại section theo type_key
+func Get(key string) (*Type, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	t, ok := registry[NormalizeKey(key)]
+	return t, ok
+}
+
+// All lấy mọi loại section theo thứ tự gợi ý
+func All() []*Type {
+	registryMu.RLock()
+	types := make([]*Type, 0, len(registry))
+	for _, t := range registry {
+		types = append(types, t)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Position != types[j].Position {
+			return types[i].Position < types[j].Position
+		}
+		return types[i].Key < types[j].Key
+	})
+	return types
+}
+
+// Keys lấy danh sách type_key đã đăng ký
+func Keys() []string {
+	types := All()
+	keys := make([]string, 0, len(types))
+	for _, t := range types {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
+
+// NormalizeKey chuẩn hóa type_key (viết hoa, bỏ khoảng trắng)
+func NormalizeKey(key string) string {
+	return strings.ToUpper(strings.TrimSpace(key))
+}
+
+func checkPatterns(s *Schema) {
+	if s.Pattern != "" {
+		compilePattern(s.Pattern)
+	}
+	for _, property := range s.Properties {
+		checkPatterns(property)
+	}
+	if s.Items != nil {
+		checkPatterns(s.Items)
+	}
+}
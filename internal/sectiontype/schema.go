@@ -0,0 +1,227 @@
+package sectiontype
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Schema - Tập con JSON Schema đủ để mô tả metadata của section và để trang quản trị tự dựng form
+type Schema struct {
+	Type                 string             `json:"type"` // object, array, string, integer, number, boolean
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PropertyOrder        []string           `json:"x-order,omitempty"` // Thứ tự hiển thị các trường trên form
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"` // uuid, uri, color (gợi ý widget cho form)
+	Enum                 []string           `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+}
+
+// Violation - Một lỗi validate metadata. Rule dùng cùng tên với rule của validator (required, max, oneof...)
+// để thông điệp lỗi được dịch giống lỗi validate request
+type Violation struct {
+	Field string // Đường dẫn trường, vd metadata[0].title
+	Rule  string // required, type, min, max, min_list, max_list, min_num, max_num, oneof, pattern, uuid, url, unknown
+	Param string
+}
+
+func (v Violation) Error() string {
+	if v.Param == "" {
+		return fmt.Sprintf("%s: %s", v.Field, v.Rule)
+	}
+	return fmt.Sprintf("%s: %s=%s", v.Field, v.Rule, v.Param)
+}
+
+var (
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+	patternCacheMu sync.Mutex
+	patternCache   = map[string]*regexp.Regexp{}
+)
+
+// Validate kiểm tra raw JSON theo schema, field là tên gốc dùng trong đường dẫn lỗi.
+// JSON sai cú pháp trả về một lỗi "type" ở trường gốc
+func (s *Schema) Validate(field string, raw json.RawMessage) []Violation {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return []Violation{{Field: field, Rule: "required"}}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []Violation{{Field: field, Rule: "type", Param: s.Type}}
+	}
+	var violations []Violation
+	s.validate(field, value, &violations)
+	return violations
+}
+
+func (s *Schema) validate(field string, value interface{}, violations *[]Violation) {
+	add := func(rule, param string) {
+		*violations = append(*violations, Violation{Field: field, Rule: rule, Param: param})
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			add("type", s.Type)
+			return
+		}
+		for _, name := range s.Required {
+			if v, exists := object[name]; !exists || v == nil || v == "" {
+				*violations = append(*violations, Violation{Field: field + "." + name, Rule: "required"})
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, known := s.Properties[name]
+			switch {
+			case !known && s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				*violations = append(*violations, Violation{Field: field + "." + name, Rule: "unknown"})
+			case known && object[name] != nil && !(object[name] == "" && s.isRequired(name)):
+				property.validate(field+"."+name, object[name], violations)
+			}
+		}
+
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			add("type", s.Type)
+			return
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			add("min_list", strconv.Itoa(*s.MinItems))
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			add("max_list", strconv.Itoa(*s.MaxItems))
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(fmt.Sprintf("%s[%d]", field, i), item, violations)
+			}
+		}
+
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add("type", s.Type)
+			return
+		}
+		length := utf8.RuneCountInString(str)
+		if s.MinLength != nil && length < *s.MinLength {
+			add("min", strconv.Itoa(*s.MinLength))
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			add("max", strconv.Itoa(*s.MaxLength))
+		}
+		if len(s.Enum) > 0 && !containsString(s.Enum, str) {
+			add("oneof", strings.Join(s.Enum, " "))
+		}
+		switch s.Format {
+		case "uuid":
+			if !uuidPattern.MatchString(str) {
+				add("uuid", "")
+			}
+		case "uri":
+			if !isURL(str) {
+				add("url", "")
+			}
+		case "color":
+			if !colorPattern.MatchString(str) {
+				add("pattern", "#rrggbb")
+			}
+		}
+		if s.Pattern != "" && !compilePattern(s.Pattern).MatchString(str) {
+			add("pattern", s.Pattern)
+		}
+
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			add("type", s.Type)
+			return
+		}
+		if s.Type == "integer" {
+			if _, err := number.Int64(); err != nil {
+				add("type", s.Type)
+				return
+			}
+		}
+		f, err := number.Float64()
+		if err != nil {
+			add("type", s.Type)
+			return
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			add("min_num", formatNumber(*s.Minimum))
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			add("max_num", formatNumber(*s.Maximum))
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			add("type", s.Type)
+		}
+	}
+}
+
+func (s *Schema) isRequired(name string) bool {
+	return containsString(s.Required, name)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// isURL nhận link tuyệt đối http(s) hoặc đường dẫn nội bộ bắt đầu bằng /
+func isURL(s string) bool {
+	if strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") {
+		return true
+	}
+	return (strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")) && !strings.ContainsAny(s, " \t\n")
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	patternCacheMu.Lock()
+	defer patternCacheMu.Unlock()
+	re, ok := patternCache[pattern]
+	if !ok {
+		re = regexp.MustCompile(pattern)
+		patternCache[pattern] = re
+	}
+	return re
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
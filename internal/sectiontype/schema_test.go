@@ -0,0 +1,106 @@
+package sectiontype
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"title": {Type: "string", MinLength: intPtr(2), MaxLength: intPtr(5)},
+			"kind":  {Type: "string", Enum: []string{"a", "b"}},
+			"id":    {Type: "string", Format: "uuid"},
+			"link":  {Type: "string", Format: "uri"},
+			"color": {Type: "string", Format: "color"},
+			"slug":  {Type: "string", Pattern: `^[a-z]+$`},
+			"limit": {Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(10)},
+			"ratio": {Type: "number"},
+			"shown": {Type: "boolean"},
+			"items": {Type: "array", MinItems: intPtr(1), MaxItems: intPtr(2), Items: &Schema{Type: "string"}},
+		},
+		Required:             []string{"title"},
+		AdditionalProperties: boolPtr(false),
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want []Violation
+	}{
+		{"hợp lệ", `{"title":"abc","kind":"a","id":"123e4567-e89b-12d3-a456-426614174000","link":"/gioi-thieu","color":"#fff","slug":"abc","limit":3,"ratio":1.5,"shown":true,"items":["x"]}`, nil},
+		{"trống", ``, []Violation{{Field: "metadata", Rule: "required"}}},
+		{"null", `null`, []Violation{{Field: "metadata", Rule: "required"}}},
+		{"sai cú pháp", `{"title":`, []Violation{{Field: "metadata", Rule: "type", Param: "object"}}},
+		{"không phải object", `[]`, []Violation{{Field: "metadata", Rule: "type", Param: "object"}}},
+		{"thiếu trường bắt buộc", `{}`, []Violation{{Field: "metadata.title", Rule: "required"}}},
+		{"chuỗi rỗng ở trường bắt buộc", `{"title":""}`, []Violation{{Field: "metadata.title", Rule: "required"}}},
+		{"trường lạ", `{"title":"abc","extra":1}`, []Violation{{Field: "metadata.extra", Rule: "unknown"}}},
+		{"chuỗi quá ngắn", `{"title":"a"}`, []Violation{{Field: "metadata.title", Rule: "min", Param: "2"}}},
+		{"chuỗi quá dài (đếm theo ký tự)", `{"title":"chúcmừng"}`, []Violation{{Field: "metadata.title", Rule: "max", Param: "5"}}},
+		{"ngoài enum", `{"title":"abc","kind":"c"}`, []Violation{{Field: "metadata.kind", Rule: "oneof", Param: "a b"}}},
+		{"uuid sai", `{"title":"abc","id":"123"}`, []Violation{{Field: "metadata.id", Rule: "uuid"}}},
+		{"link giao thức lạ", `{"title":"abc","link":"javascript:alert(1)"}`, []Violation{{Field: "metadata.link", Rule: "url"}}},
+		{"link protocol-relative", `{"title":"abc","link":"//evil.example"}`, []Violation{{Field: "metadata.link", Rule: "url"}}},
+		{"màu sai", `{"title":"abc","color":"red"}`, []Violation{{Field: "metadata.color", Rule: "pattern", Param: "#rrggbb"}}},
+		{"không khớp pattern", `{"title":"abc","slug":"ABC"}`, []Violation{{Field: "metadata.slug", Rule: "pattern", Param: `^[a-z]+$`}}},
+		{"số thực ở trường integer", `{"title":"abc","limit":1.5}`, []Violation{{Field: "metadata.limit", Rule: "type", Param: "integer"}}},
+		{"số dưới minimum", `{"title":"abc","limit":0}`, []Violation{{Field: "metadata.limit", Rule: "min_num", Param: "1"}}},
+		{"số trên maximum", `{"title":"abc","limit":11}`, []Violation{{Field: "metadata.limit", Rule: "max_num", Param: "10"}}},
+		{"chuỗi ở trường number", `{"title":"abc","ratio":"1"}`, []Violation{{Field: "metadata.ratio", Rule: "type", Param: "number"}}},
+		{"chuỗi ở trường boolean", `{"title":"abc","shown":"true"}`, []Violation{{Field: "metadata.shown", Rule: "type", Param: "boolean"}}},
+		{"mảng rỗng", `{"title":"abc","items":[]}`, []Violation{{Field: "metadata.items", Rule: "min_list", Param: "1"}}},
+		{"mảng quá dài", `{"title":"abc","items":["a","b","c"]}`, []Violation{{Field: "metadata.items", Rule: "max_list", Param: "2"}}},
+		{"phần tử mảng sai kiểu", `{"title":"abc","items":[1]}`, []Violation{{Field: "metadata.items[0]", Rule: "type", Param: "string"}}},
+		{"trường tùy chọn null được bỏ qua", `{"title":"abc","limit":null}`, nil},
+		{"nhiều lỗi theo thứ tự tên trường", `{"title":"abc","slug":"A","kind":"z"}`, []Violation{
+			{Field: "metadata.kind", Rule: "oneof", Param: "a b"},
+			{Field: "metadata.slug", Rule: "pattern", Param: `^[a-z]+$`},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schema.Validate("metadata", json.RawMessage(tt.raw))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinTypes(t *testing.T) {
+	tests := []struct {
+		key      string
+		metadata string
+		valid    bool
+	}{
+		{"TYPE01", `[{"title":"Tư vấn","des":"Mô tả","icon":"ph:monitor","color":"#1a2b3c"}]`, true},
+		{"TYPE01", `[{"title":"Tư vấn","des":"Mô tả","icon":"Monitor","color":"#1a2b3c"}]`, false},
+		{"TYPE01", `[]`, false},
+		{"type02", `[{"title":"Đất đai","des":"Mô tả","icon":"ph:house","color":"#abc"}]`, true},
+		{"TYPE03", `{"category_slug":"luat-dat-dai","limit":6}`, true},
+		{"TYPE03", `{"limit":6}`, false},
+		{"TYPE03", `{"category_slug":"luat-dat-dai","limit":21}`, false},
+		{"TYPE04", `{}`, true},
+		{"TYPE05", `{"limit":50}`, true},
+		{"TYPE05", `{"limit":51}`, false},
+		{"TYPE06", `{"article_ids":["123e4567-e89b-12d3-a456-426614174000"]}`, true},
+		{"TYPE06", `{"article_ids":["not-a-uuid"]}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+" "+tt.metadata, func(t *testing.T) {
+			sectionType, ok := Get(tt.key)
+			if !ok {
+				t.Fatalf("Get(%q) không tìm thấy loại section", tt.key)
+			}
+			violations := sectionType.Validate(json.RawMessage(tt.metadata))
+			if valid := len(violations) == 0; valid != tt.valid {
+				t.Errorf("Validate() = %v, valid = %v, want %v", violations, valid, tt.valid)
+			}
+		})
+	}
+}
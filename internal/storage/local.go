@@ -0,0 +1,417 @@
+package storage
+
+import (
+	"backend/internal/consts"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MediaRoutePrefix - Route server phục vụ file của driver local
+const MediaRoutePrefix = "/media/"
+
+// Thư mục chứa metadata (content type, etag) cạnh file, bỏ qua khi liệt kê
+const localMetaDir = ".meta"
+
+// Tiền tố file tạm khi đang ghi, đổi tên khi ghi xong để không đọc phải file dở
+const localTempPrefix = ".upload-"
+
+// Giới hạn mặc định một lần upload qua route /media (STORAGE_LOCAL_MAX_UPLOAD_BYTES)
+const defaultLocalMaxUploadBytes = 100 << 20
+
+var (
+	// ErrInvalidSignature - URL/form ký sai hoặc đã hết hạn
+	ErrInvalidSignature = errors.New("chữ ký không hợp lệ hoặc đã hết hạn")
+	// ErrTooLarge - File vượt giới hạn upload của driver
+	ErrTooLarge = errors.New("file vượt quá dung lượng cho phép")
+)
+
+// Local - Driver lưu file trong thư mục local; server tự nhận upload (POST có chữ ký) và phục vụ file qua /media
+type Local struct {
+	Root           string
+	BaseURL        string // Địa chỉ server, link file có dạng {BaseURL}/media/{key}
+	Secret         []byte // Khóa ký URL upload/xem file
+	MaxUploadBytes int64
+}
+
+type localMeta struct {
+	ContentType  string `json:"content_type"`
+	CacheControl string `json:"cache_control,omitempty"`
+	ETag         string `json:"etag"`
+}
+
+// NewLocalFromEnv tạo driver local: STORAGE_LOCAL_DIR (mặc định storage), STORAGE_LOCAL_BASE_URL,
+// STORAGE_LOCAL_SECRET, STORAGE_LOCAL_MAX_UPLOAD_BYTES
+func NewLocalFromEnv() *Local {
+	root := os.Getenv("STORAGE_LOCAL_DIR")
+	if root == "" {
+		root = "storage"
+	}
+	baseURL := strings.TrimRight(os.Getenv("STORAGE_LOCAL_BASE_URL"), "/")
+	if baseURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		baseURL = "http://localhost:" + port
+	}
+	secret := os.Getenv("STORAGE_LOCAL_SECRET")
+	if secret == "" {
+		secret = consts.JWT_SECRET_KEY
+	}
+	maxUpload, err := strconv.ParseInt(os.Getenv("STORAGE_LOCAL_MAX_UPLOAD_BYTES"), 10, 64)
+	if err != nil || maxUpload <= 0 {
+		maxUpload = defaultLocalMaxUploadBytes
+	}
+	return &Local{Root: root, BaseURL: baseURL, Secret: []byte(secret), MaxUploadBytes: maxUpload}
+}
+
+func (l *Local) Driver() string   { return "local" }
+func (l *Local) Location() string { return l.Root }
+
+// filePath đổi key sang đường dẫn file, chặn key thoát khỏi thư mục gốc
+func (l *Local) filePath(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(key, localMetaDir+"/") || strings.Contains(key, "/"+localTempPrefix) || strings.HasPrefix(key, localTempPrefix) {
+		return "", fmt.Errorf("key không hợp lệ: %q", key)
+	}
+	return filepath.Join(l.Root, filepath.FromSlash(key)), nil
+}
+
+func (l *Local) metaPath(key string) string {
+	return filepath.Join(l.Root, localMetaDir, filepath.FromSlash(key)+".json")
+}
+
+func (l *Local) readMeta(key string) localMeta {
+	var meta localMeta
+	if data, err := os.ReadFile(l.metaPath(key)); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = "application/octet-stream"
+	}
+	return meta
+}
+
+// sign ký method + key + thời điểm hết hạn bằng HMAC-SHA256
+func (l *Local) sign(method, key string, expires int64) string {
+	mac := hmac.New(sha256.New, l.Secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) signedURL(method, key string, expires time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	exp := time.Now().Add(expires).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(exp, 10))
+	query.Set("signature", l.sign(method, key, exp))
+	return l.PublicURL(key) + "?" + query.Encode(), nil
+}
+
+// VerifySignature kiểm tra URL do PresignGet tạo còn hạn và đúng chữ ký
+func (l *Local) VerifySignature(method, key string, query url.Values, now time.Time) error {
+	exp, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || now.Unix() > exp {
+		return ErrInvalidSignature
+	}
+	expected := l.sign(method, key, exp)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signPost ký toàn bộ điều kiện của form upload để client không sửa được content type/kích thước
+func (l *Local) signPost(key, contentType string, minSize, maxSize, expires int64) string {
+	mac := hmac.New(sha256.New, l.Secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d\n%d\n%d", http.MethodPost, key, contentType, minSize, maxSize, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PresignPost tạo form upload tới POST {BaseURL}/media/{key}, giới hạn bởi MaxUploadBytes
+func (l *Local) PresignPost(ctx context.Context, policy UploadPolicy) (string, map[string]string, error) {
+	key, err := l.KeyFromURL(policy.Key)
+	if err != nil {
+		return "", nil, err
+	}
+	if policy.MaxSize > l.MaxUploadBytes {
+		return "", nil, fmt.Errorf("%w: tối đa %d bytes", ErrTooLarge, l.MaxUploadBytes)
+	}
+	exp := time.Now().Add(policy.Expires).Unix()
+	fields := map[string]string{
+		"key":          key,
+		"content_type": policy.ContentType,
+		"min_size":     strconv.FormatInt(policy.MinSize, 10),
+		"max_size":     strconv.FormatInt(policy.MaxSize, 10),
+		"expires":      strconv.FormatInt(exp, 10),
+		"signature":    l.signPost(key, policy.ContentType, policy.MinSize, policy.MaxSize, exp),
+	}
+	return l.PublicURL(key), fields, nil
+}
+
+// VerifyPost kiểm tra các field của form upload do PresignPost tạo, trả về điều kiện cần áp dụng cho file
+func (l *Local) VerifyPost(key string, fields map[string]string, now time.Time) (UploadPolicy, error) {
+	exp, err := strconv.ParseInt(fields["expires"], 10, 64)
+	if err != nil || now.Unix() > exp {
+		return UploadPolicy{}, ErrInvalidSignature
+	}
+	minSize, errMin := strconv.ParseInt(fields["min_size"], 10, 64)
+	maxSize, errMax := strconv.ParseInt(fields["max_size"], 10, 64)
+	if errMin != nil || errMax != nil || fields["key"] != key {
+		return UploadPolicy{}, ErrInvalidSignature
+	}
+	expected := l.signPost(key, fields["content_type"], minSize, maxSize, exp)
+	if !hmac.Equal([]byte(expected), []byte(fields["signature"])) {
+		return UploadPolicy{}, ErrInvalidSignature
+	}
+	return UploadPolicy{Key: key, ContentType: fields["content_type"], MinSize: minSize, MaxSize: maxSize}, nil
+}
+
+func (l *Local) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return l.signedURL(http.MethodGet, key, expires)
+}
+
+func (l *Local) PublicURL(key string) string {
+	return l.BaseURL + MediaRoutePrefix + strings.TrimPrefix(key, "/")
+}
+
+// KeyFromURL nhận key hoặc link {BaseURL}/media/{key}
+func (l *Local) KeyFromURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	key := raw
+	if strings.HasPrefix(raw, "http") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(u.Path, MediaRoutePrefix) {
+			return "", fmt.Errorf("link không phải file của server: %q", raw)
+		}
+		key = strings.TrimPrefix(u.Path, MediaRoutePrefix)
+	}
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	if _, err := l.filePath(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (l *Local) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	path, err := l.filePath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ObjectInfo{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return ObjectInfo{}, err
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	meta := l.readMeta(key)
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  meta.ContentType,
+		ETag:         meta.ETag,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// limitedFile - Đọc một đoạn của file và đóng file khi xong
+type limitedFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (f limitedFile) Close() error { return f.file.Close() }
+
+func (l *Local) Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	path, err := l.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, err
+	}
+	if offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if length <= 0 {
+		return file, nil
+	}
+	return limitedFile{Reader: io.LimitReader(file, length), file: file}, nil
+}
+
+// Put ghi file qua file tạm rồi đổi tên, lưu content type và etag (MD5 như S3 với upload một phần)
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (ObjectInfo, error) {
+	path, err := l.filePath(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), localTempPrefix+"*")
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if size >= 0 && written != size {
+		return ObjectInfo{}, fmt.Errorf("dữ liệu nhận được %d bytes, cần %d bytes", written, size)
+	}
+
+	meta := localMeta{
+		ContentType:  opts.ContentType,
+		CacheControl: opts.CacheControl,
+		ETag:         hex.EncodeToString(hash.Sum(nil)),
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = "application/octet-stream"
+	}
+	metaData, _ := json.Marshal(meta)
+	if err := os.MkdirAll(filepath.Dir(l.metaPath(key)), 0o755); err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.WriteFile(l.metaPath(key), metaData, 0o644); err != nil {
+		return ObjectInfo{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{Key: key, Size: written, ContentType: meta.ContentType, ETag: meta.ETag, LastModified: time.Now()}, nil
+}
+
+func (l *Local) Remove(ctx context.Context, key string) error {
+	path, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(l.metaPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) List(ctx context.Context, fn func(ObjectInfo) error) error {
+	err := filepath.WalkDir(l.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != l.Root && d.Name() == localMetaDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), localTempPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(l.Root, path)
+		if err != nil {
+			return err
+		}
+		info, err := l.Stat(ctx, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		return fn(info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Serve trả file cho route GET/HEAD /media (hỗ trợ Range, If-None-Match).
+// signed = route đã kiểm tra chữ ký của link; không có chữ ký thì key (đã chuẩn hóa) trong khu không công khai
+// được coi như không tồn tại
+func (l *Local) Serve(w http.ResponseWriter, r *http.Request, key string, signed bool) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	if !signed && IsProtectedKey(key) {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	path, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+
+	meta := l.readMeta(key)
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if meta.ETag != "" {
+		w.Header().Set("ETag", `"`+meta.ETag+`"`)
+	}
+	if meta.CacheControl != "" {
+		w.Header().Set("Cache-Control", meta.CacheControl)
+	}
+	http.ServeContent(w, r, "", info.ModTime(), file)
+	return nil
+}
@@ -0,0 +1,391 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/sirupsen/logrus"
+)
+
+// S3Config - Cấu hình kết nối S3/MinIO
+type S3Config struct {
+	AccessKey  string
+	SecretKey  string
+	Endpoint   string
+	BucketName string
+	IsSSL      bool
+	Region     string
+	// ManagePolicy: tự thêm lệnh chặn đọc ẩn danh pending/*, private/* và quarantine/* vào bucket policy
+	// (tắt bằng S3_MANAGE_BUCKET_POLICY=false khi provider không hỗ trợ bucket policy và đã chặn ở chỗ khác)
+	ManagePolicy bool
+}
+
+// S3ConfigFromEnv lấy cấu hình S3 từ environment variables
+func S3ConfigFromEnv() S3Config {
+	config := S3Config{
+		AccessKey:  strings.TrimSpace(os.Getenv("S3_ACCESS_KEY")),
+		SecretKey:  strings.TrimSpace(os.Getenv("S3_SECRET_KEY")),
+		Endpoint:   strings.TrimSpace(os.Getenv("S3_ENDPOINT")),
+		BucketName: strings.TrimSpace(os.Getenv("S3_BUCKET")),
+		IsSSL:      os.Getenv("S3_SSL") == "true",
+		Region:     strings.TrimSpace(os.Getenv("S3_REGION")),
+
+		ManagePolicy: os.Getenv("S3_MANAGE_BUCKET_POLICY") != "false",
+	}
+
+	// Set default region if not provided
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return config
+}
+
+// S3 - Driver S3/MinIO, giữ một client dùng chung cho cả process
+type S3 struct {
+	config S3Config
+	client *minio.Client
+
+	bucketMu    sync.Mutex
+	bucketReady bool
+}
+
+// NewS3FromEnv tạo driver S3 từ environment variables
+func NewS3FromEnv() (*S3, error) {
+	return NewS3(S3ConfigFromEnv())
+}
+
+// NewS3 tạo driver S3 (chưa kết nối mạng; bucket được kiểm tra ở lần upload đầu tiên)
+func NewS3(config S3Config) (*S3, error) {
+	if config.AccessKey == "" || config.SecretKey == "" || config.Endpoint == "" {
+		return nil, fmt.Errorf("missing required S3 configuration: access_key, secret_key, or endpoint")
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure: config.IsSSL,
+		Region: config.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+
+	return &S3{config: config, client: client}, nil
+}
+
+func (s *S3) Driver() string   { return "s3" }
+func (s *S3) Location() string { return s.config.BucketName }
+
+// Client trả về minio client dùng chung (cho thao tác riêng của S3 như multipart upload)
+func (s *S3) Client() *minio.Client { return s.client }
+
+// ensureBucket kiểm tra và tạo bucket nếu chưa có, chỉ một lần mỗi process (lỗi thì thử lại lần sau)
+func (s *S3) ensureBucket(ctx context.Context) error {
+	s.bucketMu.Lock()
+	defer s.bucketMu.Unlock()
+	if s.bucketReady {
+		return nil
+	}
+
+	exists, err := s.client.BucketExists(ctx, s.config.BucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+	if !exists {
+		err = s.client.MakeBucket(ctx, s.config.BucketName, minio.MakeBucketOptions{
+			Region: "us-east-1", // Đảm bảo region consistency
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create bucket: %w", err)
+		}
+		logrus.Info("Created bucket: ", s.config.BucketName)
+	}
+	if s.config.ManagePolicy {
+		if err := s.ensureProtectedPolicy(ctx); err != nil {
+			return fmt.Errorf("failed to enforce bucket policy: %w", err)
+		}
+	}
+	s.bucketReady = true
+	return nil
+}
+
+// protectedPolicySid - Sid của lệnh chặn đọc ẩn danh các khu không công khai trong bucket policy
+const protectedPolicySid = "DenyAnonymousReadProtected"
+
+// ensureProtectedPolicy thêm (hoặc cập nhật) lệnh Deny s3:GetObject với request ẩn danh trên các khu không công khai.
+// Các lệnh khác trong policy (vd cho phép đọc công khai cả bucket) được giữ nguyên; link ký của backend không bị ảnh hưởng
+func (s *S3) ensureProtectedPolicy(ctx context.Context) error {
+	current, err := s.client.GetBucketPolicy(ctx, s.config.BucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucketPolicy" {
+		return err
+	}
+
+	policy := map[string]interface{}{"Version": "2012-10-17"}
+	if strings.TrimSpace(current) != "" {
+		if err := json.Unmarshal([]byte(current), &policy); err != nil {
+			return fmt.Errorf("bucket policy hiện tại không đọc được: %w", err)
+		}
+	}
+	var statements []interface{}
+	if existing, ok := policy["Statement"].([]interface{}); ok {
+		for _, statement := range existing {
+			if item, ok := statement.(map[string]interface{}); ok && item["Sid"] == protectedPolicySid {
+				continue
+			}
+			statements = append(statements, statement)
+		}
+	}
+
+	bucketARN := "arn:aws:s3:::" + s.config.BucketName
+	resources := make([]string, 0, len(protectedPrefixes))
+	for _, prefix := range protectedPrefixes {
+		resources = append(resources, bucketARN+"/"+prefix+"*")
+	}
+	statements = append(statements, map[string]interface{}{
+		"Sid":       protectedPolicySid,
+		"Effect":    "Deny",
+		"Principal": map[string]interface{}{"AWS": []string{"*"}},
+		"Action":    []string{"s3:GetObject"},
+		"Resource":  resources,
+		"Condition": map[string]interface{}{
+			"StringEquals": map[string]interface{}{"aws:PrincipalType": "Anonymous"},
+		},
+	})
+	policy["Statement"] = statements
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	if string(data) == current {
+		return nil
+	}
+	return s.client.SetBucketPolicy(ctx, s.config.BucketName, string(data))
+}
+
+func (s *S3) PresignPost(ctx context.Context, policy UploadPolicy) (string, map[string]string, error) {
+	if err := s.ensureBucket(ctx); err != nil {
+		return "", nil, err
+	}
+	p := minio.NewPostPolicy()
+	if err := p.SetBucket(s.config.BucketName); err != nil {
+		return "", nil, err
+	}
+	if err := p.SetKey(policy.Key); err != nil {
+		return "", nil, err
+	}
+	if err := p.SetExpires(time.Now().UTC().Add(policy.Expires)); err != nil {
+		return "", nil, err
+	}
+	if err := p.SetContentType(policy.ContentType); err != nil {
+		return "", nil, err
+	}
+	if err := p.SetContentLengthRange(policy.MinSize, policy.MaxSize); err != nil {
+		return "", nil, err
+	}
+	u, fields, err := s.client.PresignedPostPolicy(ctx, p)
+	if err != nil {
+		return "", nil, err
+	}
+	return u.String(), fields, nil
+}
+
+func (s *S3) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.config.BucketName, key, expires, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// PublicURL - Direct URL (chỉ xem được khi bucket public)
+func (s *S3) PublicURL(key string) string {
+	return fmt.Sprintf("https://%s/%s/%s", s.config.Endpoint, s.config.BucketName, strings.TrimPrefix(key, "/"))
+}
+
+// KeyFromURL nhận object key hoặc URL dạng https://endpoint/bucket/key (kể cả presigned URL)
+func (s *S3) KeyFromURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	key := raw
+	if strings.HasPrefix(raw, "http") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", err
+		}
+		parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)
+		if len(parts) < 2 {
+			return "", fmt.Errorf("invalid S3 URL format")
+		}
+		if parts[0] != s.config.BucketName {
+			return "", fmt.Errorf("file không thuộc bucket %s", s.config.BucketName)
+		}
+		key = parts[1]
+	}
+	return cleanKey(key)
+}
+
+func (s *S3) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.config.BucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, s.mapError(err)
+	}
+	return s3ObjectInfo(info), nil
+}
+
+func (s *S3) Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	opts := minio.GetObjectOptions{}
+	if offset > 0 || length > 0 {
+		end := int64(0)
+		if length > 0 {
+			end = offset + length - 1
+		}
+		if err := opts.SetRange(offset, end); err != nil {
+			return nil, err
+		}
+	}
+	object, err := s.client.GetObject(ctx, s.config.BucketName, key, opts)
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+	return object, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (ObjectInfo, error) {
+	if err := s.ensureBucket(ctx); err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := s.client.PutObject(ctx, s.config.BucketName, key, r, size, minio.PutObjectOptions{
+		ContentType:  opts.ContentType,
+		CacheControl: opts.CacheControl,
+	})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Key: key, Size: info.Size, ContentType: opts.ContentType, ETag: info.ETag, LastModified: time.Now()}, nil
+}
+
+func (s *S3) Remove(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.config.BucketName, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3) List(ctx context.Context, fn func(ObjectInfo) error) error {
+	for object := range s.client.ListObjects(ctx, s.config.BucketName, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		if err := fn(s3ObjectInfo(object)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upload nhiều phần qua minio.Core. Nên đặt thêm lifecycle rule AbortIncompleteMultipartUpload cho bucket
+// để storage tự dọn phần còn sót nếu backend không kịp hủy
+
+func (s *S3) NewMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	if err := s.ensureBucket(ctx); err != nil {
+		return "", err
+	}
+	core := minio.Core{Client: s.client}
+	return core.NewMultipartUpload(ctx, s.config.BucketName, key, minio.PutObjectOptions{ContentType: contentType})
+}
+
+func (s *S3) PresignPart(ctx context.Context, key, uploadID string, partNumber int, expires time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("partNumber", strconv.Itoa(partNumber))
+	params.Set("uploadId", uploadID)
+	u, err := s.client.Presign(ctx, http.MethodPut, s.config.BucketName, key, expires, params)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (s *S3) ListParts(ctx context.Context, key, uploadID string) ([]UploadedPart, error) {
+	core := minio.Core{Client: s.client}
+	parts := []UploadedPart{}
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, s.config.BucketName, key, uploadID, marker, 1000)
+		if err != nil {
+			return nil, s.mapError(err)
+		}
+		for _, part := range result.ObjectParts {
+			parts = append(parts, UploadedPart{PartNumber: part.PartNumber, ETag: part.ETag, Size: part.Size})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+func (s *S3) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []UploadedPart) (ObjectInfo, error) {
+	core := minio.Core{Client: s.client}
+	complete := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		complete = append(complete, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	if _, err := core.CompleteMultipartUpload(ctx, s.config.BucketName, key, uploadID, complete, minio.PutObjectOptions{}); err != nil {
+		return ObjectInfo{}, s.mapError(err)
+	}
+	return s.Stat(ctx, key)
+}
+
+func (s *S3) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	core := minio.Core{Client: s.client}
+	err := core.AbortMultipartUpload(ctx, s.config.BucketName, key, uploadID)
+	if err != nil && !errors.Is(s.mapError(err), ErrMultipartNotFound) {
+		return err
+	}
+	return nil
+}
+
+// mapError đổi lỗi NoSuchKey/NoSuchUpload của S3 sang ErrNotFound/ErrMultipartNotFound
+func (s *S3) mapError(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case minio.NoSuchKey:
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	case minio.NoSuchUpload:
+		return fmt.Errorf("%w: %v", ErrMultipartNotFound, err)
+	}
+	return err
+}
+
+func s3ObjectInfo(info minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
+
+// cleanKey chuẩn hóa key và chặn key thoát khỏi thư mục gốc. Đoạn rỗng và đoạn "." cũng bị từ chối
+// để mỗi file chỉ có một key (vd "./private/x" không lách được kiểm tra khu không công khai)
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(strings.TrimSpace(key), "/")
+	if key == "" || strings.Contains(key, "..") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("key không hợp lệ: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." {
+			return "", fmt.Errorf("key không hợp lệ: %q", key)
+		}
+	}
+	return key, nil
+}
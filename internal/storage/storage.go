@@ -0,0 +1,154 @@
+// Package storage - Nơi lưu file upload: S3/MinIO hoặc thư mục local (chạy offline, môi trường dev)
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound - Object không tồn tại
+var ErrNotFound = errors.New("object không tồn tại")
+
+// ErrMultipartNotFound - Phiên upload nhiều phần không còn trên storage (đã hoàn tất, bị hủy hoặc bị dọn)
+var ErrMultipartNotFound = errors.New("phiên upload nhiều phần không tồn tại")
+
+// Các khu không công khai. Route /media chỉ phục vụ các key này qua link có chữ ký;
+// với S3, bucket policy chặn đọc ẩn danh các khu này (xem S3.ensureProtectedPolicy)
+const (
+	PendingPrefix    = "pending/"    // File vừa upload, chờ kiểm tra; sạch mới được chuyển tới key công khai
+	QuarantinePrefix = "quarantine/" // File bị từ chối khi kiểm tra
+	PrivatePrefix    = "private/"    // File riêng tư, chỉ tải qua link ký ngắn hạn do backend cấp
+)
+
+// protectedPrefixes - Mọi khu không công khai
+var protectedPrefixes = []string{PendingPrefix, QuarantinePrefix, PrivatePrefix}
+
+// IsProtectedKey kiểm tra key nằm trong khu không công khai
+func IsProtectedKey(key string) bool {
+	key = strings.TrimPrefix(key, "/")
+	for _, prefix := range protectedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ObjectInfo - Thông tin một object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// PutOptions - Tùy chọn khi server tự ghi object (vd variant ảnh)
+type PutOptions struct {
+	ContentType  string
+	CacheControl string
+}
+
+// UploadPolicy - Điều kiện của một lần upload trực tiếp từ trình duyệt (presigned POST)
+type UploadPolicy struct {
+	Key         string
+	ContentType string // Content type bắt buộc của file
+	MinSize     int64  // Kích thước tối thiểu (bytes)
+	MaxSize     int64  // Kích thước tối đa (bytes)
+	Expires     time.Duration
+}
+
+// Storage - Driver lưu trữ file. Key có dạng đường dẫn tương đối, vd: {uuid}/images/{yyyymm}/{uuid}.jpg
+type Storage interface {
+	// Driver trả về tên driver (s3, local)
+	Driver() string
+	// Location trả về bucket (S3) hoặc thư mục gốc (local)
+	Location() string
+	// PresignPost tạo form upload trực tiếp: client gửi POST multipart/form-data tới URL gồm các field trả về,
+	// field "file" đặt cuối cùng. Storage từ chối file sai content type hoặc ngoài khoảng kích thước
+	PresignPost(ctx context.Context, policy UploadPolicy) (string, map[string]string, error)
+	// PresignGet tạo URL xem file có thời hạn
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+	// PublicURL trả về link trực tiếp tới file (bucket public hoặc route /media)
+	PublicURL(key string) string
+	// KeyFromURL nhận key hoặc link do PublicURL/PresignGet tạo, trả về key
+	KeyFromURL(raw string) (string, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Open đọc object; length <= 0 là đọc tới hết file
+	Open(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Put(ctx context.Context, key string, r io.Reader, size int64, opts PutOptions) (ObjectInfo, error)
+	// Remove xóa object, không lỗi nếu object không tồn tại
+	Remove(ctx context.Context, key string) error
+	// List duyệt toàn bộ object
+	List(ctx context.Context, fn func(ObjectInfo) error) error
+}
+
+// UploadedPart - Một phần đã upload của phiên upload nhiều phần
+type UploadedPart struct {
+	PartNumber int    `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// MultipartStorage - Driver hỗ trợ upload nhiều phần trực tiếp từ trình duyệt (file lớn, mạng chập chờn).
+// Driver không hỗ trợ thì client dùng PresignPost
+type MultipartStorage interface {
+	// NewMultipartUpload mở phiên upload, trả về upload ID của storage
+	NewMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+	// PresignPart tạo URL để client PUT một phần (partNumber từ 1)
+	PresignPart(ctx context.Context, key, uploadID string, partNumber int, expires time.Duration) (string, error)
+	// ListParts lấy các phần đã upload, theo thứ tự part number
+	ListParts(ctx context.Context, key, uploadID string) ([]UploadedPart, error)
+	// CompleteMultipartUpload ghép các phần thành object
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []UploadedPart) (ObjectInfo, error)
+	// AbortMultipartUpload hủy phiên và xóa các phần đã upload, không lỗi nếu phiên không còn
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+var (
+	currentMu sync.RWMutex
+	current   Storage
+)
+
+// newDefault tạo driver theo STORAGE_DRIVER (mặc định s3, hoặc local)
+func newDefault() (Storage, error) {
+	switch os.Getenv("STORAGE_DRIVER") {
+	case "local":
+		return NewLocalFromEnv(), nil
+	default:
+		return NewS3FromEnv()
+	}
+}
+
+// SetStorage thay thế driver lưu trữ mặc định
+func SetStorage(s Storage) {
+	currentMu.Lock()
+	current = s
+	currentMu.Unlock()
+}
+
+// Get trả về driver đang dùng; driver được tạo một lần và dùng lại cho mọi request
+func Get() (Storage, error) {
+	currentMu.RLock()
+	s := current
+	currentMu.RUnlock()
+	if s != nil {
+		return s, nil
+	}
+
+	currentMu.Lock()
+	defer currentMu.Unlock()
+	if current == nil {
+		created, err := newDefault()
+		if err != nil {
+			return nil, err
+		}
+		current = created
+	}
+	return current, nil
+}
@@ -19,6 +19,19 @@ func SetupAdminRoutes(router *gin.Engine) {
 	tagHandler := handle.NewTagHandler()
 	s3Handler := handle.NewS3Handler()
 	homepageSectionHandler := handle.NewHomepageSectionHandler()
+	seriesHandler := handle.NewSeriesHandler()
+	consultationHandler := handle.NewConsultationHandler(userRepo)
+	authorHandler := handle.NewAuthorHandler(userRepo)
+	articleAuthorHandler := handle.NewArticleAuthorHandler(userRepo)
+	commentHandler := handle.NewCommentHandler()
+	feedbackHandler := handle.NewFeedbackHandler()
+	viewAnalyticsHandler := handle.NewViewAnalyticsHandler()
+	dashboardHandler := handle.NewDashboardHandler()
+	legalReviewHandler := handle.NewLegalReviewHandler()
+	legalDocumentHandler := handle.NewLegalDocumentHandler()
+	faqHandler := handle.NewFAQHandler()
+	newsletterHandler := handle.NewNewsletterHandler()
+	mediaHandler := handle.NewMediaHandler()
 
 	// Base admin group - yêu cầu authentication
 	admin := router.Group("/api/admin")
@@ -39,6 +52,21 @@ func SetupAdminRoutes(router *gin.Engine) {
 		superAdminRoutes.PUT("/user/:id/status", adminHandler.ToggleUserStatus)
 		superAdminRoutes.DELETE("/user/:id", adminHandler.DeleteUser)
 		superAdminRoutes.GET("/stats/users", adminHandler.GetUserStats)
+
+		// Chuyển tác giả chính của bài viết
+		superAdminRoutes.PUT("/article/:id/author", articleAuthorHandler.ReassignArticleAuthor)
+
+		// Chạy ngay job nhắc rà soát pháp lý
+		superAdminRoutes.POST("/legal-review/run", legalReviewHandler.RunLegalReviewCheck)
+
+		// Gửi bản tin tổng hợp cho người đăng ký
+		superAdminRoutes.POST("/newsletter/digest/send", newsletterHandler.SendDigest)
+
+		// Dọn file upload không còn được dùng (mặc định dry_run=true chỉ trả báo cáo) và dựng lại chỉ mục sử dụng file
+		superAdminRoutes.POST("/media/gc", mediaHandler.RunMediaGC)
+		superAdminRoutes.POST("/media/usages/rebuild", mediaHandler.RebuildMediaUsages)
+		// Dung lượng storage thực tế và theo từng người upload
+		superAdminRoutes.GET("/storage/usage", s3Handler.GetS3BucketMemoryUsage)
 	}
 
 	// Routes dành cho cả Super Admin và Admin
@@ -60,6 +88,93 @@ func SetupAdminRoutes(router *gin.Engine) {
 		managerRoutes.POST("/article", articleHandler.CreateArticle)
 		managerRoutes.PUT("/article/:id", articleHandler.UpdateArticle)
 		managerRoutes.DELETE("/article/:id", articleHandler.DeleteArticle)
+		managerRoutes.GET("/article/:id/related", articleHandler.GetPinnedRelatedArticles)
+		managerRoutes.PUT("/article/:id/related", articleHandler.SetPinnedRelatedArticles)
+		managerRoutes.PUT("/article/:id/attribution", articleAuthorHandler.SetArticleAttribution)
+
+		// Rà soát pháp lý: hàng đợi bài cần rà soát và ghi nhận đã rà soát
+		managerRoutes.GET("/articles/needs-review", legalReviewHandler.GetNeedsReviewArticles)
+		managerRoutes.POST("/article/:id/legal-review", legalReviewHandler.MarkArticleReviewed)
+
+		// Quản lý chuỗi bài viết (Series)
+		managerRoutes.GET("/series", seriesHandler.GetSeriesList)
+		managerRoutes.GET("/series/:id", seriesHandler.GetSeriesByID)
+		managerRoutes.POST("/series", seriesHandler.CreateSeries)
+		managerRoutes.PUT("/series/:id", seriesHandler.UpdateSeries)
+		managerRoutes.PUT("/series/:id/articles", seriesHandler.SetSeriesArticles)
+		managerRoutes.DELETE("/series/:id", seriesHandler.DeleteSeries)
+
+		// Thư viện văn bản pháp luật và liên kết với bài viết trích dẫn
+		managerRoutes.GET("/legal-documents", legalDocumentHandler.GetLegalDocuments)
+		managerRoutes.GET("/legal-document/:id", legalDocumentHandler.GetLegalDocumentByID)
+		managerRoutes.POST("/legal-document", legalDocumentHandler.CreateLegalDocument)
+		managerRoutes.PUT("/legal-document/:id", legalDocumentHandler.UpdateLegalDocument)
+		managerRoutes.DELETE("/legal-document/:id", legalDocumentHandler.DeleteLegalDocument)
+		managerRoutes.GET("/article/:id/legal-documents", legalDocumentHandler.GetArticleLegalDocuments)
+		managerRoutes.PUT("/article/:id/legal-documents", legalDocumentHandler.SetArticleLegalDocuments)
+
+		// Câu hỏi thường gặp và câu hỏi nhúng trong bài viết
+		managerRoutes.GET("/faqs", faqHandler.GetFAQs)
+		managerRoutes.PUT("/faqs/reorder", faqHandler.ReorderFAQs)
+		managerRoutes.GET("/faq/:id", faqHandler.GetFAQByID)
+		managerRoutes.POST("/faq", faqHandler.CreateFAQ)
+		managerRoutes.PUT("/faq/:id", faqHandler.UpdateFAQ)
+		managerRoutes.DELETE("/faq/:id", faqHandler.DeleteFAQ)
+		managerRoutes.GET("/article/:id/faqs", faqHandler.GetArticleFAQs)
+		managerRoutes.PUT("/article/:id/faqs", faqHandler.SetArticleFAQs)
+
+		// Quản lý hồ sơ tác giả (luật sư, thành viên)
+		managerRoutes.GET("/authors", authorHandler.GetAuthorProfiles)
+		managerRoutes.GET("/author/:id", authorHandler.GetAuthorProfileByID)
+		managerRoutes.POST("/author", authorHandler.CreateAuthorProfile)
+		managerRoutes.PUT("/author/:id", authorHandler.UpdateAuthorProfile)
+		managerRoutes.DELETE("/author/:id", authorHandler.DeleteAuthorProfile)
+
+		// Kiểm duyệt bình luận
+		managerRoutes.GET("/comments", commentHandler.GetComments)
+		managerRoutes.PUT("/comment/:id/status", commentHandler.UpdateCommentStatus)
+		managerRoutes.DELETE("/comment/:id", commentHandler.DeleteComment)
+
+		// Dashboard nội dung - mọi báo cáo nhận start_date, end_date, period
+		dashboard := managerRoutes.Group("/dashboard")
+		{
+			dashboard.GET("/overview", dashboardHandler.GetFullOverview)
+			dashboard.GET("/analytics", dashboardHandler.GetAnalytics)
+			dashboard.GET("/alerts", dashboardHandler.GetAlerts)
+			dashboard.GET("/published", dashboardHandler.GetPublishedChart)
+			dashboard.GET("/views", dashboardHandler.GetViewsChart)
+			dashboard.GET("/top-articles", dashboardHandler.GetTopArticles)
+			dashboard.GET("/top-categories", dashboardHandler.GetTopCategories)
+			dashboard.GET("/top-tags", dashboardHandler.GetTopTags)
+			dashboard.GET("/authors", dashboardHandler.GetAuthorProductivity)
+			dashboard.GET("/drafts", dashboardHandler.GetDraftBacklog)
+			dashboard.GET("/scheduled", dashboardHandler.GetScheduledQueue)
+			dashboard.GET("/stale", dashboardHandler.GetStaleArticles)
+		}
+
+		// Thống kê lượt xem (từ bảng article_view_daily)
+		managerRoutes.GET("/analytics/views", viewAnalyticsHandler.GetViewOverview)
+		managerRoutes.GET("/article/:id/views", viewAnalyticsHandler.GetArticleViews)
+
+		// Thống kê đánh giá "bài viết có hữu ích không?"
+		managerRoutes.GET("/feedback/articles", feedbackHandler.GetArticleFeedbackStats)
+		managerRoutes.GET("/feedback/categories", feedbackHandler.GetCategoryFeedbackStats)
+		managerRoutes.GET("/article/:id/feedback", feedbackHandler.GetArticleFeedback)
+
+		// Người đăng ký bản tin và lịch sử gửi
+		managerRoutes.GET("/newsletter/subscribers", newsletterHandler.GetSubscribers)
+		managerRoutes.GET("/newsletter/subscribers/export", newsletterHandler.ExportSubscribers)
+		managerRoutes.DELETE("/newsletter/subscriber/:id", newsletterHandler.DeleteSubscriber)
+		managerRoutes.GET("/newsletter/digests", newsletterHandler.GetDigests)
+		managerRoutes.GET("/newsletter/digest/preview", newsletterHandler.PreviewDigest)
+
+		// Hộp thư yêu cầu tư vấn
+		managerRoutes.GET("/consultations", consultationHandler.GetConsultations)
+		managerRoutes.GET("/consultation/:id", consultationHandler.GetConsultationByID)
+		managerRoutes.PUT("/consultation/:id/status", consultationHandler.UpdateConsultationStatus)
+		managerRoutes.PUT("/consultation/:id/assign", consultationHandler.AssignConsultation)
+		managerRoutes.POST("/consultation/:id/notes", consultationHandler.AddConsultationNote)
+		managerRoutes.DELETE("/consultation/:id", consultationHandler.DeleteConsultation)
 
 		// Quản lý tags
 		managerRoutes.GET("/tags", tagHandler.GetTags)
@@ -77,9 +192,32 @@ func SetupAdminRoutes(router *gin.Engine) {
 
 		managerRoutes.POST("/upload/s3", s3Handler.GetUploadUrl)
 		managerRoutes.DELETE("/upload", s3Handler.DeleteS3Object)
+		managerRoutes.GET("/upload/quota", s3Handler.GetUploadQuota)
+
+		// Upload nhiều phần cho video/âm thanh lớn (chỉ storage S3)
+		managerRoutes.POST("/upload/multipart", s3Handler.InitiateMultipartUpload)
+		managerRoutes.GET("/upload/multipart/:id", s3Handler.GetMultipartUpload)
+		managerRoutes.POST("/upload/multipart/:id/parts", s3Handler.PresignMultipartParts)
+		managerRoutes.POST("/upload/multipart/:id/complete", s3Handler.CompleteMultipartUpload)
+		managerRoutes.DELETE("/upload/multipart/:id", s3Handler.AbortMultipartUpload)
+
+		// Thư viện media: xác nhận file sau khi upload qua presigned URL, duyệt và chọn lại file có sẵn
+		managerRoutes.POST("/media/confirm", mediaHandler.ConfirmUpload)
+		managerRoutes.GET("/media", mediaHandler.GetMediaAssets)
+		managerRoutes.GET("/media/:id", mediaHandler.GetMediaAssetByID)
+		managerRoutes.PUT("/media/:id", mediaHandler.UpdateMediaAsset)
+		managerRoutes.POST("/media/:id/variants", mediaHandler.RegenerateMediaVariants)
+		managerRoutes.POST("/media/:id/scan", mediaHandler.ScanMediaAsset)
+		managerRoutes.PUT("/media/:id/visibility", mediaHandler.UpdateMediaVisibility)
+		managerRoutes.GET("/media/:id/downloads", mediaHandler.GetMediaDownloads)
+		// Theo dõi nơi đang dùng file; xóa file đang được dùng cần force=true
+		managerRoutes.GET("/media/usages", mediaHandler.GetMediaUsagesByKey)
+		managerRoutes.GET("/media/:id/usages", mediaHandler.GetMediaAssetUsages)
+		managerRoutes.DELETE("/media/:id", mediaHandler.DeleteMediaAsset)
 
 		// Quản lý Homepage Sections
 		managerRoutes.GET("/homepage-sections", homepageSectionHandler.GetSections)
+		managerRoutes.GET("/homepage-section/types", homepageSectionHandler.GetSectionTypes)
 		managerRoutes.GET("/homepage-section/:id", homepageSectionHandler.GetSectionByID)
 		managerRoutes.GET("/homepage-section/type/:type_key", homepageSectionHandler.GetSectionByTypeKey)
 		managerRoutes.POST("/homepage-section", homepageSectionHandler.CreateSection)
@@ -1,7 +1,10 @@
 package router
 
 import (
+	"backend/app"
 	"backend/internal/handle"
+	"backend/internal/repo"
+	"backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,11 +18,58 @@ func SetupUserRoutes(router *gin.Engine) {
 	s3Handler := handle.NewS3Handler()
 	homepageSectionHandler := handle.NewHomepageSectionHandler()
 	sitemapHandler := handle.NewSitemapHandler()
+	seriesHandler := handle.NewSeriesHandler()
+	userRepo := repo.NewUserRepository(app.GetDB())
+	consultationHandler := handle.NewConsultationHandler(userRepo)
+	authorHandler := handle.NewAuthorHandler(userRepo)
+	consultationLimit, consultationWindow := handle.ConsultationRateLimit()
+	commentHandler := handle.NewCommentHandler()
+	commentLimit, commentWindow := handle.CommentRateLimit()
+	feedbackHandler := handle.NewFeedbackHandler()
+	feedbackLimit, feedbackWindow := handle.FeedbackRateLimit()
+	legalDocumentHandler := handle.NewLegalDocumentHandler()
+	faqHandler := handle.NewFAQHandler()
+	newsletterHandler := handle.NewNewsletterHandler()
+	newsletterLimit, newsletterWindow := handle.NewsletterRateLimit()
+	localMediaHandler := handle.NewLocalMediaHandler()
+	uploadTicketLimit, uploadTicketWindow := handle.UploadTicketRateLimit()
+	mediaHandler := handle.NewMediaHandler()
+	downloadLinkLimit, downloadLinkWindow := handle.DownloadLinkRateLimit()
+
+	// File của storage local (STORAGE_DRIVER=local): nhận upload qua form đã ký và phục vụ file như bucket public
+	router.GET("/media/*key", localMediaHandler.ServeMedia)
+	router.HEAD("/media/*key", localMediaHandler.ServeMedia)
+	router.POST("/media/*key", localMediaHandler.UploadMedia)
 
 	// Routes công khai - không cần xác thực
 	public := router.Group("/api")
 	{
-		public.POST("/upload/s3", s3Handler.GetUploadUrl)
+		// Upload công khai: tài khoản quản trị hoặc vé upload dùng một lần (giới hạn số vé theo IP, hạn mức dung lượng chung mỗi ngày)
+		public.POST("/upload/s3", utils.OptionalAuthMiddleware(), s3Handler.GetUploadUrl)
+		public.POST("/upload/ticket",
+			utils.RateLimitMiddleware("upload_ticket", uploadTicketLimit, uploadTicketWindow),
+			s3Handler.CreateUploadTicket,
+		)
+
+		// Tải file qua backend: kiểm tra quyền, đếm lượt tải rồi chuyển hướng tới link ký ngắn hạn
+		public.GET("/files/:id", utils.OptionalAuthMiddleware(), mediaHandler.DownloadMedia)
+
+		// Yêu cầu tư vấn - giới hạn số lần gửi theo IP để chống spam
+		public.POST("/consultations",
+			utils.RateLimitMiddleware("consultation", consultationLimit, consultationWindow),
+			consultationHandler.CreateConsultation,
+		)
+
+		// Đăng ký bản tin (double opt-in) - giới hạn số lần đăng ký theo IP
+		publicNewsletter := public.Group("/newsletter")
+		{
+			publicNewsletter.POST("/subscribe",
+				utils.RateLimitMiddleware("newsletter", newsletterLimit, newsletterWindow),
+				newsletterHandler.Subscribe,
+			)
+			publicNewsletter.POST("/confirm", newsletterHandler.ConfirmSubscription)
+			publicNewsletter.POST("/unsubscribe", newsletterHandler.Unsubscribe)
+		}
 
 		publicCategories := public.Group("/categories")
 		{
@@ -35,6 +85,46 @@ func SetupUserRoutes(router *gin.Engine) {
 			publicArticles.GET("/all", articleHandler.GetAllPublicArticles)
 			publicArticles.GET("", articleHandler.GetPublicArticles)
 			publicArticles.GET("/:slug", articleHandler.GetArticleBySlugPublic)
+			publicArticles.GET("/:slug/related", articleHandler.GetRelatedArticles)
+			publicArticles.GET("/:slug/comments", commentHandler.GetPublicComments)
+			publicArticles.POST("/:slug/comments",
+				utils.RateLimitMiddleware("comment", commentLimit, commentWindow),
+				commentHandler.CreatePublicComment,
+			)
+			publicArticles.POST("/:slug/feedback",
+				utils.RateLimitMiddleware("feedback", feedbackLimit, feedbackWindow),
+				feedbackHandler.SubmitFeedback,
+			)
+			// Tệp đính kèm: tệp chỉ dành cho người đăng ký cần link gửi qua email (giới hạn số lần xin link theo IP)
+			publicArticles.GET("/:slug/attachments/:media_id", utils.OptionalAuthMiddleware(), mediaHandler.DownloadArticleAttachment)
+			publicArticles.POST("/:slug/attachments/:media_id/request",
+				utils.RateLimitMiddleware("download_link", downloadLinkLimit, downloadLinkWindow),
+				mediaHandler.RequestAttachmentLink,
+			)
+		}
+
+		// Chuỗi bài viết công khai
+		public.GET("/series/:slug", seriesHandler.GetPublicSeriesBySlug)
+
+		// Hồ sơ tác giả công khai
+		publicAuthors := public.Group("/authors")
+		{
+			publicAuthors.GET("", authorHandler.GetPublicAuthors)
+			publicAuthors.GET("/:slug", authorHandler.GetPublicAuthorBySlug)
+		}
+
+		// Thư viện văn bản pháp luật công khai
+		publicDocuments := public.Group("/legal-documents")
+		{
+			publicDocuments.GET("", legalDocumentHandler.GetPublicLegalDocuments)
+			publicDocuments.GET("/:slug", legalDocumentHandler.GetPublicLegalDocumentBySlug)
+		}
+
+		// Câu hỏi thường gặp công khai (kèm JSON-LD FAQPage)
+		publicFAQs := public.Group("/faqs")
+		{
+			publicFAQs.GET("", faqHandler.GetPublicFAQs)
+			publicFAQs.GET("/category/:slug", faqHandler.GetPublicFAQsByCategorySlug)
 		}
 
 		// Tag công khai
@@ -57,6 +147,8 @@ func SetupUserRoutes(router *gin.Engine) {
 			sitemap.GET("/tags/urls", sitemapHandler.GetTagsURLs)
 			sitemap.GET("/categories/urls", sitemapHandler.GetCategoriesURLs)
 			sitemap.GET("/articles/urls", sitemapHandler.GetArticlesURLs)
+			sitemap.GET("/authors/urls", sitemapHandler.GetAuthorsURLs)
+			sitemap.GET("/legal-documents/urls", sitemapHandler.GetLegalDocumentsURLs)
 		}
 	}
 }
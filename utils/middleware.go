@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"backend/internal/consts"
 	"backend/internal/helpers"
 	"net/http"
 	"strings"
@@ -14,56 +13,64 @@ import (
 // Auth middleware để xác thực JWT token
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
+		if !authenticate(c) {
+			helpers.AbortWithError(c, helpers.ErrUnauthorized, nil)
 			return
 		}
+		c.Next()
+	}
+}
 
-		// Kiểm tra định dạng Bearer token
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
+// OptionalAuthMiddleware - Route cho cả khách và người đăng nhập: có token thì phải hợp lệ, không có thì bỏ qua
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" && !authenticate(c) {
+			helpers.AbortWithError(c, helpers.ErrUnauthorized, nil)
 			return
 		}
+		c.Next()
+	}
+}
 
-		token, err := helpers.ValidateJWT(tokenParts[1])
-		if err != nil || !token.Valid {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
-			return
-		}
+// authenticate kiểm tra Bearer token và set thông tin user vào context
+func authenticate(c *gin.Context) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return false
+	}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
-			return
-		}
+	// Kiểm tra định dạng Bearer token
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		return false
+	}
 
-		// Parse và set user info
-		userIDStr, ok := claims["user_id"].(string)
-		if !ok {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
-			return
-		}
+	token, err := helpers.ValidateJWT(tokenParts[1])
+	if err != nil || !token.Valid {
+		return false
+	}
 
-		userID, err := uuid.Parse(userIDStr)
-		if err != nil {
-			helpers.UnauthorizedResponse(c, consts.MSG_UNAUTHORIZED)
-			c.Abort()
-			return
-		}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
 
-		// Sử dụng key là "userID" thay vì "user_id" cho nhất quán
-		c.Set("userID", userID)
-		c.Set("username", claims["username"].(string))
-		c.Set("user_role", claims["role"].(string))
-		c.Next()
+	// Parse và set user info
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		return false
 	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return false
+	}
+
+	// Sử dụng key là "userID" thay vì "user_id" cho nhất quán
+	c.Set("userID", userID)
+	c.Set("username", claims["username"].(string))
+	c.Set("user_role", claims["role"].(string))
+	return true
 }
 
 // CORS middleware để xử lý cross-origin requests
@@ -89,12 +96,11 @@ func CORSMiddleware() gin.HandlerFunc {
 }
 
 // Generic role middleware
-func roleMiddleware(allowedRoles []string, message string) gin.HandlerFunc {
+func roleMiddleware(allowedRoles []string, apiErr *helpers.APIError) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("user_role")
 		if !exists {
-			helpers.ForbiddenResponse(c, consts.MSG_FORBIDDEN)
-			c.Abort()
+			helpers.AbortWithError(c, helpers.ErrForbidden, nil)
 			return
 		}
 
@@ -106,19 +112,18 @@ func roleMiddleware(allowedRoles []string, message string) gin.HandlerFunc {
 			}
 		}
 
-		helpers.ForbiddenResponse(c, message)
-		c.Abort()
+		helpers.AbortWithError(c, apiErr, nil)
 	}
 }
 
 // SuperAdminMiddleware - Chỉ dành cho super admin
 func SuperAdminMiddleware() gin.HandlerFunc {
-	return roleMiddleware([]string{"super_admin"}, "Yêu cầu quyền Super Admin")
+	return roleMiddleware([]string{"super_admin"}, helpers.ErrSuperAdminRequired)
 }
 
 // AdminMiddleware - Dành cho cả super admin và admin
 func AdminMiddleware() gin.HandlerFunc {
-	return roleMiddleware([]string{"super_admin", "admin"}, "Yêu cầu quyền Admin")
+	return roleMiddleware([]string{"super_admin", "admin"}, helpers.ErrAdminRequired)
 }
 
 // OwnerMiddleware - deprecated, giữ lại để tương thích
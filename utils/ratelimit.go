@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"backend/internal/helpers"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateLimitEntry struct {
+	count   int
+	resetAt time.Time
+}
+
+// RateLimitMiddleware giới hạn số request theo IP trong một khoảng thời gian (fixed window, lưu trong bộ nhớ)
+// name dùng để tách bộ đếm giữa các endpoint khác nhau. IP lấy từ ClientIP, chỉ đọc header X-Forwarded-For
+// của proxy tin cậy (TRUSTED_PROXIES, xem cmd/main.go)
+func RateLimitMiddleware(name string, limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	entries := map[string]*rateLimitEntry{}
+	lastCleanup := time.Now()
+
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		key := name + ":" + c.ClientIP()
+
+		mu.Lock()
+		// Dọn các bộ đếm đã hết hạn để map không phình mãi
+		if now.Sub(lastCleanup) > window {
+			for k, e := range entries {
+				if now.After(e.resetAt) {
+					delete(entries, k)
+				}
+			}
+			lastCleanup = now
+		}
+
+		entry, ok := entries[key]
+		if !ok || now.After(entry.resetAt) {
+			entry = &rateLimitEntry{resetAt: now.Add(window)}
+			entries[key] = entry
+		}
+		entry.count++
+		count, resetAt := entry.count, entry.resetAt
+		mu.Unlock()
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(math.Max(0, float64(limit-count)))))
+
+		if count > limit {
+			retryAfter := int(math.Ceil(resetAt.Sub(now).Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			helpers.AbortWithError(c, helpers.ErrTooManyRequests, fmt.Errorf("thử lại sau %d giây", retryAfter))
+			return
+		}
+
+		c.Next()
+	}
+}